@@ -1,9 +1,16 @@
 package server
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	_ "app/docs"
+	"app/internal/config"
 	"app/internal/router"
 
 	"github.com/goccy/go-json"
@@ -13,11 +20,22 @@ import (
 	"github.com/gofiber/swagger"
 )
 
-func Serve() {
+// shutdownTimeout bounds how long Serve waits for requests in flight.
+const shutdownTimeout = 30 * time.Second
+
+// Serve runs the API until SIGINT or SIGTERM, then stops accepting
+// connections and waits for requests in flight before returning, so the
+// caller's deferred shutdown of background workers runs. It returns an
+// error only when the server could not run.
+func Serve() error {
 	app := fiber.New(fiber.Config{
-		DisableStartupMessage: true,
-		JSONEncoder:           json.Marshal,
-		JSONDecoder:           json.Unmarshal,
+		DisableStartupMessage:   true,
+		JSONEncoder:             json.Marshal,
+		JSONDecoder:             json.Unmarshal,
+		ProxyHeader:             config.ProxyHeader,
+		EnableTrustedProxyCheck: len(config.TrustedProxies) > 0,
+		TrustedProxies:          config.TrustedProxies,
+		BodyLimit:               config.UploadMaxSize*config.UploadMaxFiles + 1<<20,
 	})
 
 	app.Use(logger.New())
@@ -39,7 +57,35 @@ func Serve() {
 		})
 	})
 
+	// Local uploads are served from their stored names under /media, so
+	// MEDIA_URL is the address of this API.
+	if config.StorageDriver == "local" {
+		app.Static("/media", filepath.Join(config.StorageLocalDir, "media"), fiber.Static{
+			ByteRange: true,
+			MaxAge:    365 * 24 * 60 * 60,
+		})
+	}
+
 	router.Init(app)
-	log.Println("Server started on port 8080")
-	log.Fatal(app.Listen(":8080"))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errs := make(chan error, 1)
+	go func() {
+		log.Println("Server started on port 8080")
+		errs <- app.Listen(":8080")
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	log.Println("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
+		log.Printf("server: shutdown: %v", err)
+	}
+	return nil
 }
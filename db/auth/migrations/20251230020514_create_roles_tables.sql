@@ -0,0 +1,94 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS permissions (
+  code TEXT PRIMARY KEY,
+  name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+  id BIGSERIAL PRIMARY KEY,
+  code TEXT NOT NULL UNIQUE,
+  name TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+  role_id BIGINT NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+  permission TEXT NOT NULL REFERENCES permissions (code) ON DELETE CASCADE,
+  PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  role_id BIGINT NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+  PRIMARY KEY (user_id, role_id)
+);
+
+INSERT INTO
+  permissions (code, name)
+VALUES
+  ('catalog.manage', 'Manage products, categories, collections, attributes, reviews and questions'),
+  ('orders.manage', 'Manage orders and shipping fees'),
+  ('customers.manage', 'Manage customers'),
+  ('content.manage', 'Manage posts, pages, menus, files and redirects'),
+  ('marketing.manage', 'Manage discounts and product feeds'),
+  ('reports.view', 'View reports'),
+  ('users.manage', 'Manage admin users and roles');
+
+INSERT INTO
+  roles (code, name)
+VALUES
+  ('owner', 'Owner'),
+  ('staff', 'Staff'),
+  ('content_editor', 'Content editor'),
+  ('warehouse', 'Warehouse');
+
+INSERT INTO
+  role_permissions (role_id, permission)
+SELECT
+  r.id,
+  p.code
+FROM
+  roles r
+  JOIN permissions p ON r.code = 'owner'
+  OR (
+    r.code = 'staff'
+    AND p.code IN (
+      'catalog.manage',
+      'orders.manage',
+      'customers.manage',
+      'marketing.manage',
+      'reports.view'
+    )
+  )
+  OR (
+    r.code = 'content_editor'
+    AND p.code = 'content.manage'
+  )
+  OR (
+    r.code = 'warehouse'
+    AND p.code = 'orders.manage'
+  );
+
+-- Existing admin accounts keep full access.
+INSERT INTO
+  user_roles (user_id, role_id)
+SELECT
+  u.id,
+  r.id
+FROM
+  users u
+  JOIN roles r ON r.code = 'owner';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_roles CASCADE;
+
+DROP TABLE IF EXISTS role_permissions CASCADE;
+
+DROP TABLE IF EXISTS roles CASCADE;
+
+DROP TABLE IF EXISTS permissions CASCADE;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS login_attempts (
+  key TEXT PRIMARY KEY,
+  failures INT NOT NULL DEFAULT 0,
+  last_failed_at TIMESTAMPTZ NOT NULL,
+  previous_failed_at TIMESTAMPTZ, -- last_failed_at before the latest failure
+  locked_until TIMESTAMPTZ
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS login_attempts CASCADE;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sessions (
+  id BIGSERIAL PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  user_agent TEXT NOT NULL,
+  ip TEXT NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Refresh tokens issued before sessions existed belong to no session; their
+-- users sign in again.
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens
+ADD COLUMN session_id BIGINT NOT NULL REFERENCES sessions (id) ON DELETE CASCADE;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+DROP COLUMN IF EXISTS session_id;
+
+DROP TABLE IF EXISTS sessions CASCADE;
+
+-- +goose StatementEnd
@@ -0,0 +1,70 @@
+-- name: CreateApiKey :one
+INSERT INTO
+  api_keys (
+    name,
+    prefix,
+    key_hash,
+    permissions,
+    created_by,
+    expires_at
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6)
+RETURNING
+  id,
+  created_at;
+
+-- name: GetActiveApiKeyByPrefix :one
+SELECT
+  id,
+  key_hash,
+  permissions,
+  created_by
+FROM
+  api_keys
+WHERE
+  prefix = $1
+  AND revoked_at IS NULL
+  AND (
+    expires_at IS NULL
+    OR expires_at > CURRENT_TIMESTAMP
+  )
+LIMIT
+  1;
+
+-- name: TouchApiKey :exec
+UPDATE api_keys
+SET
+  last_used_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1
+  AND (
+    last_used_at IS NULL
+    OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute'
+  );
+
+-- name: GetApiKeys :many
+SELECT
+  k.id,
+  k.name,
+  k.prefix,
+  k.permissions,
+  k.created_by,
+  u.name AS created_by_name,
+  k.expires_at,
+  k.last_used_at,
+  k.revoked_at,
+  k.created_at
+FROM
+  api_keys k
+  JOIN users u ON u.id = k.created_by
+ORDER BY
+  k.created_at DESC;
+
+-- name: RevokeApiKey :execrows
+UPDATE api_keys
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1
+  AND revoked_at IS NULL;
@@ -0,0 +1,102 @@
+-- name: CreateAuditLog :exec
+INSERT INTO
+  audit_logs (
+    actor_id,
+    action,
+    entity_type,
+    entity_id,
+    method,
+    path,
+    status,
+    before,
+    after,
+    ip
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: CountAuditLogs :one
+SELECT
+  COUNT(*)
+FROM
+  audit_logs
+WHERE
+  (
+    sqlc.narg (actor_id)::uuid IS NULL
+    OR actor_id = sqlc.narg (actor_id)
+  )
+  AND (
+    sqlc.narg (action)::text IS NULL
+    OR action = sqlc.narg (action)
+  )
+  AND (
+    sqlc.narg (entity_type)::text IS NULL
+    OR entity_type = sqlc.narg (entity_type)
+  )
+  AND (
+    sqlc.narg (entity_id)::text IS NULL
+    OR entity_id = sqlc.narg (entity_id)
+  )
+  AND (
+    sqlc.narg (created_from)::timestamptz IS NULL
+    OR created_at >= sqlc.narg (created_from)
+  )
+  AND (
+    sqlc.narg (created_to)::timestamptz IS NULL
+    OR created_at < sqlc.narg (created_to)
+  );
+
+-- name: GetAuditLogs :many
+SELECT
+  a.id,
+  a.actor_id,
+  u.name AS actor_name,
+  a.action,
+  a.entity_type,
+  a.entity_id,
+  a.method,
+  a.path,
+  a.status,
+  a.before,
+  a.after,
+  a.ip,
+  a.created_at
+FROM
+  audit_logs a
+  LEFT JOIN users u ON u.id = a.actor_id
+WHERE
+  (
+    sqlc.narg (actor_id)::uuid IS NULL
+    OR a.actor_id = sqlc.narg (actor_id)
+  )
+  AND (
+    sqlc.narg (action)::text IS NULL
+    OR a.action = sqlc.narg (action)
+  )
+  AND (
+    sqlc.narg (entity_type)::text IS NULL
+    OR a.entity_type = sqlc.narg (entity_type)
+  )
+  AND (
+    sqlc.narg (entity_id)::text IS NULL
+    OR a.entity_id = sqlc.narg (entity_id)
+  )
+  AND (
+    sqlc.narg (created_from)::timestamptz IS NULL
+    OR a.created_at >= sqlc.narg (created_from)
+  )
+  AND (
+    sqlc.narg (created_to)::timestamptz IS NULL
+    OR a.created_at < sqlc.narg (created_to)
+  )
+ORDER BY
+  a.id DESC
+LIMIT
+  sqlc.arg (limit_count)
+OFFSET
+  sqlc.arg (offset_count);
+
+-- name: DeleteAuditLogsBefore :exec
+DELETE FROM audit_logs
+WHERE
+  created_at < $1;
@@ -0,0 +1,61 @@
+-- name: RecordLoginFailure :one
+INSERT INTO
+  login_attempts (key, failures, last_failed_at)
+VALUES
+  (@key, 1, @now::timestamptz)
+ON CONFLICT (key) DO UPDATE
+SET
+  failures = CASE
+    WHEN login_attempts.last_failed_at < @window_start::timestamptz THEN 1
+    ELSE login_attempts.failures + 1
+  END,
+  last_failed_at = @now::timestamptz,
+  previous_failed_at = login_attempts.last_failed_at
+RETURNING
+  key,
+  failures,
+  last_failed_at,
+  previous_failed_at,
+  locked_until;
+
+-- name: ForgiveLoginFailure :exec
+UPDATE login_attempts
+SET
+  failures = GREATEST(failures - 1, 0)
+WHERE
+  key = $1;
+
+-- name: LockLoginAttempt :exec
+UPDATE login_attempts
+SET
+  locked_until = $2
+WHERE
+  key = $1;
+
+-- name: GetLockedLoginAttempts :many
+SELECT
+  key,
+  failures,
+  last_failed_at,
+  previous_failed_at,
+  locked_until
+FROM
+  login_attempts
+WHERE
+  locked_until > @now::timestamptz
+ORDER BY
+  locked_until DESC;
+
+-- name: DeleteLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE
+  key = ANY (@keys::text[]);
+
+-- name: DeleteStaleLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE
+  last_failed_at < @before::timestamptz
+  AND (
+    locked_until IS NULL
+    OR locked_until < @before::timestamptz
+  );
@@ -0,0 +1,21 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO
+  refresh_tokens (user_id, session_id, token_hash, expires_at)
+VALUES
+  ($1, $2, $3, $4);
+
+-- name: RevokeRefreshToken :one
+UPDATE refresh_tokens t
+SET
+  revoked_at = CURRENT_TIMESTAMP
+FROM
+  sessions s
+WHERE
+  t.token_hash = $1
+  AND t.revoked_at IS NULL
+  AND t.expires_at > CURRENT_TIMESTAMP
+  AND s.id = t.session_id
+  AND s.revoked_at IS NULL
+RETURNING
+  t.user_id,
+  t.session_id;
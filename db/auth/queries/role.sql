@@ -0,0 +1,102 @@
+-- name: GetPermissions :many
+SELECT
+  code,
+  name
+FROM
+  permissions
+ORDER BY
+  code;
+
+-- name: GetRoles :many
+SELECT
+  r.id,
+  r.code,
+  r.name,
+  COALESCE(
+    array_agg(
+      rp.permission
+      ORDER BY
+        rp.permission
+    ) FILTER (
+      WHERE
+        rp.permission IS NOT NULL
+    ),
+    '{}'
+  )::text[] AS permissions
+FROM
+  roles r
+  LEFT JOIN role_permissions rp ON rp.role_id = r.id
+GROUP BY
+  r.id
+ORDER BY
+  r.id;
+
+-- name: CreateRole :one
+INSERT INTO
+  roles (code, name)
+VALUES
+  ($1, $2)
+RETURNING
+  id;
+
+-- name: UpdateRole :exec
+UPDATE roles
+SET
+  code = $2,
+  name = $3
+WHERE
+  id = $1;
+
+-- name: BulkDeleteRoles :exec
+DELETE FROM roles
+WHERE
+  id = ANY ($1::bigint[])
+  AND code <> 'owner';
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions
+WHERE
+  role_id = $1;
+
+-- name: InsertRolePermissions :exec
+INSERT INTO
+  role_permissions (role_id, permission)
+SELECT
+  sqlc.arg(role_id)::bigint,
+  unnest(sqlc.arg(permissions)::text[]);
+
+-- name: GetRolesByUser :many
+SELECT
+  r.id,
+  r.code,
+  r.name
+FROM
+  user_roles ur
+  JOIN roles r ON r.id = ur.role_id
+WHERE
+  ur.user_id = $1
+ORDER BY
+  r.id;
+
+-- name: DeleteUserRoles :exec
+DELETE FROM user_roles
+WHERE
+  user_id = $1;
+
+-- name: InsertUserRoles :exec
+INSERT INTO
+  user_roles (user_id, role_id)
+SELECT
+  sqlc.arg(user_id)::uuid,
+  unnest(sqlc.arg(role_ids)::bigint[]);
+
+-- name: GetPermissionsByUser :many
+SELECT DISTINCT
+  rp.permission
+FROM
+  user_roles ur
+  JOIN role_permissions rp ON rp.role_id = ur.role_id
+WHERE
+  ur.user_id = $1
+ORDER BY
+  rp.permission;
@@ -0,0 +1,79 @@
+-- name: CreateSession :one
+INSERT INTO
+  sessions (user_id, user_agent, ip, expires_at)
+VALUES
+  ($1, $2, $3, $4)
+RETURNING
+  id;
+
+-- name: GetActiveSession :one
+SELECT
+  last_seen_at
+FROM
+  sessions
+WHERE
+  id = $1
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+LIMIT
+  1;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET
+  last_seen_at = CURRENT_TIMESTAMP,
+  ip = $2
+WHERE
+  id = $1;
+
+-- name: ExtendSession :exec
+UPDATE sessions
+SET
+  last_seen_at = CURRENT_TIMESTAMP,
+  ip = $2,
+  expires_at = $3
+WHERE
+  id = $1;
+
+-- name: GetSessionsByUser :many
+SELECT
+  id,
+  user_agent,
+  ip,
+  last_seen_at,
+  expires_at,
+  created_at
+FROM
+  sessions
+WHERE
+  user_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+ORDER BY
+  last_seen_at DESC;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL;
+
+-- name: RevokeOtherSessions :execrows
+UPDATE sessions
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  user_id = @user_id
+  AND id <> @keep_id::bigint
+  AND revoked_at IS NULL;
+
+-- name: RevokeSessionsByUser :exec
+UPDATE sessions
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  user_id = $1
+  AND revoked_at IS NULL;
@@ -0,0 +1,16 @@
+-- name: GetSetting :one
+SELECT
+  value
+FROM
+  settings
+WHERE
+  key = $1;
+
+-- name: UpsertSetting :exec
+INSERT INTO
+  settings (key, value)
+VALUES
+  ($1, $2)
+ON CONFLICT (key) DO UPDATE
+SET
+  value = EXCLUDED.value;
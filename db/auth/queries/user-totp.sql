@@ -0,0 +1,80 @@
+-- name: GetUserTotp :one
+SELECT
+  id,
+  name,
+  email,
+  username,
+  totp_secret,
+  totp_enabled
+FROM
+  users
+WHERE
+  id = $1
+LIMIT
+  1;
+
+-- name: SetUserTotpSecret :exec
+UPDATE users
+SET
+  totp_secret = $2,
+  totp_enabled = FALSE,
+  totp_last_step = NULL
+WHERE
+  id = $1;
+
+-- name: EnableUserTotp :exec
+UPDATE users
+SET
+  totp_enabled = TRUE
+WHERE
+  id = $1;
+
+-- name: DisableUserTotp :exec
+UPDATE users
+SET
+  totp_secret = NULL,
+  totp_enabled = FALSE,
+  totp_last_step = NULL
+WHERE
+  id = $1;
+
+-- name: UseUserTotpStep :execrows
+UPDATE users
+SET
+  totp_last_step = @step::bigint
+WHERE
+  id = @id
+  AND (
+    totp_last_step IS NULL
+    OR totp_last_step < @step::bigint
+  );
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE
+  user_id = $1;
+
+-- name: InsertRecoveryCodes :exec
+INSERT INTO
+  user_recovery_codes (user_id, code_hash)
+SELECT
+  @user_id::uuid,
+  unnest(@code_hashes::text[]);
+
+-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET
+  used_at = CURRENT_TIMESTAMP
+WHERE
+  user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL;
+
+-- name: CountRecoveryCodes :one
+SELECT
+  COUNT(*)
+FROM
+  user_recovery_codes
+WHERE
+  user_id = $1
+  AND used_at IS NULL;
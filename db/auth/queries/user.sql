@@ -35,7 +35,8 @@ SELECT
   phone,
   email,
   username,
-  password
+  password,
+  totp_enabled
 FROM
   users
 WHERE
@@ -49,3 +50,12 @@ LIMIT
 DELETE FROM users
 WHERE
   id = ANY ($1::bigint[]);
+
+-- name: GetUserNames :many
+SELECT
+  id,
+  name
+FROM
+  users
+WHERE
+  id = ANY (@ids::uuid[]);
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS post_redirects (
+  id BIGSERIAL PRIMARY KEY,
+  from_slug TEXT UNIQUE NOT NULL,
+  to_slug TEXT NOT NULL,
+  status_code INT NOT NULL DEFAULT 301,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS post_redirects CASCADE;
+-- +goose StatementEnd
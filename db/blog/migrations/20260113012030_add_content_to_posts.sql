@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS post_categories (
+  id BIGSERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  slug TEXT UNIQUE NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE posts
+ADD COLUMN body TEXT NOT NULL DEFAULT '',
+ADD COLUMN body_format TEXT NOT NULL DEFAULT 'html',
+ADD COLUMN excerpt TEXT NOT NULL DEFAULT '',
+ADD COLUMN author_id UUID,
+ADD COLUMN category_id BIGINT REFERENCES post_categories (id) ON DELETE SET NULL,
+ADD COLUMN reading_time INT NOT NULL DEFAULT 0,
+ADD COLUMN published_at TIMESTAMP;
+
+UPDATE posts
+SET
+  published_at = created_at;
+
+ALTER TABLE post_tags
+ADD PRIMARY KEY (post_id, name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE post_tags
+DROP CONSTRAINT IF EXISTS post_tags_pkey;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS body,
+DROP COLUMN IF EXISTS body_format,
+DROP COLUMN IF EXISTS excerpt,
+DROP COLUMN IF EXISTS author_id,
+DROP COLUMN IF EXISTS category_id,
+DROP COLUMN IF EXISTS reading_time,
+DROP COLUMN IF EXISTS published_at;
+
+DROP TABLE IF EXISTS post_categories;
+-- +goose StatementEnd
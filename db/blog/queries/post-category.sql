@@ -0,0 +1,49 @@
+-- name: GetPostCategories :many
+SELECT
+  c.id,
+  c.name,
+  c.slug,
+  c.description,
+  COUNT(p.id) AS post_count
+FROM
+  post_categories c
+  LEFT JOIN posts p ON p.category_id = c.id
+  AND (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+GROUP BY
+  c.id
+ORDER BY
+  c.name;
+
+-- name: GetPostCategory :one
+SELECT
+  id,
+  name,
+  slug,
+  description
+FROM
+  post_categories
+WHERE
+  id = $1;
+
+-- name: CreatePostCategory :one
+INSERT INTO
+  post_categories (name, slug, description)
+VALUES
+  ($1, $2, $3)
+RETURNING
+  id;
+
+-- name: UpdatePostCategory :execrows
+UPDATE post_categories
+SET
+  name = $2,
+  slug = $3,
+  description = $4
+WHERE
+  id = $1;
+
+-- name: BulkDeletePostCategories :exec
+DELETE FROM post_categories
+WHERE
+  id = ANY (@ids::bigint[]);
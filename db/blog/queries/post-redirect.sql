@@ -0,0 +1,87 @@
+-- name: CountPostRedirects :one
+SELECT
+  COUNT(*)
+FROM
+  post_redirects;
+
+-- name: GetPostRedirects :many
+SELECT
+  id,
+  from_slug,
+  to_slug,
+  status_code,
+  created_at
+FROM
+  post_redirects
+ORDER BY
+  created_at DESC
+LIMIT
+  $1
+OFFSET
+  $2;
+
+-- name: GetPostRedirect :one
+SELECT
+  id,
+  from_slug,
+  to_slug,
+  status_code
+FROM
+  post_redirects
+WHERE
+  id = $1;
+
+-- name: GetPostRedirectBySlug :one
+SELECT
+  to_slug,
+  status_code
+FROM
+  post_redirects
+WHERE
+  from_slug = $1;
+
+-- name: CreatePostRedirect :one
+INSERT INTO
+  post_redirects (from_slug, to_slug, status_code)
+VALUES
+  ($1, $2, $3)
+RETURNING
+  id;
+
+-- name: UpdatePostRedirect :exec
+UPDATE post_redirects
+SET
+  from_slug = $2,
+  to_slug = $3,
+  status_code = $4,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1;
+
+-- name: UpsertPostRedirect :exec
+INSERT INTO
+  post_redirects (from_slug, to_slug)
+VALUES
+  ($1, $2)
+ON CONFLICT (from_slug) DO UPDATE
+SET
+  to_slug = EXCLUDED.to_slug,
+  updated_at = CURRENT_TIMESTAMP;
+
+-- name: RetargetPostRedirects :exec
+UPDATE post_redirects
+SET
+  to_slug = @new_slug,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  to_slug = @old_slug;
+
+-- name: DeletePostRedirectBySlug :exec
+DELETE FROM post_redirects
+WHERE
+  from_slug = $1;
+
+-- name: BulkDeletePostRedirects :exec
+DELETE FROM post_redirects
+WHERE
+  id = ANY ($1::bigint[]);
@@ -8,53 +8,345 @@ FROM
 SELECT
   COUNT(*)
 FROM
-  posts
-WHERE is_active = true;
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+  AND (
+    sqlc.narg(tag)::text IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        post_tags t
+      WHERE
+        t.post_id = p.id
+        AND t.name = sqlc.narg(tag)
+    )
+  )
+  AND (
+    sqlc.narg(category)::text IS NULL
+    OR c.slug = sqlc.narg(category)
+  );
 
 -- name: GetPosts :many
-SELECT id, title, slug, file
+SELECT id, title, slug, file, is_active, is_scheduled, published_at
 FROM posts
+ORDER BY published_at DESC NULLS LAST, id DESC
 LIMIT
   $1
 OFFSET
   $2;
 
 -- name: GetPublicPosts :many
-SELECT id, title, slug, file, meta_title, meta_description, created_at
-FROM posts
-WHERE is_active = true
+SELECT
+  p.id,
+  p.title,
+  p.slug,
+  p.file,
+  p.excerpt,
+  p.reading_time,
+  p.author_id,
+  p.meta_title,
+  p.meta_description,
+  c.name AS category_name,
+  c.slug AS category_slug,
+  ARRAY(
+    SELECT
+      t.name
+    FROM
+      post_tags t
+    WHERE
+      t.post_id = p.id
+    ORDER BY
+      t.name
+  )::text[] AS tags,
+  p.published_at,
+  p.created_at
+FROM
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+  AND (
+    sqlc.narg(tag)::text IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        post_tags t
+      WHERE
+        t.post_id = p.id
+        AND t.name = sqlc.narg(tag)
+    )
+  )
+  AND (
+    sqlc.narg(category)::text IS NULL
+    OR c.slug = sqlc.narg(category)
+  )
+ORDER BY
+  p.published_at DESC NULLS LAST,
+  p.id DESC
 LIMIT
-  $1
+  @limit_count
 OFFSET
-  $2;
+  @offset_count;
 
 -- name: GetPost :one
-SELECT id, title, slug, file
-FROM posts
-WHERE id = $1;
+SELECT
+  id,
+  title,
+  slug,
+  file,
+  body,
+  body_format,
+  excerpt,
+  author_id,
+  category_id,
+  reading_time,
+  is_active,
+  is_scheduled,
+  ARRAY(
+    SELECT
+      t.name
+    FROM
+      post_tags t
+    WHERE
+      t.post_id = posts.id
+    ORDER BY
+      t.name
+  )::text[] AS tags,
+  published_at
+FROM
+  posts
+WHERE
+  id = $1;
 
 -- name: GetPostBySlug :one
-SELECT id, title, slug, file, meta_title, meta_description, created_at
-FROM posts
-WHERE slug = $1;
+SELECT
+  p.id,
+  p.title,
+  p.slug,
+  p.file,
+  p.body,
+  p.body_format,
+  p.excerpt,
+  p.reading_time,
+  p.author_id,
+  p.meta_title,
+  p.meta_description,
+  c.name AS category_name,
+  c.slug AS category_slug,
+  ARRAY(
+    SELECT
+      t.name
+    FROM
+      post_tags t
+    WHERE
+      t.post_id = p.id
+    ORDER BY
+      t.name
+  )::text[] AS tags,
+  p.published_at,
+  p.created_at
+FROM
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  p.slug = $1
+  AND (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW();
 
 -- name: CreatePost :one
 INSERT INTO
-  posts (title, slug, file)
+  posts (
+    title,
+    slug,
+    file,
+    body,
+    body_format,
+    excerpt,
+    author_id,
+    category_id,
+    reading_time,
+    published_at,
+    is_active,
+    is_scheduled
+  )
 VALUES
-  ($1, $2, $3)
+  (
+    @title,
+    @slug,
+    @file,
+    @body,
+    @body_format,
+    @excerpt,
+    @author_id,
+    @category_id,
+    @reading_time,
+    COALESCE(sqlc.narg(published_at)::timestamptz, NOW()),
+    COALESCE(sqlc.narg(published_at)::timestamptz <= NOW(), true),
+    COALESCE(sqlc.narg(published_at)::timestamptz > NOW(), false)
+  )
 RETURNING id;
 
 -- name: UpdatePost :exec
+-- Without published_at the schedule is left as is. A future date schedules
+-- the post; a past one publishes a scheduled post now.
 UPDATE posts
 SET
-  title = $2,
-  slug = $3,
-  file = $4
+  title = @title,
+  slug = @slug,
+  file = @file,
+  body = @body,
+  body_format = @body_format,
+  excerpt = @excerpt,
+  author_id = @author_id,
+  category_id = @category_id,
+  reading_time = @reading_time,
+  published_at = COALESCE(sqlc.narg(published_at)::timestamptz, published_at),
+  is_active = CASE
+    WHEN sqlc.narg(published_at)::timestamptz IS NULL THEN is_active
+    WHEN sqlc.narg(published_at)::timestamptz > NOW() THEN false
+    WHEN is_scheduled THEN true
+    ELSE is_active
+  END,
+  is_scheduled = COALESCE(sqlc.narg(published_at)::timestamptz > NOW(), is_scheduled),
+  updated_at = NOW()
 WHERE
-  id = $1;
+  id = @id;
+
+-- name: DeletePostTags :exec
+DELETE FROM post_tags
+WHERE
+  post_id = $1;
+
+-- name: InsertPostTags :exec
+INSERT INTO
+  post_tags (post_id, name)
+SELECT
+  @post_id,
+  unnest(@names::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: GetPostTags :many
+SELECT
+  t.name,
+  COUNT(*) AS post_count
+FROM
+  post_tags t
+  JOIN posts p ON p.id = t.post_id
+WHERE
+  (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+GROUP BY
+  t.name
+ORDER BY
+  t.name;
 
 -- name: BulkDeletePosts :exec
 DELETE FROM posts
 WHERE
   id = ANY ($1::bigint[]);
+
+-- name: GetPostSeoBySlug :one
+SELECT
+  id,
+  title,
+  slug,
+  file,
+  meta_title,
+  meta_description,
+  canonical_url,
+  og_title,
+  og_description,
+  og_image,
+  published_at,
+  created_at,
+  updated_at
+FROM
+  posts
+WHERE
+  slug = $1
+  AND (is_active OR is_scheduled)
+  AND published_at <= NOW();
+
+-- name: GetPostFileUsages :many
+SELECT
+  id,
+  title,
+  'og_image' AS field,
+  og_image AS file_name
+FROM
+  posts
+WHERE
+  og_image = ANY (@names::text[])
+UNION ALL
+SELECT
+  id,
+  title,
+  'file',
+  file
+FROM
+  posts
+WHERE
+  file = ANY (@names::text[])
+UNION ALL
+SELECT
+  p.id,
+  p.title,
+  'body',
+  n.name
+FROM
+  posts p
+  -- Matching without the extension also finds renditions of the file.
+  JOIN unnest(@names::text[]) AS n (name) ON strpos(p.body, regexp_replace(n.name, '\.[^./]*$', '')) > 0;
+
+-- name: GetPostLinks :many
+SELECT
+  id,
+  slug,
+  (
+    (is_active OR is_scheduled)
+    AND published_at <= NOW()
+  )::boolean AS is_active
+FROM
+  posts
+WHERE
+  id = ANY (@ids::bigint[]);
+
+-- name: GetScheduledPosts :many
+SELECT
+  p.id,
+  p.title,
+  p.slug,
+  p.author_id,
+  c.name AS category_name,
+  p.published_at::date AS publish_date,
+  p.published_at
+FROM
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  p.is_scheduled = true
+  AND p.published_at >= @from_date::date
+  AND p.published_at < @to_date::date + 1
+ORDER BY
+  p.published_at,
+  p.id;
+
+-- name: PublishDuePosts :many
+UPDATE posts
+SET
+  is_active = true,
+  is_scheduled = false,
+  updated_at = NOW()
+WHERE
+  is_scheduled = true
+  AND published_at <= NOW()
+RETURNING
+  id,
+  slug,
+  published_at;
@@ -1,3 +1,11 @@
+CREATE TABLE IF NOT EXISTS post_categories (
+  id BIGSERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  slug TEXT UNIQUE NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE IF NOT EXISTS posts (
   id BIGSERIAL PRIMARY KEY,
   title TEXT NOT NULL,
@@ -10,12 +18,30 @@ CREATE TABLE IF NOT EXISTS posts (
   og_description TEXT NOT NULL DEFAULT '',
   og_image TEXT NOT NULL DEFAULT '',
   file TEXT,
+  body TEXT NOT NULL DEFAULT '', -- sanitized HTML, or a JSON block list as pages use
+  body_format TEXT NOT NULL DEFAULT 'html', -- 'html' | 'blocks'
+  excerpt TEXT NOT NULL DEFAULT '',
+  author_id UUID, -- users in the auth database
+  category_id BIGINT REFERENCES post_categories (id) ON DELETE SET NULL,
+  reading_time INT NOT NULL DEFAULT 0, -- minutes
   is_active BOOLEAN NOT NULL DEFAULT TRUE,
+  is_scheduled BOOLEAN NOT NULL DEFAULT FALSE, -- public from published_at on; the publisher then makes it active
+  published_at TIMESTAMP,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE IF NOT EXISTS post_tags (
   name TEXT NOT NULL,
-  post_id BIGINT NOT NULL REFERENCES posts (id) ON DELETE CASCADE
+  post_id BIGINT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+  PRIMARY KEY (post_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS post_redirects (
+  id BIGSERIAL PRIMARY KEY,
+  from_slug TEXT UNIQUE NOT NULL,
+  to_slug TEXT NOT NULL,
+  status_code INT NOT NULL DEFAULT 301,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 );
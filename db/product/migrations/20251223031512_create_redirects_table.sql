@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS redirects (
+  id BIGSERIAL PRIMARY KEY,
+  entity_type TEXT NOT NULL, -- 'product' | 'collection' | 'page'; post redirects live in the blog database
+  from_slug TEXT NOT NULL,
+  to_slug TEXT NOT NULL,
+  status_code INT NOT NULL DEFAULT 301,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (entity_type, from_slug)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS redirects CASCADE;
+-- +goose StatementEnd
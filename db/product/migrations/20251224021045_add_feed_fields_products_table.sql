@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products
+ADD COLUMN brand TEXT NOT NULL DEFAULT '',
+ADD COLUMN gtin TEXT NOT NULL DEFAULT '',
+ADD COLUMN exclude_from_feeds BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE products
+DROP COLUMN IF EXISTS brand,
+DROP COLUMN IF EXISTS gtin,
+DROP COLUMN IF EXISTS exclude_from_feeds;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wishlist_items (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  variant_id BIGINT REFERENCES variants (id) ON DELETE CASCADE,
+  notify_price_drop BOOLEAN NOT NULL DEFAULT FALSE,
+  last_price INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE NULLS NOT DISTINCT (customer_id, product_id, variant_id)
+);
+
+CREATE TABLE IF NOT EXISTS wishlist_price_drops (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  variant_id BIGINT REFERENCES variants (id) ON DELETE CASCADE,
+  old_price INT NOT NULL,
+  new_price INT NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS wishlist_price_drops CASCADE;
+
+DROP TABLE IF EXISTS wishlist_items CASCADE;
+-- +goose StatementEnd
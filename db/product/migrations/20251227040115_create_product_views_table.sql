@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_views (
+  id BIGSERIAL PRIMARY KEY,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  customer_id BIGINT REFERENCES customers (id) ON DELETE SET NULL,
+  session_id TEXT,
+  viewed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS product_views_customer_id_viewed_at_idx ON product_views (customer_id, viewed_at DESC);
+
+CREATE INDEX IF NOT EXISTS product_views_product_id_viewed_at_idx ON product_views (product_id, viewed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_views CASCADE;
+-- +goose StatementEnd
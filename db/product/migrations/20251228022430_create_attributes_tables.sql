@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS attributes (
+  id BIGSERIAL PRIMARY KEY,
+  code TEXT UNIQUE NOT NULL,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT 'text', -- 'text' | 'number' | 'enum' | 'boolean'
+  unit TEXT NOT NULL DEFAULT '',
+  options TEXT[] NOT NULL DEFAULT '{}',
+  is_filterable BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS category_attributes (
+  category_id BIGINT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+  attribute_id BIGINT NOT NULL REFERENCES attributes (id) ON DELETE CASCADE,
+  is_required BOOLEAN NOT NULL DEFAULT FALSE,
+  no INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (category_id, attribute_id)
+);
+
+CREATE TABLE IF NOT EXISTS product_attribute_values (
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  attribute_id BIGINT NOT NULL REFERENCES attributes (id) ON DELETE CASCADE,
+  value TEXT NOT NULL,
+  value_number DOUBLE PRECISION,
+  PRIMARY KEY (product_id, attribute_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_attribute_values CASCADE;
+
+DROP TABLE IF EXISTS category_attributes CASCADE;
+
+DROP TABLE IF EXISTS attributes CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS customer_otps (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  purpose TEXT NOT NULL,
+  code_hash TEXT NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS otp_deliveries (
+  id BIGSERIAL PRIMARY KEY,
+  otp_id BIGINT NOT NULL REFERENCES customer_otps (id) ON DELETE CASCADE,
+  channel TEXT NOT NULL,
+  provider TEXT NOT NULL,
+  recipient TEXT NOT NULL,
+  status TEXT NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE customers
+DROP COLUMN IF EXISTS zns_otp;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE customers
+ADD COLUMN zns_otp TEXT;
+
+DROP TABLE IF EXISTS otp_deliveries CASCADE;
+
+DROP TABLE IF EXISTS customer_otps CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS customer_sessions (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  user_agent TEXT NOT NULL,
+  ip TEXT NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Refresh tokens issued before sessions existed belong to no session; their
+-- customers sign in again.
+DELETE FROM customer_refresh_tokens;
+
+ALTER TABLE customer_refresh_tokens
+ADD COLUMN session_id BIGINT NOT NULL REFERENCES customer_sessions (id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE customer_refresh_tokens
+DROP COLUMN IF EXISTS session_id;
+
+DROP TABLE IF EXISTS customer_sessions CASCADE;
+-- +goose StatementEnd
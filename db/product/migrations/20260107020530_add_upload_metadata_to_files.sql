@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE files
+ADD COLUMN hash TEXT UNIQUE,
+ADD COLUMN size BIGINT,
+ADD COLUMN content_type TEXT,
+ADD COLUMN original_name TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE files
+DROP COLUMN IF EXISTS hash,
+DROP COLUMN IF EXISTS size,
+DROP COLUMN IF EXISTS content_type,
+DROP COLUMN IF EXISTS original_name;
+-- +goose StatementEnd
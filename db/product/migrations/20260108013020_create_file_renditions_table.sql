@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE files
+ADD COLUMN width INT,
+ADD COLUMN height INT,
+ADD COLUMN blurhash TEXT;
+
+CREATE TABLE IF NOT EXISTS file_renditions (
+  id BIGSERIAL PRIMARY KEY,
+  file_id BIGINT NOT NULL REFERENCES files (id) ON DELETE CASCADE,
+  name TEXT UNIQUE NOT NULL,
+  size_name TEXT NOT NULL,
+  format TEXT NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  size BIGINT NOT NULL,
+  UNIQUE (file_id, size_name, format)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS file_renditions;
+
+ALTER TABLE files
+DROP COLUMN IF EXISTS width,
+DROP COLUMN IF EXISTS height,
+DROP COLUMN IF EXISTS blurhash;
+-- +goose StatementEnd
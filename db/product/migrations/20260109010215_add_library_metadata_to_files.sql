@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE files
+ADD COLUMN alt_text TEXT NOT NULL DEFAULT '',
+ADD COLUMN title TEXT NOT NULL DEFAULT '',
+ADD COLUMN folder TEXT NOT NULL DEFAULT '',
+ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE files
+DROP COLUMN IF EXISTS alt_text,
+DROP COLUMN IF EXISTS title,
+DROP COLUMN IF EXISTS folder,
+DROP COLUMN IF EXISTS tags;
+-- +goose StatementEnd
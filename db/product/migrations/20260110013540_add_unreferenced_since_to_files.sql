@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE files
+ADD COLUMN unreferenced_since TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE files
+DROP COLUMN IF EXISTS unreferenced_since;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pages
+ADD COLUMN draft_blocks JSONB NOT NULL DEFAULT '[]',
+ADD COLUMN published_blocks JSONB,
+ADD COLUMN published_at TIMESTAMPTZ,
+ADD COLUMN updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+
+-- Pages that existed before the builder stay reachable by slug.
+UPDATE pages
+SET
+  published_blocks = '[]',
+  published_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pages
+DROP COLUMN IF EXISTS draft_blocks,
+DROP COLUMN IF EXISTS published_blocks,
+DROP COLUMN IF EXISTS published_at,
+DROP COLUMN IF EXISTS updated_at;
+-- +goose StatementEnd
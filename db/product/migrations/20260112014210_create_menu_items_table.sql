@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS menu_items (
+  id BIGSERIAL PRIMARY KEY,
+  menu_id BIGINT NOT NULL REFERENCES menus (id) ON DELETE CASCADE,
+  parent_id BIGINT REFERENCES menu_items (id) ON DELETE CASCADE,
+  label TEXT NOT NULL,
+  link_type TEXT NOT NULL,
+  url TEXT NOT NULL DEFAULT '',
+  target_id BIGINT,
+  no INT NOT NULL DEFAULT 0,
+  is_visible BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS menu_items;
+-- +goose StatementEnd
@@ -0,0 +1,178 @@
+-- name: CountAttributes :one
+SELECT
+  COUNT(*)
+FROM
+  attributes;
+
+-- name: GetAttributes :many
+SELECT
+  id,
+  code,
+  name,
+  type,
+  unit,
+  options,
+  is_filterable
+FROM
+  attributes
+ORDER BY
+  name ASC
+LIMIT
+  $1
+OFFSET
+  $2;
+
+-- name: GetAttribute :one
+SELECT
+  id,
+  code,
+  name,
+  type,
+  unit,
+  options,
+  is_filterable,
+  created_at,
+  updated_at
+FROM
+  attributes
+WHERE
+  id = $1;
+
+-- name: CreateAttribute :one
+INSERT INTO
+  attributes (code, name, type, unit, options, is_filterable)
+VALUES
+  ($1, $2, $3, $4, $5, $6)
+RETURNING
+  id;
+
+-- name: UpdateAttribute :exec
+UPDATE attributes
+SET
+  code = $2,
+  name = $3,
+  type = $4,
+  unit = $5,
+  options = $6,
+  is_filterable = $7,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1;
+
+-- name: BulkDeleteAttributes :exec
+DELETE FROM attributes
+WHERE
+  id = ANY ($1::bigint[]);
+
+-- name: GetCategoryAttributes :many
+SELECT
+  a.id,
+  a.code,
+  a.name,
+  a.type,
+  a.unit,
+  ca.is_required,
+  ca.no
+FROM
+  category_attributes ca
+  JOIN attributes a ON a.id = ca.attribute_id
+WHERE
+  ca.category_id = $1
+ORDER BY
+  ca.no ASC;
+
+-- name: GetAttributesByCategory :many
+WITH RECURSIVE
+  path AS (
+    SELECT
+      id,
+      parent_id,
+      0 AS depth
+    FROM
+      categories
+    WHERE
+      categories.id = $1
+    UNION ALL
+    SELECT
+      c.id,
+      c.parent_id,
+      path.depth + 1
+    FROM
+      categories c
+      JOIN path ON c.id = path.parent_id
+    WHERE
+      path.depth < 10
+  )
+SELECT
+  a.id,
+  a.code,
+  a.name,
+  a.type,
+  a.unit,
+  a.options,
+  a.is_filterable,
+  bool_or(ca.is_required)::boolean AS is_required
+FROM
+  path
+  JOIN category_attributes ca ON ca.category_id = path.id
+  JOIN attributes a ON a.id = ca.attribute_id
+GROUP BY
+  a.id
+ORDER BY
+  MAX(path.depth) DESC,
+  MIN(ca.no) ASC,
+  a.id ASC;
+
+-- name: DeleteCategoryAttributes :exec
+DELETE FROM category_attributes
+WHERE
+  category_id = $1;
+
+-- name: BulkInsertCategoryAttributes :exec
+INSERT INTO
+  category_attributes (category_id, attribute_id, is_required, no)
+SELECT
+  unnest(@category_ids::bigint[]),
+  unnest(@attribute_ids::bigint[]),
+  unnest(@is_requireds::boolean[]),
+  unnest(@nos::int[]);
+
+-- name: GetProductAttributeValues :many
+SELECT
+  a.id,
+  a.code,
+  a.name,
+  a.type,
+  a.unit,
+  pav.value,
+  pav.value_number
+FROM
+  product_attribute_values pav
+  JOIN attributes a ON a.id = pav.attribute_id
+WHERE
+  pav.product_id = $1
+ORDER BY
+  a.id ASC;
+
+-- name: DeleteProductAttributeValues :exec
+DELETE FROM product_attribute_values
+WHERE
+  product_id = $1;
+
+-- name: BulkInsertProductAttributeValues :exec
+INSERT INTO
+  product_attribute_values (product_id, attribute_id, value, value_number)
+SELECT
+  t.product_id,
+  a.id,
+  t.value,
+  CASE
+    WHEN a.type = 'number' THEN t.value::float8
+  END
+FROM
+  unnest(
+    @product_ids::bigint[],
+    @attribute_ids::bigint[],
+    @values::text[]
+  ) AS t (product_id, attribute_id, value)
+  JOIN attributes a ON a.id = t.attribute_id;
@@ -48,3 +48,38 @@ WHERE
 DELETE FROM categories
 WHERE
   id = ANY ($1::bigint[]);
+
+-- name: GetCategoryPath :many
+WITH RECURSIVE
+  path AS (
+    SELECT
+      id,
+      name,
+      slug,
+      parent_id,
+      0 AS depth
+    FROM
+      categories
+    WHERE
+      categories.id = $1
+    UNION ALL
+    SELECT
+      c.id,
+      c.name,
+      c.slug,
+      c.parent_id,
+      path.depth + 1
+    FROM
+      categories c
+      JOIN path ON c.id = path.parent_id
+    WHERE
+      path.depth < 10
+  )
+SELECT
+  id,
+  name,
+  slug
+FROM
+  path
+ORDER BY
+  depth DESC;
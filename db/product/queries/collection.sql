@@ -90,3 +90,22 @@ WHERE
 DELETE FROM collections
 WHERE
   id = ANY ($1::bigint[]);
+
+-- name: GetCollectionSeoBySlug :one
+SELECT
+  id,
+  name,
+  slug,
+  file,
+  meta_title,
+  meta_description,
+  canonical_url,
+  og_title,
+  og_description,
+  og_image
+FROM
+  collections
+WHERE
+  slug = $1
+LIMIT
+  1;
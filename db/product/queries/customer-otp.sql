@@ -0,0 +1,43 @@
+-- name: CreateCustomerOtp :one
+INSERT INTO
+  customer_otps (customer_id, purpose, code_hash, expires_at)
+VALUES
+  ($1, $2, $3, $4)
+RETURNING
+  id;
+
+-- name: ClaimCustomerOtpAttempt :one
+-- Counts an attempt on the latest code for purpose and returns it, or no
+-- row once that code is used, expired or out of attempts. Concurrent
+-- claims queue on the row, so each sees the count the previous one left.
+UPDATE customer_otps
+SET
+  attempts = attempts + 1
+WHERE
+  id = (
+    SELECT
+      latest.id
+    FROM
+      customer_otps latest
+    WHERE
+      latest.customer_id = @customer_id
+      AND latest.purpose = @purpose
+    ORDER BY
+      latest.id DESC
+    LIMIT
+      1
+  )
+  AND used_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+  AND attempts < @max_attempts::int
+RETURNING
+  id,
+  code_hash;
+
+-- name: UseCustomerOtp :execrows
+UPDATE customer_otps
+SET
+  used_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1
+  AND used_at IS NULL;
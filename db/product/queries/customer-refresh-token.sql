@@ -0,0 +1,21 @@
+-- name: CreateCustomerRefreshToken :exec
+INSERT INTO
+  customer_refresh_tokens (customer_id, session_id, token_hash, expires_at)
+VALUES
+  ($1, $2, $3, $4);
+
+-- name: RevokeCustomerRefreshToken :one
+UPDATE customer_refresh_tokens t
+SET
+  revoked_at = CURRENT_TIMESTAMP
+FROM
+  customer_sessions s
+WHERE
+  t.token_hash = $1
+  AND t.revoked_at IS NULL
+  AND t.expires_at > CURRENT_TIMESTAMP
+  AND s.id = t.session_id
+  AND s.revoked_at IS NULL
+RETURNING
+  t.customer_id,
+  t.session_id;
@@ -0,0 +1,79 @@
+-- name: CreateCustomerSession :one
+INSERT INTO
+  customer_sessions (customer_id, user_agent, ip, expires_at)
+VALUES
+  ($1, $2, $3, $4)
+RETURNING
+  id;
+
+-- name: GetActiveCustomerSession :one
+SELECT
+  last_seen_at
+FROM
+  customer_sessions
+WHERE
+  id = $1
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+LIMIT
+  1;
+
+-- name: TouchCustomerSession :exec
+UPDATE customer_sessions
+SET
+  last_seen_at = CURRENT_TIMESTAMP,
+  ip = $2
+WHERE
+  id = $1;
+
+-- name: ExtendCustomerSession :exec
+UPDATE customer_sessions
+SET
+  last_seen_at = CURRENT_TIMESTAMP,
+  ip = $2,
+  expires_at = $3
+WHERE
+  id = $1;
+
+-- name: GetCustomerSessions :many
+SELECT
+  id,
+  user_agent,
+  ip,
+  last_seen_at,
+  expires_at,
+  created_at
+FROM
+  customer_sessions
+WHERE
+  customer_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+ORDER BY
+  last_seen_at DESC;
+
+-- name: RevokeCustomerSession :execrows
+UPDATE customer_sessions
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1
+  AND customer_id = $2
+  AND revoked_at IS NULL;
+
+-- name: RevokeOtherCustomerSessions :execrows
+UPDATE customer_sessions
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  customer_id = @customer_id
+  AND id <> @keep_id::bigint
+  AND revoked_at IS NULL;
+
+-- name: RevokeCustomerSessionsByCustomer :exec
+UPDATE customer_sessions
+SET
+  revoked_at = CURRENT_TIMESTAMP
+WHERE
+  customer_id = $1
+  AND revoked_at IS NULL;
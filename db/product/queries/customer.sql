@@ -28,19 +28,25 @@ FROM customers
 WHERE phone = $1
 LIMIT 1;
 
+-- name: GetCustomerByIdentify :one
+SELECT id, name, phone, email
+FROM customers
+WHERE phone = $1 OR email = $1
+ORDER BY id
+LIMIT 1;
+
 -- name: CreateCustomer :one
 INSERT INTO
-  customers (name, phone, password, zns_otp)
+  customers (name, phone, password)
 VALUES
-  ($1, $2, $3, $4)
+  ($1, $2, $3)
 RETURNING
   id;
 
 -- name: VerifyPhone :exec
 UPDATE customers
-SET phone_verified = true,
-    zns_otp = NULL
-WHERE phone = $1 AND zns_otp = $2;
+SET phone_verified = true
+WHERE id = $1;
 
 -- name: UpdateCustomer :one
 UPDATE customers
@@ -52,6 +58,11 @@ SET
 WHERE id = $1
 RETURNING id;
 
+-- name: UpdateCustomerPassword :exec
+UPDATE customers
+SET password = $2
+WHERE id = $1;
+
 -- name: BulkDeleteCustomers :exec
 DELETE FROM customers
 WHERE
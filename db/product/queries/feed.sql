@@ -0,0 +1,49 @@
+-- name: GetFeedItems :many
+SELECT
+  p.id,
+  p.name,
+  p.slug,
+  p.sku,
+  p.meta_description,
+  p.brand,
+  p.gtin,
+  p.origin_price,
+  p.sale_price,
+  p.stock,
+  v.id AS variant_id,
+  v.sku AS variant_sku,
+  v.origin_price AS variant_origin_price,
+  v.sale_price AS variant_sale_price,
+  v.stock AS variant_stock,
+  v.file AS variant_file,
+  ARRAY(
+    SELECT
+      pf.name
+    FROM
+      product_files pf
+    WHERE
+      pf.product_id = p.id
+      AND pf.name IS NOT NULL
+    ORDER BY
+      pf.is_primary DESC,
+      pf.no ASC
+  )::text[] AS files,
+  (
+    SELECT
+      COALESCE(jsonb_object_agg(o.name, ov.name), '{}'::jsonb)
+    FROM
+      variant_options vo
+      JOIN options o ON o.id = vo.option_id
+      JOIN option_values ov ON ov.id = vo.option_value_id
+    WHERE
+      vo.variant_id = v.id
+  )::jsonb AS attributes
+FROM
+  products p
+  LEFT JOIN variants v ON v.product_id = p.id
+WHERE
+  p.is_active = true
+  AND p.exclude_from_feeds = false
+ORDER BY
+  p.id,
+  v.no;
@@ -0,0 +1,41 @@
+-- name: CreateFileRendition :exec
+INSERT INTO
+  file_renditions (file_id, name, size_name, format, width, height, size)
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT DO NOTHING;
+
+-- name: GetFileRenditionsByNames :many
+SELECT
+  f.name,
+  f.width,
+  f.height,
+  f.blurhash,
+  r.name AS rendition_name,
+  r.size_name,
+  r.format,
+  r.width AS rendition_width
+FROM
+  files f
+  LEFT JOIN file_renditions r ON r.file_id = f.id
+WHERE
+  f.name = ANY (@names::text[])
+  AND f.width IS NOT NULL
+ORDER BY
+  f.name,
+  r.format,
+  r.width;
+
+-- name: GetFileRenditionNames :many
+SELECT
+  name
+FROM
+  file_renditions
+WHERE
+  file_id = ANY (@file_ids::bigint[]);
+
+-- name: GetAllFileRenditionNames :many
+SELECT
+  name
+FROM
+  file_renditions;
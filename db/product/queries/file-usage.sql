@@ -0,0 +1,129 @@
+-- name: GetFileUsages :many
+SELECT
+  'review' AS entity_type,
+  rf.review_id AS entity_id,
+  COALESCE(p.name, '') AS entity_name,
+  'files' AS field,
+  rf.name AS file_name
+FROM
+  review_files rf
+  JOIN reviews r ON r.id = rf.review_id
+  LEFT JOIN products p ON p.id = r.product_id
+WHERE
+  rf.name = ANY (@names::text[])
+UNION ALL
+SELECT
+  'product',
+  p.id,
+  p.name,
+  'files',
+  pf.name
+FROM
+  product_files pf
+  JOIN products p ON p.id = pf.product_id
+WHERE
+  pf.name = ANY (@names::text[])
+UNION ALL
+SELECT
+  'product',
+  id,
+  name,
+  'og_image',
+  og_image
+FROM
+  products
+WHERE
+  og_image = ANY (@names::text[])
+UNION ALL
+SELECT
+  'variant',
+  v.id,
+  p.name,
+  'file',
+  v.file
+FROM
+  variants v
+  JOIN products p ON p.id = v.product_id
+WHERE
+  v.file = ANY (@names::text[])
+UNION ALL
+SELECT
+  'collection',
+  id,
+  name,
+  'file',
+  file
+FROM
+  collections
+WHERE
+  file = ANY (@names::text[])
+UNION ALL
+SELECT
+  'collection',
+  id,
+  name,
+  'og_image',
+  og_image
+FROM
+  collections
+WHERE
+  og_image = ANY (@names::text[])
+UNION ALL
+SELECT
+  'hotspot',
+  id,
+  '',
+  'file',
+  file
+FROM
+  hotspots
+WHERE
+  file = ANY (@names::text[])
+UNION ALL
+SELECT
+  'customer',
+  id,
+  name,
+  'avatar',
+  avatar
+FROM
+  customers
+WHERE
+  avatar = ANY (@names::text[])
+UNION ALL
+SELECT
+  'page',
+  p.id,
+  p.name,
+  'blocks',
+  b.file
+FROM
+  pages p
+  CROSS JOIN LATERAL (
+    SELECT
+      jsonb_array_elements(p.draft_blocks) -> 'data' ->> 'file' AS file
+    UNION
+    SELECT
+      jsonb_array_elements(COALESCE(p.published_blocks, '[]')) -> 'data' ->> 'file'
+  ) b
+WHERE
+  b.file = ANY (@names::text[])
+UNION ALL
+SELECT
+  'page',
+  p.id,
+  p.name,
+  'html',
+  n.name
+FROM
+  pages p
+  -- Images embedded in HTML blocks are URLs; matching without the
+  -- extension also finds renditions of the file.
+  JOIN unnest(@names::text[]) AS n (name) ON EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_array_elements(p.draft_blocks || COALESCE(p.published_blocks, '[]')) AS b (block)
+    WHERE
+      strpos(b.block -> 'data' ->> 'html', regexp_replace(n.name, '\.[^./]*$', '')) > 0
+  );
@@ -2,20 +2,132 @@
 SELECT
   COUNT(*)
 FROM
-  files;
+  files
+WHERE
+  (
+    sqlc.narg (search)::text IS NULL
+    OR name ILIKE '%' || sqlc.narg (search) || '%'
+    OR original_name ILIKE '%' || sqlc.narg (search) || '%'
+    OR title ILIKE '%' || sqlc.narg (search) || '%'
+    OR alt_text ILIKE '%' || sqlc.narg (search) || '%'
+  )
+  AND (
+    sqlc.narg (folder)::text IS NULL
+    OR folder = sqlc.narg (folder)
+    OR folder LIKE sqlc.narg (folder) || '/%'
+  )
+  AND (
+    sqlc.narg (tag)::text IS NULL
+    OR sqlc.narg (tag) = ANY (tags)
+  )
+  AND (
+    sqlc.narg (content_type)::text IS NULL
+    OR content_type LIKE sqlc.narg (content_type) || '%'
+  );
 
 -- name: GetFiles :many
 SELECT
   id,
-  name
+  name,
+  content_type,
+  size,
+  original_name,
+  width,
+  height,
+  alt_text,
+  title,
+  folder,
+  tags,
+  created_at
 FROM
   files
+WHERE
+  (
+    sqlc.narg (search)::text IS NULL
+    OR name ILIKE '%' || sqlc.narg (search) || '%'
+    OR original_name ILIKE '%' || sqlc.narg (search) || '%'
+    OR title ILIKE '%' || sqlc.narg (search) || '%'
+    OR alt_text ILIKE '%' || sqlc.narg (search) || '%'
+  )
+  AND (
+    sqlc.narg (folder)::text IS NULL
+    OR folder = sqlc.narg (folder)
+    OR folder LIKE sqlc.narg (folder) || '/%'
+  )
+  AND (
+    sqlc.narg (tag)::text IS NULL
+    OR sqlc.narg (tag) = ANY (tags)
+  )
+  AND (
+    sqlc.narg (content_type)::text IS NULL
+    OR content_type LIKE sqlc.narg (content_type) || '%'
+  )
 ORDER BY
   created_at DESC
 LIMIT
-  $1
+  sqlc.arg (limit_count)
 OFFSET
-  $2;
+  sqlc.arg (offset_count);
+
+-- name: GetFile :one
+SELECT
+  id,
+  name,
+  content_type,
+  size,
+  original_name,
+  width,
+  height,
+  blurhash,
+  alt_text,
+  title,
+  folder,
+  tags,
+  created_at
+FROM
+  files
+WHERE
+  id = $1;
+
+-- name: GetFilesByIDs :many
+SELECT
+  id,
+  name,
+  hash
+FROM
+  files
+WHERE
+  id = ANY (@ids::bigint[]);
+
+-- name: UpdateFile :execrows
+UPDATE files
+SET
+  alt_text = $2,
+  title = $3,
+  folder = $4,
+  tags = $5
+WHERE
+  id = $1;
+
+-- name: MoveFiles :execrows
+UPDATE files
+SET
+  folder = @folder
+WHERE
+  id = ANY (@ids::bigint[]);
+
+-- name: GetFolders :many
+SELECT
+  folder,
+  COUNT(*) AS file_count
+FROM
+  files
+WHERE
+  folder <> ''
+GROUP BY
+  folder
+ORDER BY
+  folder;
 
 -- name: BulkInsertFiles :exec
 INSERT INTO
@@ -27,3 +139,63 @@ SELECT
 DELETE FROM files
 WHERE
   id = ANY ($1::bigint[]);
+
+-- name: GetFileByHash :one
+SELECT
+  id,
+  name,
+  content_type,
+  size,
+  width,
+  height,
+  blurhash
+FROM
+  files
+WHERE
+  hash = $1
+LIMIT
+  1;
+
+-- name: CreateUploadedFile :one
+INSERT INTO
+  files (
+    name,
+    hash,
+    size,
+    content_type,
+    original_name,
+    width,
+    height,
+    blurhash
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (hash) DO NOTHING
+RETURNING
+  id,
+  name;
+
+-- name: GetFilesForCleanup :many
+SELECT
+  id,
+  name,
+  unreferenced_since
+FROM
+  files
+ORDER BY
+  id;
+
+-- name: MarkFilesUnreferenced :exec
+UPDATE files
+SET
+  unreferenced_since = NOW()
+WHERE
+  id = ANY (@ids::bigint[])
+  AND unreferenced_since IS NULL;
+
+-- name: ClearFilesUnreferenced :exec
+UPDATE files
+SET
+  unreferenced_since = NULL
+WHERE
+  id = ANY (@ids::bigint[]);
@@ -0,0 +1,99 @@
+-- name: GetMenuItems :many
+SELECT
+  mi.id,
+  mi.parent_id,
+  mi.label,
+  mi.link_type,
+  mi.url,
+  mi.target_id,
+  mi.no,
+  mi.is_visible,
+  COALESCE(p.slug, c.slug, cat.slug, pg.slug, '') AS target_slug,
+  (
+    p.is_active
+    OR c.id IS NOT NULL
+    OR cat.id IS NOT NULL
+    OR pg.published_blocks IS NOT NULL
+  ) IS TRUE AS target_available
+FROM
+  menu_items mi
+  LEFT JOIN products p ON mi.link_type = 'product'
+  AND p.id = mi.target_id
+  LEFT JOIN collections c ON mi.link_type = 'collection'
+  AND c.id = mi.target_id
+  LEFT JOIN categories cat ON mi.link_type = 'category'
+  AND cat.id = mi.target_id
+  LEFT JOIN pages pg ON mi.link_type = 'page'
+  AND pg.id = mi.target_id
+WHERE
+  mi.menu_id = $1
+ORDER BY
+  mi.no,
+  mi.id;
+
+-- name: CreateMenuItem :one
+INSERT INTO
+  menu_items (
+    menu_id,
+    parent_id,
+    label,
+    link_type,
+    url,
+    target_id,
+    is_visible,
+    no
+  )
+VALUES
+  (
+    @menu_id,
+    @parent_id,
+    @label,
+    @link_type,
+    @url,
+    @target_id,
+    @is_visible,
+    (
+      SELECT
+        COALESCE(MAX(no) + 1, 0)
+      FROM
+        menu_items
+      WHERE
+        menu_id = @menu_id
+        AND parent_id IS NOT DISTINCT FROM @parent_id
+    )
+  )
+RETURNING
+  id;
+
+-- name: UpdateMenuItem :execrows
+UPDATE menu_items
+SET
+  label = $3,
+  link_type = $4,
+  url = $5,
+  target_id = $6,
+  is_visible = $7
+WHERE
+  id = $1
+  AND menu_id = $2;
+
+-- name: MoveMenuItems :exec
+UPDATE menu_items mi
+SET
+  parent_id = NULLIF(u.parent_id, 0),
+  no = u.no
+FROM
+  unnest(
+    @ids::bigint[],
+    @parent_ids::bigint[],
+    @nos::int[]
+  ) AS u (id, parent_id, no)
+WHERE
+  mi.id = u.id
+  AND mi.menu_id = @menu_id;
+
+-- name: DeleteMenuItem :execrows
+DELETE FROM menu_items
+WHERE
+  id = $1
+  AND menu_id = $2;
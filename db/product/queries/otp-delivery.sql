@@ -0,0 +1,47 @@
+-- name: CreateOtpDelivery :exec
+INSERT INTO
+  otp_deliveries (otp_id, channel, provider, recipient, status, error)
+VALUES
+  ($1, $2, $3, $4, $5, $6);
+
+-- name: GetOtpDeliveryStats :one
+SELECT
+  COUNT(*) AS total,
+  MAX(d.created_at) FILTER (
+    WHERE
+      d.status = 'sent'
+  )::timestamptz AS last_sent_at
+FROM
+  otp_deliveries d
+  JOIN customer_otps o ON o.id = d.otp_id
+WHERE
+  o.customer_id = @customer_id
+  AND o.purpose = @purpose
+  AND d.created_at > @since::timestamptz;
+
+-- name: GetOtpDeliveries :many
+SELECT
+  d.id,
+  o.customer_id,
+  o.purpose,
+  d.channel,
+  d.provider,
+  d.recipient,
+  d.status,
+  d.error,
+  d.created_at
+FROM
+  otp_deliveries d
+  JOIN customer_otps o ON o.id = d.otp_id
+ORDER BY
+  d.id DESC
+LIMIT
+  $1
+OFFSET
+  $2;
+
+-- name: CountOtpDeliveries :one
+SELECT
+  COUNT(*)
+FROM
+  otp_deliveries;
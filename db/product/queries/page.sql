@@ -8,7 +8,9 @@ FROM
 SELECT
   id,
   name,
-  slug
+  slug,
+  published_at,
+  updated_at
 FROM
   pages
 LIMIT
@@ -20,7 +22,11 @@ OFFSET
 SELECT
   id,
   name,
-  slug
+  slug,
+  draft_blocks,
+  published_blocks,
+  published_at,
+  updated_at
 FROM
   pages
 WHERE
@@ -30,17 +36,20 @@ WHERE
 SELECT
   id,
   name,
-  slug
+  slug,
+  published_blocks,
+  published_at
 FROM
   pages
 WHERE
-  slug = $1;
+  slug = $1
+  AND published_blocks IS NOT NULL;
 
 -- name: CreatePage :one
 INSERT INTO
-  pages (name, slug)
+  pages (name, slug, draft_blocks)
 VALUES
-  ($1, $2)
+  ($1, $2, $3)
 RETURNING
   id;
 
@@ -48,7 +57,32 @@ RETURNING
 UPDATE pages
 SET
   name = $2,
-  slug = $3
+  slug = $3,
+  updated_at = NOW()
+WHERE
+  id = $1;
+
+-- name: UpdatePageDraft :execrows
+UPDATE pages
+SET
+  draft_blocks = $2,
+  updated_at = NOW()
+WHERE
+  id = $1;
+
+-- name: PublishPage :execrows
+UPDATE pages
+SET
+  published_blocks = draft_blocks,
+  published_at = NOW()
+WHERE
+  id = $1;
+
+-- name: UnpublishPage :execrows
+UPDATE pages
+SET
+  published_blocks = NULL,
+  published_at = NULL
 WHERE
   id = $1;
 
@@ -19,6 +19,32 @@ FROM
 WHERE
   product_id = $1;
 
+-- name: GetCollectionProductCards :many
+SELECT
+  p.id,
+  p.name,
+  p.slug,
+  p.origin_price,
+  p.sale_price,
+  (
+    SELECT
+      COALESCE(json_agg(pf.name), '[]'::json)
+    FROM
+      product_files pf
+    WHERE
+      pf.product_id = p.id
+  ) AS files
+FROM
+  product_collections pc
+  JOIN products p ON p.id = pc.product_id
+WHERE
+  pc.collection_id = @collection_id
+  AND p.is_active
+ORDER BY
+  p.id DESC
+LIMIT
+  @limit_count;
+
 -- name: GetProductsByCollectionID :many
 SELECT
   p.id,
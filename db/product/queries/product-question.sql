@@ -0,0 +1,132 @@
+-- name: CountPublishedQuestionsByProduct :one
+SELECT
+  COUNT(*)
+FROM
+  product_questions
+WHERE
+  product_id = $1
+  AND status = 'published';
+
+-- name: GetPublishedQuestionsByProduct :many
+SELECT
+  q.id,
+  q.question,
+  q.answer,
+  q.answered_at,
+  q.created_at,
+  (
+    SELECT
+      COALESCE(
+        json_build_object('id', c.id, 'name', c.name, 'avatar', c.avatar),
+        '{}'::json
+      )
+    FROM
+      customers c
+    WHERE
+      c.id = q.customer_id
+  ) AS customer
+FROM
+  product_questions q
+WHERE
+  q.product_id = $1
+  AND q.status = 'published'
+ORDER BY
+  q.created_at DESC
+LIMIT
+  $2
+OFFSET
+  $3;
+
+-- name: CountQuestions :one
+SELECT
+  COUNT(*)
+FROM
+  product_questions
+WHERE
+  (
+    sqlc.narg (status)::text IS NULL
+    OR status = sqlc.narg (status)
+  )
+  AND (
+    sqlc.narg (product_id)::bigint IS NULL
+    OR product_id = sqlc.narg (product_id)
+  );
+
+-- name: GetQuestions :many
+SELECT
+  q.id,
+  q.product_id,
+  p.name AS product_name,
+  q.customer_id,
+  c.name AS customer_name,
+  q.question,
+  q.answer,
+  q.status,
+  q.answered_at,
+  q.created_at
+FROM
+  product_questions q
+  JOIN products p ON p.id = q.product_id
+  JOIN customers c ON c.id = q.customer_id
+WHERE
+  (
+    sqlc.narg (status)::text IS NULL
+    OR q.status = sqlc.narg (status)
+  )
+  AND (
+    sqlc.narg (product_id)::bigint IS NULL
+    OR q.product_id = sqlc.narg (product_id)
+  )
+ORDER BY
+  q.created_at DESC
+LIMIT
+  sqlc.arg (limit_count)
+OFFSET
+  sqlc.arg (offset_count);
+
+-- name: GetQuestion :one
+SELECT
+  id,
+  product_id,
+  customer_id,
+  question,
+  answer,
+  status,
+  answered_at,
+  created_at,
+  updated_at
+FROM
+  product_questions
+WHERE
+  id = $1;
+
+-- name: CreateQuestion :one
+INSERT INTO
+  product_questions (product_id, customer_id, question)
+VALUES
+  ($1, $2, $3)
+RETURNING
+  id;
+
+-- name: AnswerQuestion :exec
+UPDATE product_questions
+SET
+  answer = $2,
+  status = $3,
+  answered_at = CURRENT_TIMESTAMP,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1;
+
+-- name: UpdateQuestionStatus :exec
+UPDATE product_questions
+SET
+  status = $2,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1;
+
+-- name: BulkDeleteQuestions :exec
+DELETE FROM product_questions
+WHERE
+  id = ANY ($1::bigint[]);
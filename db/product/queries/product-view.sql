@@ -0,0 +1,150 @@
+-- name: BulkInsertProductViews :exec
+INSERT INTO
+  product_views (product_id, customer_id, session_id, viewed_at)
+SELECT
+  p.id,
+  c.id,
+  NULLIF(t.session_id, ''),
+  t.viewed_at
+FROM
+  unnest(
+    @product_ids::bigint[],
+    @customer_ids::bigint[],
+    @session_ids::text[],
+    @viewed_ats::timestamptz[]
+  ) AS t (product_id, customer_id, session_id, viewed_at)
+  JOIN products p ON p.id = t.product_id
+  LEFT JOIN customers c ON c.id = t.customer_id;
+
+-- name: GetRecentlyViewedByCustomer :many
+SELECT
+  p.id,
+  p.name,
+  p.slug,
+  p.origin_price,
+  p.sale_price,
+  p.stock,
+  COALESCE(
+    (
+      SELECT
+        pf.name
+      FROM
+        product_files pf
+      WHERE
+        pf.product_id = p.id
+      ORDER BY
+        pf.is_primary DESC,
+        pf.no ASC
+      LIMIT
+        1
+    ),
+    ''
+  )::text AS file,
+  rv.viewed_at::timestamptz AS viewed_at
+FROM
+  (
+    SELECT
+      product_id,
+      MAX(viewed_at) AS viewed_at
+    FROM
+      product_views
+    WHERE
+      customer_id = $1
+    GROUP BY
+      product_id
+  ) rv
+  JOIN products p ON p.id = rv.product_id
+WHERE
+  p.is_active = true
+ORDER BY
+  rv.viewed_at DESC
+LIMIT
+  $2;
+
+-- name: GetProductViewReport :many
+WITH
+  views AS (
+    SELECT
+      product_id,
+      COUNT(*) AS views,
+      COUNT(DISTINCT COALESCE(customer_id::text, session_id)) AS visitors
+    FROM
+      product_views
+    WHERE
+      viewed_at >= @from_date
+      AND viewed_at < @to_date
+    GROUP BY
+      product_id
+  ),
+  sales AS (
+    SELECT
+      oi.product_id,
+      COUNT(DISTINCT o.id) AS orders,
+      SUM(oi.quantity) AS units
+    FROM
+      order_items oi
+      JOIN orders o ON o.id = oi.order_id
+    WHERE
+      o.created_at >= @from_date
+      AND o.created_at < @to_date
+      AND o.status <> 'cancelled'
+    GROUP BY
+      oi.product_id
+  )
+SELECT
+  p.id,
+  p.name,
+  p.slug,
+  COALESCE(v.views, 0)::bigint AS views,
+  COALESCE(v.visitors, 0)::bigint AS visitors,
+  COALESCE(s.orders, 0)::bigint AS orders,
+  COALESCE(s.units, 0)::bigint AS units,
+  (
+    CASE
+      WHEN COALESCE(v.visitors, 0) > 0 THEN COALESCE(s.orders, 0)::float8 / v.visitors
+      ELSE 0
+    END
+  )::float8 AS conversion_rate
+FROM
+  products p
+  LEFT JOIN views v ON v.product_id = p.id
+  LEFT JOIN sales s ON s.product_id = p.id
+WHERE
+  v.product_id IS NOT NULL
+  OR s.product_id IS NOT NULL
+ORDER BY
+  views DESC,
+  p.id ASC
+LIMIT
+  @limit_count
+OFFSET
+  @offset_count;
+
+-- name: CountProductViewReport :one
+SELECT
+  COUNT(*)
+FROM
+  products p
+WHERE
+  EXISTS (
+    SELECT
+      1
+    FROM
+      product_views pv
+    WHERE
+      pv.product_id = p.id
+      AND pv.viewed_at >= @from_date
+      AND pv.viewed_at < @to_date
+  )
+  OR EXISTS (
+    SELECT
+      1
+    FROM
+      order_items oi
+      JOIN orders o ON o.id = oi.order_id
+    WHERE
+      oi.product_id = p.id
+      AND o.created_at >= @from_date
+      AND o.created_at < @to_date
+      AND o.status <> 'cancelled'
+  );
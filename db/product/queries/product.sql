@@ -2,7 +2,42 @@
 SELECT
   COUNT(*)
 FROM
-  products;
+  products p
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset(sqlc.narg (attribute_filters)::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  );
 
 -- name: GetProducts :many
 SELECT
@@ -23,12 +58,47 @@ FROM
     LIMIT
       1
   ) f ON true
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset(sqlc.narg (attribute_filters)::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  )
 ORDER BY
   created_at DESC
 LIMIT
-  $1
+  sqlc.arg (limit)
 OFFSET
-  $2;
+  sqlc.arg (offset);
 
 -- name: GetProduct :one
 SELECT
@@ -46,7 +116,10 @@ SELECT
   weight,
   long,
   wide,
-  high
+  high,
+  brand,
+  gtin,
+  exclude_from_feeds
 FROM
   products
 WHERE
@@ -147,7 +220,38 @@ SELECT
       variants v
     WHERE
       v.product_id = p.id
-  ) AS variants
+  ) AS variants,
+  (
+    SELECT
+      COALESCE(
+        json_agg(
+          json_build_object(
+            'code',
+            a.code,
+            'name',
+            a.name,
+            'type',
+            a.type,
+            'unit',
+            a.unit,
+            'value',
+            CASE a.type
+              WHEN 'number' THEN to_json(pav.value_number)
+              WHEN 'boolean' THEN to_json(pav.value::boolean)
+              ELSE to_json(pav.value)
+            END
+          )
+          ORDER BY
+            a.id
+        ),
+        '[]'::json
+      )
+    FROM
+      product_attribute_values pav
+      JOIN attributes a ON a.id = pav.attribute_id
+    WHERE
+      pav.product_id = p.id
+  ) AS attributes
 FROM
   products p
 WHERE
@@ -170,10 +274,30 @@ INSERT INTO
     weight,
     long,
     wide,
-    high
+    high,
+    brand,
+    gtin,
+    exclude_from_feeds
   )
 VALUES
-  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+  (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15,
+    $16
+  )
 RETURNING
   id;
 
@@ -192,7 +316,10 @@ SET
   weight = $11,
   long = $12,
   wide = $13,
-  high = $14
+  high = $14,
+  brand = $15,
+  gtin = $16,
+  exclude_from_feeds = $17
 WHERE
   id = $1;
 
@@ -205,9 +332,43 @@ WHERE
 SELECT
   COUNT(*)
 FROM
-  products
+  products p
 WHERE
-  category_id = $1;
+  p.category_id = sqlc.arg (category_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset(sqlc.narg (attribute_filters)::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  );
 
 -- name: GetProductsByCategory :many
 SELECT
@@ -227,11 +388,45 @@ SELECT
 FROM
   products p
 WHERE
-  category_id = $3
+  p.category_id = sqlc.arg (category_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset(sqlc.narg (attribute_filters)::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  )
 LIMIT
-  $1
+  sqlc.arg (limit)
 OFFSET
-  $2;
+  sqlc.arg (offset);
 
 -- name: SearchProducts :many
 SELECT
@@ -257,3 +452,26 @@ LIMIT $2 OFFSET $3;
 SELECT COUNT(*) AS total
 FROM products
 WHERE ($1 = '' OR name LIKE '%' || $1 || '%');
+
+-- name: GetProductSeoBySlug :one
+SELECT
+  id,
+  name,
+  slug,
+  sku,
+  origin_price,
+  sale_price,
+  stock,
+  meta_title,
+  meta_description,
+  canonical_url,
+  og_title,
+  og_description,
+  og_image,
+  category_id
+FROM
+  products
+WHERE
+  slug = $1
+LIMIT
+  1;
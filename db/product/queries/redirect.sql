@@ -0,0 +1,95 @@
+-- name: CountRedirects :one
+SELECT
+  COUNT(*)
+FROM
+  redirects;
+
+-- name: GetRedirects :many
+SELECT
+  id,
+  entity_type,
+  from_slug,
+  to_slug,
+  status_code,
+  created_at
+FROM
+  redirects
+ORDER BY
+  created_at DESC
+LIMIT
+  $1
+OFFSET
+  $2;
+
+-- name: GetRedirect :one
+SELECT
+  id,
+  entity_type,
+  from_slug,
+  to_slug,
+  status_code
+FROM
+  redirects
+WHERE
+  id = $1;
+
+-- name: GetRedirectBySlug :one
+SELECT
+  to_slug,
+  status_code
+FROM
+  redirects
+WHERE
+  entity_type = $1
+  AND from_slug = $2
+LIMIT
+  1;
+
+-- name: CreateRedirect :one
+INSERT INTO
+  redirects (entity_type, from_slug, to_slug, status_code)
+VALUES
+  ($1, $2, $3, $4)
+RETURNING
+  id;
+
+-- name: UpdateRedirect :exec
+UPDATE redirects
+SET
+  entity_type = $2,
+  from_slug = $3,
+  to_slug = $4,
+  status_code = $5,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = $1;
+
+-- name: UpsertRedirect :exec
+INSERT INTO
+  redirects (entity_type, from_slug, to_slug)
+VALUES
+  ($1, $2, $3)
+ON CONFLICT (entity_type, from_slug) DO UPDATE
+SET
+  to_slug = EXCLUDED.to_slug,
+  updated_at = CURRENT_TIMESTAMP;
+
+-- name: RetargetRedirects :exec
+UPDATE redirects
+SET
+  to_slug = @new_slug,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  entity_type = @entity_type
+  AND to_slug = @old_slug;
+
+-- name: DeleteRedirectBySlug :exec
+DELETE FROM redirects
+WHERE
+  entity_type = $1
+  AND from_slug = $2;
+
+-- name: BulkDeleteRedirects :exec
+DELETE FROM redirects
+WHERE
+  id = ANY ($1::bigint[]);
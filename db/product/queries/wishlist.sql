@@ -0,0 +1,205 @@
+-- name: GetWishlistByCustomer :many
+SELECT
+  wi.id,
+  wi.product_id,
+  wi.variant_id,
+  wi.notify_price_drop,
+  wi.created_at,
+  p.name,
+  p.slug,
+  COALESCE(v.sku, p.sku, '')::text AS sku,
+  COALESCE(v.origin_price, p.origin_price)::int AS origin_price,
+  COALESCE(v.sale_price, p.sale_price)::int AS sale_price,
+  COALESCE(v.stock, p.stock, 0)::int AS stock,
+  COALESCE(
+    v.file,
+    (
+      SELECT
+        pf.name
+      FROM
+        product_files pf
+      WHERE
+        pf.product_id = p.id
+      ORDER BY
+        pf.is_primary DESC,
+        pf.no ASC
+      LIMIT
+        1
+    ),
+    ''
+  )::text AS file,
+  (
+    SELECT
+      COALESCE(json_object_agg(o.name, ov.name), '{}'::json)
+    FROM
+      variant_options vo
+      JOIN options o ON o.id = vo.option_id
+      JOIN option_values ov ON ov.id = vo.option_value_id
+    WHERE
+      vo.variant_id = wi.variant_id
+  ) AS options
+FROM
+  wishlist_items wi
+  JOIN products p ON p.id = wi.product_id
+  LEFT JOIN variants v ON v.id = wi.variant_id
+WHERE
+  wi.customer_id = $1
+ORDER BY
+  wi.created_at DESC;
+
+-- name: AddWishlistItem :one
+INSERT INTO
+  wishlist_items (
+    customer_id,
+    product_id,
+    variant_id,
+    notify_price_drop,
+    last_price
+  )
+SELECT
+  @customer_id::bigint,
+  p.id,
+  v.id,
+  @notify_price_drop::boolean,
+  CASE
+    WHEN COALESCE(v.sale_price, p.sale_price) > 0 THEN COALESCE(v.sale_price, p.sale_price)
+    ELSE COALESCE(v.origin_price, p.origin_price)
+  END
+FROM
+  products p
+  LEFT JOIN variants v ON v.id = sqlc.narg (variant_id)
+  AND v.product_id = p.id
+WHERE
+  p.id = @product_id
+  AND (
+    sqlc.narg (variant_id)::bigint IS NULL
+    OR v.id IS NOT NULL
+  )
+ON CONFLICT (customer_id, product_id, variant_id) DO UPDATE
+SET
+  notify_price_drop = EXCLUDED.notify_price_drop
+RETURNING
+  id;
+
+-- name: BulkDeleteWishlistItems :exec
+DELETE FROM wishlist_items
+WHERE
+  customer_id = @customer_id
+  AND id = ANY (@ids::bigint[]);
+
+-- name: RecordWishlistPriceDrops :many
+WITH
+  prices AS (
+    SELECT
+      wi.id,
+      wi.customer_id,
+      wi.product_id,
+      wi.variant_id,
+      wi.notify_price_drop,
+      wi.last_price AS old_price,
+      CASE
+        WHEN COALESCE(v.sale_price, p.sale_price) > 0 THEN COALESCE(v.sale_price, p.sale_price)
+        ELSE COALESCE(v.origin_price, p.origin_price)
+      END AS new_price
+    FROM
+      wishlist_items wi
+      JOIN products p ON p.id = wi.product_id
+      LEFT JOIN variants v ON v.id = wi.variant_id
+    WHERE
+      wi.product_id = $1
+  ),
+  updated AS (
+    UPDATE wishlist_items wi
+    SET
+      last_price = prices.new_price
+    FROM
+      prices
+    WHERE
+      wi.id = prices.id
+      AND prices.new_price <> prices.old_price
+  )
+INSERT INTO
+  wishlist_price_drops (
+    customer_id,
+    product_id,
+    variant_id,
+    old_price,
+    new_price
+  )
+SELECT
+  customer_id,
+  product_id,
+  variant_id,
+  old_price,
+  new_price
+FROM
+  prices
+WHERE
+  notify_price_drop = TRUE
+  AND new_price < old_price
+RETURNING
+  customer_id,
+  product_id,
+  variant_id,
+  old_price,
+  new_price;
+
+-- name: GetWishlistPriceDropsByCustomer :many
+SELECT
+  d.id,
+  d.product_id,
+  d.variant_id,
+  p.name,
+  p.slug,
+  d.old_price,
+  d.new_price,
+  d.created_at
+FROM
+  wishlist_price_drops d
+  JOIN products p ON p.id = d.product_id
+WHERE
+  d.customer_id = $1
+ORDER BY
+  d.created_at DESC
+LIMIT
+  $2
+OFFSET
+  $3;
+
+-- name: CountWishlistPriceDropsByCustomer :one
+SELECT
+  COUNT(*)
+FROM
+  wishlist_price_drops
+WHERE
+  customer_id = $1;
+
+-- name: GetMostWishlistedProducts :many
+SELECT
+  p.id,
+  p.name,
+  p.slug,
+  COUNT(*) AS wishlist_count,
+  COUNT(DISTINCT wi.customer_id) AS customer_count,
+  COUNT(*) FILTER (
+    WHERE
+      wi.notify_price_drop
+  ) AS notify_count
+FROM
+  wishlist_items wi
+  JOIN products p ON p.id = wi.product_id
+GROUP BY
+  p.id
+ORDER BY
+  wishlist_count DESC,
+  p.id ASC
+LIMIT
+  $1
+OFFSET
+  $2;
+
+-- name: CountWishlistedProducts :one
+SELECT
+  COUNT(DISTINCT product_id)
+FROM
+  wishlist_items;
@@ -46,6 +46,9 @@ CREATE TABLE IF NOT EXISTS products (
   og_image TEXT NOT NULL DEFAULT '',
   is_active BOOLEAN NOT NULL DEFAULT TRUE,
   category_id BIGINT REFERENCES categories (id) ON DELETE SET NULL,
+  brand TEXT NOT NULL DEFAULT '',
+  gtin TEXT NOT NULL DEFAULT '',
+  exclude_from_feeds BOOLEAN NOT NULL DEFAULT FALSE,
   created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 );
@@ -140,7 +143,6 @@ CREATE TABLE IF NOT EXISTS customers (
   name TEXT UNIQUE NOT NULL,
   phone TEXT UNIQUE NOT NULL,
   phone_verified  BOOLEAN DEFAULT FALSE,
-  zns_otp TEXT,
   avatar TEXT,
   email TEXT,
   password TEXT NOT NULL,
@@ -165,14 +167,45 @@ CREATE TABLE IF NOT EXISTS review_files (
 CREATE TABLE IF NOT EXISTS files (
   id BIGSERIAL PRIMARY KEY,
   name TEXT UNIQUE NOT NULL,
-  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  hash TEXT UNIQUE,
+  size BIGINT,
+  content_type TEXT,
+  original_name TEXT,
+  width INT,
+  height INT,
+  blurhash TEXT,
+  alt_text TEXT NOT NULL DEFAULT '',
+  title TEXT NOT NULL DEFAULT '',
+  folder TEXT NOT NULL DEFAULT '',
+  tags TEXT[] NOT NULL DEFAULT '{}',
+  unreferenced_since TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS file_renditions (
+  id BIGSERIAL PRIMARY KEY,
+  file_id BIGINT NOT NULL REFERENCES files (id) ON DELETE CASCADE,
+  name TEXT UNIQUE NOT NULL,
+  size_name TEXT NOT NULL,
+  format TEXT NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  size BIGINT NOT NULL,
+  UNIQUE (file_id, size_name, format)
 );
 
 CREATE TABLE IF NOT EXISTS pages (
   id BIGSERIAL PRIMARY KEY,
   name TEXT NOT NULL,
   slug TEXT UNIQUE NOT NULL,
-  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  -- Ordered content blocks, each {"type": …, "data": {…}}. Editing changes
+  -- the draft; publishing copies it to published_blocks, which is what the
+  -- storefront sees. published_blocks is NULL until the first publish.
+  draft_blocks JSONB NOT NULL DEFAULT '[]',
+  published_blocks JSONB,
+  published_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE IF NOT EXISTS menus (
@@ -182,6 +215,19 @@ CREATE TABLE IF NOT EXISTS menus (
   created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE IF NOT EXISTS menu_items (
+  id BIGSERIAL PRIMARY KEY,
+  menu_id BIGINT NOT NULL REFERENCES menus (id) ON DELETE CASCADE,
+  parent_id BIGINT REFERENCES menu_items (id) ON DELETE CASCADE,
+  label TEXT NOT NULL,
+  link_type TEXT NOT NULL, -- 'url' | 'product' | 'collection' | 'category' | 'page' | 'post'
+  url TEXT NOT NULL DEFAULT '', -- for 'url' links
+  target_id BIGINT, -- the linked entity for the other types; posts live in the blog database
+  no INT NOT NULL DEFAULT 0,
+  is_visible BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE discounts (
   id BIGSERIAL PRIMARY KEY,
   title TEXT NOT NULL,
@@ -262,3 +308,130 @@ CREATE TABLE shipping_fees (
   created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 );
+
+CREATE TABLE redirects (
+  id BIGSERIAL PRIMARY KEY,
+  entity_type TEXT NOT NULL, -- 'product' | 'collection' | 'page'; post redirects live in the blog database
+  from_slug TEXT NOT NULL,
+  to_slug TEXT NOT NULL,
+  status_code INT NOT NULL DEFAULT 301,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (entity_type, from_slug)
+);
+
+CREATE TABLE product_questions (
+  id BIGSERIAL PRIMARY KEY,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  question TEXT NOT NULL,
+  answer TEXT,
+  status TEXT NOT NULL DEFAULT 'pending', -- 'pending' | 'published' | 'rejected'
+  answered_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE wishlist_items (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  variant_id BIGINT REFERENCES variants (id) ON DELETE CASCADE,
+  notify_price_drop BOOLEAN NOT NULL DEFAULT FALSE,
+  last_price INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE NULLS NOT DISTINCT (customer_id, product_id, variant_id)
+);
+
+CREATE TABLE wishlist_price_drops (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  variant_id BIGINT REFERENCES variants (id) ON DELETE CASCADE,
+  old_price INT NOT NULL,
+  new_price INT NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE product_views (
+  id BIGSERIAL PRIMARY KEY,
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  customer_id BIGINT REFERENCES customers (id) ON DELETE SET NULL,
+  session_id TEXT,
+  viewed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX product_views_customer_id_viewed_at_idx ON product_views (customer_id, viewed_at DESC);
+
+CREATE INDEX product_views_product_id_viewed_at_idx ON product_views (product_id, viewed_at);
+
+CREATE TABLE attributes (
+  id BIGSERIAL PRIMARY KEY,
+  code TEXT UNIQUE NOT NULL,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT 'text', -- 'text' | 'number' | 'enum' | 'boolean'
+  unit TEXT NOT NULL DEFAULT '',
+  options TEXT[] NOT NULL DEFAULT '{}',
+  is_filterable BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE category_attributes (
+  category_id BIGINT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+  attribute_id BIGINT NOT NULL REFERENCES attributes (id) ON DELETE CASCADE,
+  is_required BOOLEAN NOT NULL DEFAULT FALSE,
+  no INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (category_id, attribute_id)
+);
+
+CREATE TABLE product_attribute_values (
+  product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  attribute_id BIGINT NOT NULL REFERENCES attributes (id) ON DELETE CASCADE,
+  value TEXT NOT NULL,
+  value_number DOUBLE PRECISION,
+  PRIMARY KEY (product_id, attribute_id)
+);
+
+CREATE TABLE IF NOT EXISTS customer_sessions (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  user_agent TEXT NOT NULL,
+  ip TEXT NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS customer_refresh_tokens (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  session_id BIGINT NOT NULL REFERENCES customer_sessions (id) ON DELETE CASCADE,
+  token_hash TEXT NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS customer_otps (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+  purpose TEXT NOT NULL,
+  code_hash TEXT NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS otp_deliveries (
+  id BIGSERIAL PRIMARY KEY,
+  otp_id BIGINT NOT NULL REFERENCES customer_otps (id) ON DELETE CASCADE,
+  channel TEXT NOT NULL,
+  provider TEXT NOT NULL,
+  recipient TEXT NOT NULL,
+  status TEXT NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+const pruneInterval = 24 * time.Hour
+
+// Entry is one change to one entity. Before and After are JSON documents;
+// either may be nil.
+type Entry struct {
+	ActorID    pgtype.UUID
+	Action     string
+	EntityType string
+	EntityID   string
+	Method     string
+	Path       string
+	Status     int
+	Before     []byte
+	After      []byte
+	IP         string
+}
+
+var (
+	done = make(chan struct{})
+	wg   sync.WaitGroup
+)
+
+// Record stores an entry. Middleware records HTTP writes; call it directly
+// for changes made outside a request.
+func Record(ctx context.Context, e Entry) error {
+	return db.AuthQueries.CreateAuditLog(ctx, auth_db.CreateAuditLogParams{
+		ActorID:    e.ActorID,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   pgtype.Text{String: e.EntityID, Valid: e.EntityID != ""},
+		Method:     e.Method,
+		Path:       e.Path,
+		Status:     int32(e.Status),
+		Before:     e.Before,
+		After:      e.After,
+		Ip:         e.IP,
+	})
+}
+
+// Start runs the daily removal of entries older than config.AuditRetention.
+func Start() {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		prune()
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				prune()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func Stop() {
+	close(done)
+	wg.Wait()
+}
+
+func prune() {
+	before := pgtype.Timestamptz{Time: time.Now().Add(-config.AuditRetention), Valid: true}
+	if err := db.AuthQueries.DeleteAuditLogsBefore(context.Background(), before); err != nil {
+		log.Printf("audit: prune: %v", err)
+	}
+}
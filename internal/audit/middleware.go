@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var actions = map[string]string{
+	fiber.MethodPost:   ActionCreate,
+	fiber.MethodPut:    ActionUpdate,
+	fiber.MethodDelete: ActionDelete,
+}
+
+// Request body fields never written to the log.
+var secretField = regexp.MustCompile(`(?i)password|secret|token|otp`)
+
+// Entity types whose request bodies carry one-time codes. Only the call
+// itself is logged for them.
+var codeBodies = map[string]bool{
+	"auth/2fa/enable":         true,
+	"auth/2fa/disable":        true,
+	"auth/2fa/recovery-codes": true,
+}
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// Middleware records every create, update and delete made through the route
+// it guards. It must run after the staff guard so the actor is known. The
+// entity comes from the route: /products/:id is entity type "products" with
+// the id parameter, bulk deletes take the ids from the body and creates the
+// id from the response.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		action, ok := actions[c.Method()]
+		if !ok {
+			return c.Next()
+		}
+
+		ctx := context.Background()
+		entityType := entityType(c.Route().Path)
+		load := loaders[entityType]
+		ids := requestIDs(c)
+		var body []byte
+		if !codeBodies[entityType] {
+			body = sanitize(c.Body())
+		}
+
+		before := map[string][]byte{}
+		if load != nil && action != ActionCreate {
+			for _, id := range ids {
+				before[id] = snapshot(ctx, load, id)
+			}
+		}
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+		succeeded := status < fiber.StatusBadRequest
+		if succeeded && len(ids) == 0 && action == ActionCreate {
+			ids = responseIDs(c.Response().Body())
+		}
+		if len(ids) == 0 {
+			ids = []string{""}
+		}
+
+		entry := Entry{
+			ActorID:    actorID(c),
+			Action:     action,
+			EntityType: entityType,
+			Method:     c.Method(),
+			Path:       c.Path(),
+			Status:     status,
+			IP:         c.IP(),
+		}
+		for _, id := range ids {
+			entry.EntityID = id
+			entry.Before = before[id]
+			entry.After = nil
+			if succeeded && action != ActionDelete {
+				if load != nil && id != "" {
+					entry.After = snapshot(ctx, load, id)
+				} else {
+					entry.After = body
+				}
+			}
+			if recordErr := Record(ctx, entry); recordErr != nil {
+				log.Printf("audit: %s %s: %v", entry.Method, entry.Path, recordErr)
+			}
+		}
+		return err
+	}
+}
+
+// entityType is the route path up to its first parameter, without the API
+// prefix: /api/v1/discounts/:id/effects gives "discounts" and
+// /api/v1/attributes/categories/:id gives "attributes/categories".
+func entityType(routePath string) string {
+	var parts []string
+	for _, s := range strings.Split(strings.Trim(routePath, "/"), "/") {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			break
+		}
+		if len(parts) == 0 && (s == "api" || versionSegment.MatchString(s)) {
+			continue
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, "/")
+}
+
+func requestIDs(c *fiber.Ctx) []string {
+	if id := c.Params("id"); id != "" {
+		return []string{id}
+	}
+	var req struct {
+		IDs []json.Number `json:"ids"`
+	}
+	d := json.NewDecoder(bytes.NewReader(c.Body()))
+	d.UseNumber()
+	if d.Decode(&req) != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		ids = append(ids, id.String())
+	}
+	return ids
+}
+
+func responseIDs(body []byte) []string {
+	var res struct {
+		ID any `json:"id"`
+	}
+	if json.Unmarshal(body, &res) != nil || res.ID == nil {
+		return nil
+	}
+	switch id := res.ID.(type) {
+	case float64:
+		return []string{fmt.Sprintf("%.0f", id)}
+	case string:
+		return []string{id}
+	}
+	return nil
+}
+
+func snapshot(ctx context.Context, load loader, id string) []byte {
+	v, err := load(ctx, id)
+	if err != nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// sanitize returns a JSON request body with secret fields removed, or nil
+// for bodies that are not JSON such as file uploads.
+func sanitize(body []byte) []byte {
+	if len(body) == 0 || !json.Valid(body) {
+		return nil
+	}
+	var v any
+	if json.Unmarshal(body, &v) != nil {
+		return nil
+	}
+	b, err := json.Marshal(redact(v))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func redact(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if secretField.MatchString(k) {
+				delete(t, k)
+				continue
+			}
+			t[k] = redact(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = redact(child)
+		}
+	}
+	return v
+}
+
+func actorID(c *fiber.Ctx) pgtype.UUID {
+	var id pgtype.UUID
+	if t, ok := c.Locals("user").(*jwt.Token); ok {
+		if claims, ok := t.Claims.(jwt.MapClaims); ok {
+			if sub, ok := claims["sub"].(string); ok {
+				_ = id.Scan(sub)
+			}
+		}
+	}
+	return id
+}
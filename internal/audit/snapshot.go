@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"app/internal/db"
+	blog_db "app/internal/db/blog"
+	product_db "app/internal/db/product"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// loader reads the current state of one entity for the before and after
+// snapshots. Entity types without a loader record the request body as
+// after instead.
+type loader func(ctx context.Context, id string) (any, error)
+
+// product and blog take method expressions so the queries are resolved at
+// call time, after db.Init.
+func product[T any](get func(*product_db.Queries, context.Context, int64) (T, error)) loader {
+	return func(ctx context.Context, id string) (any, error) {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return get(db.ProductQueries, ctx, n)
+	}
+}
+
+func blog[T any](get func(*blog_db.Queries, context.Context, int64) (T, error)) loader {
+	return func(ctx context.Context, id string) (any, error) {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return get(db.BlogQueries, ctx, n)
+	}
+}
+
+var loaders = map[string]loader{
+	"attributes":       product((*product_db.Queries).GetAttribute),
+	"categories":       product((*product_db.Queries).GetCategory),
+	"collections":      product((*product_db.Queries).GetCollection),
+	"customers":        product((*product_db.Queries).GetCustomer),
+	"discounts":        product((*product_db.Queries).GetDiscountWithRelations),
+	"files":            product((*product_db.Queries).GetFile),
+	"hotspots":         product((*product_db.Queries).GetHotspot),
+	"menus":            product((*product_db.Queries).GetMenu),
+	"orders":           product((*product_db.Queries).GetOrder),
+	"pages":            product(getPage),
+	"posts":            blog((*blog_db.Queries).GetPost),
+	"posts/categories": blog((*blog_db.Queries).GetPostCategory),
+	"products":         product((*product_db.Queries).GetProduct),
+	"questions":        product((*product_db.Queries).GetQuestion),
+	"redirects":        product((*product_db.Queries).GetRedirect),
+	"shipping-fees":    product((*product_db.Queries).GetShippingFee),
+	"users": func(ctx context.Context, id string) (any, error) {
+		var userID pgtype.UUID
+		if err := userID.Scan(id); err != nil {
+			return nil, err
+		}
+		return db.AuthQueries.GetUser(ctx, userID)
+	},
+}
+
+// getPage loads a page with its blocks as JSON; the generated row holds
+// them as bytes, which would be snapshotted as base64.
+func getPage(q *product_db.Queries, ctx context.Context, id int64) (any, error) {
+	p, err := q.GetPage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		product_db.GetPageRow
+		DraftBlocks     json.RawMessage `json:"draft_blocks"`
+		PublishedBlocks json.RawMessage `json:"published_blocks"`
+	}{p, p.DraftBlocks, p.PublishedBlocks}, nil
+}
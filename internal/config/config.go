@@ -0,0 +1,277 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	SiteURL  string
+	MediaURL string
+
+	// StorageDriver is "local" to keep uploads under StorageLocalDir, served
+	// by the API at /media, or "s3" for an S3-compatible bucket such as MinIO.
+	StorageDriver   string
+	StorageLocalDir string
+	S3Endpoint      string
+	S3Region        string
+	S3Bucket        string
+	S3AccessKey     string
+	S3SecretKey     string
+	// S3PathStyle puts the bucket in the path instead of the host name, as
+	// MinIO expects.
+	S3PathStyle bool
+
+	// UploadMaxSize is the largest file accepted per upload, in bytes, and
+	// UploadMaxFiles the most files in one request.
+	UploadMaxSize  int
+	UploadMaxFiles int
+
+	// ImageSizes are the widths uploaded images are rendered at, from
+	// IMAGE_SIZES ("thumbnail:320,medium:768,large:1600"), each in every
+	// ImageFormats format ("webp" or "jpeg", default "jpeg"). WebP
+	// renditions are lossless: they suit graphics and transparency, but a
+	// photo comes out several times larger than as JPEG, so only add webp
+	// for catalogs that need it.
+	ImageSizes   []ImageSize
+	ImageFormats []string
+	ImageQuality int
+
+	// MediaGCInterval is how often unreferenced media is looked for, 0 to
+	// only run it by hand, and MediaGCAfter how long a file must have gone
+	// unreferenced before it is deleted. With MediaGCDryRun the scheduled
+	// run only logs what it would delete.
+	MediaGCInterval time.Duration
+	MediaGCAfter    time.Duration
+	MediaGCDryRun   bool
+
+	// ProxyHeader names the header carrying the client IP when the API runs
+	// behind a proxy, e.g. X-Forwarded-For. It is only trusted from
+	// TrustedProxies when that list is set.
+	ProxyHeader    string
+	TrustedProxies []string
+
+	// LoginLimitStore is "memory" for a single instance or "postgres" to
+	// share login failure counters between instances.
+	LoginLimitStore string
+
+	// AuditRetention is how long audit log entries are kept.
+	AuditRetention time.Duration
+
+	// PostPublishInterval is how often due scheduled blog posts are marked
+	// as published, 0 to never do it automatically. Due posts are public
+	// either way; with 0 they stay listed as scheduled in the admin.
+	PostPublishInterval time.Duration
+
+	// JWTKeys holds every key accepted when verifying tokens, by key ID.
+	// JWTKeyID names the one used to sign new tokens.
+	JWTKeys         map[string][]byte
+	JWTKeyID        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// TOTPIssuer is the account issuer shown in authenticator apps.
+	TOTPIssuer string
+
+	// OTP delivery. OTPPhoneProvider is one of "zns", "sms" or "fake" and
+	// OTPEmailProvider one of "email" or "fake"; the fake provider only logs
+	// the code.
+	OTPPhoneProvider   string
+	OTPEmailProvider   string
+	OTPTTL             time.Duration
+	OTPMaxAttempts     int
+	OTPResendCooldown  time.Duration
+	OTPMaxSendsPerHour int
+
+	ZNSURL         string
+	ZNSAccessToken string
+	ZNSTemplateID  string
+
+	SMSURL    string
+	SMSAPIKey string
+	SMSSender string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+)
+
+func Init() {
+	SiteURL = strings.TrimRight(os.Getenv("SITE_URL"), "/")
+	initStorage()
+
+	ProxyHeader = os.Getenv("PROXY_HEADER")
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			TrustedProxies = append(TrustedProxies, p)
+		}
+	}
+	LoginLimitStore = stringEnv("LOGIN_LIMIT_STORE", "memory")
+	AuditRetention = durationEnv("AUDIT_RETENTION", 90*24*time.Hour)
+	if os.Getenv("POST_PUBLISH_INTERVAL") != "0" {
+		PostPublishInterval = durationEnv("POST_PUBLISH_INTERVAL", time.Minute)
+	}
+
+	initJWT()
+	initOTP()
+}
+
+// initJWT loads signing keys from JWT_KEYS ("kid:secret,kid:secret"). The
+// first key signs unless JWT_KEY_ID picks another, so a key can be rotated by
+// adding the new one in front and dropping the old one once its tokens have
+// expired. JWT_SECRET is accepted as a single key with ID "default".
+func initJWT() {
+	JWTKeys = map[string][]byte{}
+	for _, pair := range strings.Split(os.Getenv("JWT_KEYS"), ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || secret == "" {
+			continue
+		}
+		JWTKeys[kid] = []byte(secret)
+		if JWTKeyID == "" {
+			JWTKeyID = kid
+		}
+	}
+	if secret := os.Getenv("JWT_SECRET"); secret != "" && len(JWTKeys) == 0 {
+		JWTKeys["default"] = []byte(secret)
+		JWTKeyID = "default"
+	}
+	if kid := os.Getenv("JWT_KEY_ID"); kid != "" {
+		JWTKeyID = kid
+	}
+	if _, ok := JWTKeys[JWTKeyID]; !ok {
+		log.Fatal("JWT signing key not set in environment variables")
+	}
+
+	AccessTokenTTL = durationEnv("JWT_ACCESS_TTL", 15*time.Minute)
+	RefreshTokenTTL = durationEnv("JWT_REFRESH_TTL", 30*24*time.Hour)
+	TOTPIssuer = stringEnv("TOTP_ISSUER", "Admin")
+}
+
+// initStorage picks the upload backend. MEDIA_URL is where stored files are
+// publicly reachable: the address of this API for local storage, or the
+// bucket or a CDN in front of it for S3. Without it the bucket URL is used
+// for S3 and SITE_URL for local storage.
+func initStorage() {
+	StorageDriver = stringEnv("STORAGE_DRIVER", "local")
+	StorageLocalDir = stringEnv("STORAGE_LOCAL_DIR", "uploads")
+	S3Endpoint = strings.TrimRight(os.Getenv("S3_ENDPOINT"), "/")
+	S3Region = stringEnv("S3_REGION", "us-east-1")
+	S3Bucket = os.Getenv("S3_BUCKET")
+	S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	S3SecretKey = os.Getenv("S3_SECRET_KEY")
+	S3PathStyle = stringEnv("S3_PATH_STYLE", "true") == "true"
+
+	UploadMaxSize = intEnv("UPLOAD_MAX_SIZE", 10<<20)
+	UploadMaxFiles = intEnv("UPLOAD_MAX_FILES", 10)
+
+	for _, pair := range strings.Split(stringEnv("IMAGE_SIZES", "thumbnail:320,medium:768,large:1600"), ",") {
+		name, width, _ := strings.Cut(strings.TrimSpace(pair), ":")
+		w, err := strconv.Atoi(width)
+		if name == "" || err != nil || w <= 0 {
+			log.Fatalf("Invalid size in IMAGE_SIZES: %q", pair)
+		}
+		ImageSizes = append(ImageSizes, ImageSize{Name: name, Width: w})
+	}
+	for _, f := range strings.Split(stringEnv("IMAGE_FORMATS", "jpeg"), ",") {
+		switch f = strings.TrimSpace(f); f {
+		case "webp", "jpeg":
+			ImageFormats = append(ImageFormats, f)
+		default:
+			log.Fatalf("Unknown format in IMAGE_FORMATS: %q", f)
+		}
+	}
+	ImageQuality = intEnv("IMAGE_QUALITY", 82)
+
+	if os.Getenv("MEDIA_GC_INTERVAL") != "0" {
+		MediaGCInterval = durationEnv("MEDIA_GC_INTERVAL", 24*time.Hour)
+	}
+	MediaGCAfter = durationEnv("MEDIA_GC_AFTER", 30*24*time.Hour)
+	MediaGCDryRun = stringEnv("MEDIA_GC_DRY_RUN", "true") == "true"
+
+	MediaURL = strings.TrimRight(os.Getenv("MEDIA_URL"), "/")
+	if MediaURL == "" {
+		MediaURL = SiteURL
+		if StorageDriver == "s3" {
+			MediaURL = S3Endpoint + "/" + S3Bucket
+		}
+	}
+}
+
+func initOTP() {
+	OTPPhoneProvider = stringEnv("OTP_PHONE_PROVIDER", "fake")
+	OTPEmailProvider = stringEnv("OTP_EMAIL_PROVIDER", "fake")
+	OTPTTL = durationEnv("OTP_TTL", 5*time.Minute)
+	OTPMaxAttempts = intEnv("OTP_MAX_ATTEMPTS", 5)
+	OTPResendCooldown = durationEnv("OTP_RESEND_COOLDOWN", time.Minute)
+	OTPMaxSendsPerHour = intEnv("OTP_MAX_SENDS_PER_HOUR", 5)
+
+	ZNSURL = stringEnv("ZNS_URL", "https://business.openapi.zalo.me/message/template")
+	ZNSAccessToken = os.Getenv("ZNS_ACCESS_TOKEN")
+	ZNSTemplateID = os.Getenv("ZNS_TEMPLATE_ID")
+
+	SMSURL = os.Getenv("SMS_URL")
+	SMSAPIKey = os.Getenv("SMS_API_KEY")
+	SMSSender = os.Getenv("SMS_SENDER")
+
+	SMTPHost = os.Getenv("SMTP_HOST")
+	SMTPPort = stringEnv("SMTP_PORT", "587")
+	SMTPUsername = os.Getenv("SMTP_USERNAME")
+	SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	SMTPFrom = os.Getenv("SMTP_FROM")
+}
+
+// ImageSize is a named rendition width.
+type ImageSize struct {
+	Name  string
+	Width int
+}
+
+func stringEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func intEnv(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Fatalf("Invalid number in %s: %q", key, v)
+	}
+	return n
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Fatalf("Invalid duration in %s: %q", key, v)
+	}
+	return d
+}
+
+// AbsoluteURL joins a storefront path onto SiteURL.
+func AbsoluteURL(path string) string {
+	return SiteURL + "/" + strings.TrimLeft(path, "/")
+}
+
+// FileURL returns the public link of a stored file name. Names that are
+// already absolute URLs are returned unchanged.
+func FileURL(name string) string {
+	if name == "" || strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+		return name
+	}
+	return MediaURL + "/" + strings.TrimLeft(name, "/")
+}
@@ -8,11 +8,106 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiKey struct {
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Prefix      string             `json:"prefix"`
+	KeyHash     string             `json:"key_hash"`
+	Permissions []string           `json:"permissions"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID         int64              `json:"id"`
+	ActorID    pgtype.UUID        `json:"actor_id"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   pgtype.Text        `json:"entity_id"`
+	Method     string             `json:"method"`
+	Path       string             `json:"path"`
+	Status     int32              `json:"status"`
+	Before     []byte             `json:"before"`
+	After      []byte             `json:"after"`
+	Ip         string             `json:"ip"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	Key              string             `json:"key"`
+	Failures         int32              `json:"failures"`
+	LastFailedAt     pgtype.Timestamptz `json:"last_failed_at"`
+	PreviousFailedAt pgtype.Timestamptz `json:"previous_failed_at"`
+	LockedUntil      pgtype.Timestamptz `json:"locked_until"`
+}
+
+type Permission struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type RefreshToken struct {
+	ID        int64              `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	SessionID int64              `json:"session_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Role struct {
+	ID        int64              `json:"id"`
+	Code      string             `json:"code"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type RolePermission struct {
+	RoleID     int64  `json:"role_id"`
+	Permission string `json:"permission"`
+}
+
+type Session struct {
+	ID         int64              `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	UserAgent  string             `json:"user_agent"`
+	Ip         string             `json:"ip"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 type User struct {
-	ID       pgtype.UUID `json:"id"`
-	Name     string      `json:"name"`
-	Email    pgtype.Text `json:"email"`
-	Phone    pgtype.Text `json:"phone"`
-	Username pgtype.Text `json:"username"`
-	Password string      `json:"password"`
+	ID           pgtype.UUID `json:"id"`
+	Name         string      `json:"name"`
+	Email        pgtype.Text `json:"email"`
+	Phone        pgtype.Text `json:"phone"`
+	Username     pgtype.Text `json:"username"`
+	Password     string      `json:"password"`
+	TotpSecret   pgtype.Text `json:"totp_secret"`
+	TotpEnabled  bool        `json:"totp_enabled"`
+	TotpLastStep pgtype.Int8 `json:"totp_last_step"`
+}
+
+type UserRecoveryCode struct {
+	ID        int64              `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserRole struct {
+	UserID pgtype.UUID `json:"user_id"`
+	RoleID int64       `json:"role_id"`
 }
@@ -81,7 +81,8 @@ SELECT
   phone,
   email,
   username,
-  password
+  password,
+  totp_enabled
 FROM
   users
 WHERE
@@ -99,12 +100,13 @@ type GetUserByIdentifyParams struct {
 }
 
 type GetUserByIdentifyRow struct {
-	ID       pgtype.UUID `json:"id"`
-	Name     string      `json:"name"`
-	Phone    pgtype.Text `json:"phone"`
-	Email    pgtype.Text `json:"email"`
-	Username pgtype.Text `json:"username"`
-	Password string      `json:"password"`
+	ID          pgtype.UUID `json:"id"`
+	Name        string      `json:"name"`
+	Phone       pgtype.Text `json:"phone"`
+	Email       pgtype.Text `json:"email"`
+	Username    pgtype.Text `json:"username"`
+	Password    string      `json:"password"`
+	TotpEnabled bool        `json:"totp_enabled"`
 }
 
 func (q *Queries) GetUserByIdentify(ctx context.Context, arg GetUserByIdentifyParams) (GetUserByIdentifyRow, error) {
@@ -117,10 +119,46 @@ func (q *Queries) GetUserByIdentify(ctx context.Context, arg GetUserByIdentifyPa
 		&i.Email,
 		&i.Username,
 		&i.Password,
+		&i.TotpEnabled,
 	)
 	return i, err
 }
 
+const getUserNames = `-- name: GetUserNames :many
+SELECT
+  id,
+  name
+FROM
+  users
+WHERE
+  id = ANY ($1::uuid[])
+`
+
+type GetUserNamesRow struct {
+	ID   pgtype.UUID `json:"id"`
+	Name string      `json:"name"`
+}
+
+func (q *Queries) GetUserNames(ctx context.Context, ids []pgtype.UUID) ([]GetUserNamesRow, error) {
+	rows, err := q.db.Query(ctx, getUserNames, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserNamesRow
+	for rows.Next() {
+		var i GetUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
 SELECT
   id,
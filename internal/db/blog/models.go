@@ -20,11 +20,36 @@ type Post struct {
 	OgDescription   string           `json:"og_description"`
 	OgImage         string           `json:"og_image"`
 	File            pgtype.Text      `json:"file"`
+	Body            string           `json:"body"`
+	BodyFormat      string           `json:"body_format"`
+	Excerpt         string           `json:"excerpt"`
+	AuthorID        pgtype.UUID      `json:"author_id"`
+	CategoryID      pgtype.Int8      `json:"category_id"`
+	ReadingTime     int32            `json:"reading_time"`
 	IsActive        bool             `json:"is_active"`
+	IsScheduled     bool             `json:"is_scheduled"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
 	CreatedAt       pgtype.Timestamp `json:"created_at"`
 	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
 }
 
+type PostCategory struct {
+	ID          int64            `json:"id"`
+	Name        string           `json:"name"`
+	Slug        string           `json:"slug"`
+	Description string           `json:"description"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type PostRedirect struct {
+	ID         int64              `json:"id"`
+	FromSlug   string             `json:"from_slug"`
+	ToSlug     string             `json:"to_slug"`
+	StatusCode int32              `json:"status_code"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
 type PostTag struct {
 	Name   string `json:"name"`
 	PostID int64  `json:"post_id"`
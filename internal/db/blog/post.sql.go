@@ -40,12 +40,36 @@ const countPublicPosts = `-- name: CountPublicPosts :one
 SELECT
   COUNT(*)
 FROM
-  posts
-WHERE is_active = true
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+  AND (
+    $1::text IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        post_tags t
+      WHERE
+        t.post_id = p.id
+        AND t.name = $1
+    )
+  )
+  AND (
+    $2::text IS NULL
+    OR c.slug = $2
+  )
 `
 
-func (q *Queries) CountPublicPosts(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countPublicPosts)
+type CountPublicPostsParams struct {
+	Tag      pgtype.Text `json:"tag"`
+	Category pgtype.Text `json:"category"`
+}
+
+func (q *Queries) CountPublicPosts(ctx context.Context, arg CountPublicPostsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPublicPosts, arg.Tag, arg.Category)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -53,36 +77,126 @@ func (q *Queries) CountPublicPosts(ctx context.Context) (int64, error) {
 
 const createPost = `-- name: CreatePost :one
 INSERT INTO
-  posts (title, slug, file)
+  posts (
+    title,
+    slug,
+    file,
+    body,
+    body_format,
+    excerpt,
+    author_id,
+    category_id,
+    reading_time,
+    published_at,
+    is_active,
+    is_scheduled
+  )
 VALUES
-  ($1, $2, $3)
+  (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    COALESCE($10::timestamptz, NOW()),
+    COALESCE($10::timestamptz <= NOW(), true),
+    COALESCE($10::timestamptz > NOW(), false)
+  )
 RETURNING id
 `
 
 type CreatePostParams struct {
-	Title string      `json:"title"`
-	Slug  string      `json:"slug"`
-	File  pgtype.Text `json:"file"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	File        pgtype.Text        `json:"file"`
+	Body        string             `json:"body"`
+	BodyFormat  string             `json:"body_format"`
+	Excerpt     string             `json:"excerpt"`
+	AuthorID    pgtype.UUID        `json:"author_id"`
+	CategoryID  pgtype.Int8        `json:"category_id"`
+	ReadingTime int32              `json:"reading_time"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (int64, error) {
-	row := q.db.QueryRow(ctx, createPost, arg.Title, arg.Slug, arg.File)
+	row := q.db.QueryRow(ctx, createPost,
+		arg.Title,
+		arg.Slug,
+		arg.File,
+		arg.Body,
+		arg.BodyFormat,
+		arg.Excerpt,
+		arg.AuthorID,
+		arg.CategoryID,
+		arg.ReadingTime,
+		arg.PublishedAt,
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
 }
 
+const deletePostTags = `-- name: DeletePostTags :exec
+DELETE FROM post_tags
+WHERE
+  post_id = $1
+`
+
+func (q *Queries) DeletePostTags(ctx context.Context, postID int64) error {
+	_, err := q.db.Exec(ctx, deletePostTags, postID)
+	return err
+}
+
 const getPost = `-- name: GetPost :one
-SELECT id, title, slug, file
-FROM posts
-WHERE id = $1
+SELECT
+  id,
+  title,
+  slug,
+  file,
+  body,
+  body_format,
+  excerpt,
+  author_id,
+  category_id,
+  reading_time,
+  is_active,
+  is_scheduled,
+  ARRAY(
+    SELECT
+      t.name
+    FROM
+      post_tags t
+    WHERE
+      t.post_id = posts.id
+    ORDER BY
+      t.name
+  )::text[] AS tags,
+  published_at
+FROM
+  posts
+WHERE
+  id = $1
 `
 
 type GetPostRow struct {
-	ID    int64       `json:"id"`
-	Title string      `json:"title"`
-	Slug  string      `json:"slug"`
-	File  pgtype.Text `json:"file"`
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Slug        string           `json:"slug"`
+	File        pgtype.Text      `json:"file"`
+	Body        string           `json:"body"`
+	BodyFormat  string           `json:"body_format"`
+	Excerpt     string           `json:"excerpt"`
+	AuthorID    pgtype.UUID      `json:"author_id"`
+	CategoryID  pgtype.Int8      `json:"category_id"`
+	ReadingTime int32            `json:"reading_time"`
+	IsActive    bool             `json:"is_active"`
+	IsScheduled bool             `json:"is_scheduled"`
+	Tags        []string         `json:"tags"`
+	PublishedAt pgtype.Timestamp `json:"published_at"`
 }
 
 func (q *Queries) GetPost(ctx context.Context, id int64) (GetPostRow, error) {
@@ -93,14 +207,54 @@ func (q *Queries) GetPost(ctx context.Context, id int64) (GetPostRow, error) {
 		&i.Title,
 		&i.Slug,
 		&i.File,
+		&i.Body,
+		&i.BodyFormat,
+		&i.Excerpt,
+		&i.AuthorID,
+		&i.CategoryID,
+		&i.ReadingTime,
+		&i.IsActive,
+		&i.IsScheduled,
+		&i.Tags,
+		&i.PublishedAt,
 	)
 	return i, err
 }
 
 const getPostBySlug = `-- name: GetPostBySlug :one
-SELECT id, title, slug, file, meta_title, meta_description, created_at
-FROM posts
-WHERE slug = $1
+SELECT
+  p.id,
+  p.title,
+  p.slug,
+  p.file,
+  p.body,
+  p.body_format,
+  p.excerpt,
+  p.reading_time,
+  p.author_id,
+  p.meta_title,
+  p.meta_description,
+  c.name AS category_name,
+  c.slug AS category_slug,
+  ARRAY(
+    SELECT
+      t.name
+    FROM
+      post_tags t
+    WHERE
+      t.post_id = p.id
+    ORDER BY
+      t.name
+  )::text[] AS tags,
+  p.published_at,
+  p.created_at
+FROM
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  p.slug = $1
+  AND (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
 `
 
 type GetPostBySlugRow struct {
@@ -108,14 +262,190 @@ type GetPostBySlugRow struct {
 	Title           string           `json:"title"`
 	Slug            string           `json:"slug"`
 	File            pgtype.Text      `json:"file"`
+	Body            string           `json:"body"`
+	BodyFormat      string           `json:"body_format"`
+	Excerpt         string           `json:"excerpt"`
+	ReadingTime     int32            `json:"reading_time"`
+	AuthorID        pgtype.UUID      `json:"author_id"`
 	MetaTitle       string           `json:"meta_title"`
 	MetaDescription string           `json:"meta_description"`
+	CategoryName    pgtype.Text      `json:"category_name"`
+	CategorySlug    pgtype.Text      `json:"category_slug"`
+	Tags            []string         `json:"tags"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
 	CreatedAt       pgtype.Timestamp `json:"created_at"`
 }
 
 func (q *Queries) GetPostBySlug(ctx context.Context, slug string) (GetPostBySlugRow, error) {
 	row := q.db.QueryRow(ctx, getPostBySlug, slug)
 	var i GetPostBySlugRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.File,
+		&i.Body,
+		&i.BodyFormat,
+		&i.Excerpt,
+		&i.ReadingTime,
+		&i.AuthorID,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CategoryName,
+		&i.CategorySlug,
+		&i.Tags,
+		&i.PublishedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPostFileUsages = `-- name: GetPostFileUsages :many
+SELECT
+  id,
+  title,
+  'og_image' AS field,
+  og_image AS file_name
+FROM
+  posts
+WHERE
+  og_image = ANY ($1::text[])
+UNION ALL
+SELECT
+  id,
+  title,
+  'file',
+  file
+FROM
+  posts
+WHERE
+  file = ANY ($1::text[])
+UNION ALL
+SELECT
+  p.id,
+  p.title,
+  'body',
+  n.name
+FROM
+  posts p
+  -- Matching without the extension also finds renditions of the file.
+  JOIN unnest($1::text[]) AS n (name) ON strpos(p.body, regexp_replace(n.name, '\.[^./]*$', '')) > 0
+`
+
+type GetPostFileUsagesRow struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Field    string `json:"field"`
+	FileName string `json:"file_name"`
+}
+
+func (q *Queries) GetPostFileUsages(ctx context.Context, names []string) ([]GetPostFileUsagesRow, error) {
+	rows, err := q.db.Query(ctx, getPostFileUsages, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPostFileUsagesRow
+	for rows.Next() {
+		var i GetPostFileUsagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Field,
+			&i.FileName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPostLinks = `-- name: GetPostLinks :many
+SELECT
+  id,
+  slug,
+  (
+    (is_active OR is_scheduled)
+    AND published_at <= NOW()
+  )::boolean AS is_active
+FROM
+  posts
+WHERE
+  id = ANY ($1::bigint[])
+`
+
+type GetPostLinksRow struct {
+	ID       int64  `json:"id"`
+	Slug     string `json:"slug"`
+	IsActive bool   `json:"is_active"`
+}
+
+func (q *Queries) GetPostLinks(ctx context.Context, ids []int64) ([]GetPostLinksRow, error) {
+	rows, err := q.db.Query(ctx, getPostLinks, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPostLinksRow
+	for rows.Next() {
+		var i GetPostLinksRow
+		if err := rows.Scan(&i.ID, &i.Slug, &i.IsActive); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPostSeoBySlug = `-- name: GetPostSeoBySlug :one
+SELECT
+  id,
+  title,
+  slug,
+  file,
+  meta_title,
+  meta_description,
+  canonical_url,
+  og_title,
+  og_description,
+  og_image,
+  published_at,
+  created_at,
+  updated_at
+FROM
+  posts
+WHERE
+  slug = $1
+  AND (is_active OR is_scheduled)
+  AND published_at <= NOW()
+`
+
+type GetPostSeoBySlugRow struct {
+	ID              int64            `json:"id"`
+	Title           string           `json:"title"`
+	Slug            string           `json:"slug"`
+	File            pgtype.Text      `json:"file"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgTitle         string           `json:"og_title"`
+	OgDescription   string           `json:"og_description"`
+	OgImage         string           `json:"og_image"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+}
+
+func (q *Queries) GetPostSeoBySlug(ctx context.Context, slug string) (GetPostSeoBySlugRow, error) {
+	row := q.db.QueryRow(ctx, getPostSeoBySlug, slug)
+	var i GetPostSeoBySlugRow
 	err := row.Scan(
 		&i.ID,
 		&i.Title,
@@ -123,14 +453,62 @@ func (q *Queries) GetPostBySlug(ctx context.Context, slug string) (GetPostBySlug
 		&i.File,
 		&i.MetaTitle,
 		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.PublishedAt,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getPostTags = `-- name: GetPostTags :many
+SELECT
+  t.name,
+  COUNT(*) AS post_count
+FROM
+  post_tags t
+  JOIN posts p ON p.id = t.post_id
+WHERE
+  (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+GROUP BY
+  t.name
+ORDER BY
+  t.name
+`
+
+type GetPostTagsRow struct {
+	Name      string `json:"name"`
+	PostCount int64  `json:"post_count"`
+}
+
+func (q *Queries) GetPostTags(ctx context.Context) ([]GetPostTagsRow, error) {
+	rows, err := q.db.Query(ctx, getPostTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPostTagsRow
+	for rows.Next() {
+		var i GetPostTagsRow
+		if err := rows.Scan(&i.Name, &i.PostCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPosts = `-- name: GetPosts :many
-SELECT id, title, slug, file
+SELECT id, title, slug, file, is_active, is_scheduled, published_at
 FROM posts
+ORDER BY published_at DESC NULLS LAST, id DESC
 LIMIT
   $1
 OFFSET
@@ -143,10 +521,13 @@ type GetPostsParams struct {
 }
 
 type GetPostsRow struct {
-	ID    int64       `json:"id"`
-	Title string      `json:"title"`
-	Slug  string      `json:"slug"`
-	File  pgtype.Text `json:"file"`
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Slug        string           `json:"slug"`
+	File        pgtype.Text      `json:"file"`
+	IsActive    bool             `json:"is_active"`
+	IsScheduled bool             `json:"is_scheduled"`
+	PublishedAt pgtype.Timestamp `json:"published_at"`
 }
 
 func (q *Queries) GetPosts(ctx context.Context, arg GetPostsParams) ([]GetPostsRow, error) {
@@ -163,6 +544,9 @@ func (q *Queries) GetPosts(ctx context.Context, arg GetPostsParams) ([]GetPostsR
 			&i.Title,
 			&i.Slug,
 			&i.File,
+			&i.IsActive,
+			&i.IsScheduled,
+			&i.PublishedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -175,18 +559,66 @@ func (q *Queries) GetPosts(ctx context.Context, arg GetPostsParams) ([]GetPostsR
 }
 
 const getPublicPosts = `-- name: GetPublicPosts :many
-SELECT id, title, slug, file, meta_title, meta_description, created_at
-FROM posts
-WHERE is_active = true
+SELECT
+  p.id,
+  p.title,
+  p.slug,
+  p.file,
+  p.excerpt,
+  p.reading_time,
+  p.author_id,
+  p.meta_title,
+  p.meta_description,
+  c.name AS category_name,
+  c.slug AS category_slug,
+  ARRAY(
+    SELECT
+      t.name
+    FROM
+      post_tags t
+    WHERE
+      t.post_id = p.id
+    ORDER BY
+      t.name
+  )::text[] AS tags,
+  p.published_at,
+  p.created_at
+FROM
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  (p.is_active OR p.is_scheduled)
+  AND p.published_at <= NOW()
+  AND (
+    $1::text IS NULL
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        post_tags t
+      WHERE
+        t.post_id = p.id
+        AND t.name = $1
+    )
+  )
+  AND (
+    $2::text IS NULL
+    OR c.slug = $2
+  )
+ORDER BY
+  p.published_at DESC NULLS LAST,
+  p.id DESC
 LIMIT
-  $1
+  $3
 OFFSET
-  $2
+  $4
 `
 
 type GetPublicPostsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Tag         pgtype.Text `json:"tag"`
+	Category    pgtype.Text `json:"category"`
+	LimitCount  int32       `json:"limit_count"`
+	OffsetCount int32       `json:"offset_count"`
 }
 
 type GetPublicPostsRow struct {
@@ -194,13 +626,25 @@ type GetPublicPostsRow struct {
 	Title           string           `json:"title"`
 	Slug            string           `json:"slug"`
 	File            pgtype.Text      `json:"file"`
+	Excerpt         string           `json:"excerpt"`
+	ReadingTime     int32            `json:"reading_time"`
+	AuthorID        pgtype.UUID      `json:"author_id"`
 	MetaTitle       string           `json:"meta_title"`
 	MetaDescription string           `json:"meta_description"`
+	CategoryName    pgtype.Text      `json:"category_name"`
+	CategorySlug    pgtype.Text      `json:"category_slug"`
+	Tags            []string         `json:"tags"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
 	CreatedAt       pgtype.Timestamp `json:"created_at"`
 }
 
 func (q *Queries) GetPublicPosts(ctx context.Context, arg GetPublicPostsParams) ([]GetPublicPostsRow, error) {
-	rows, err := q.db.Query(ctx, getPublicPosts, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getPublicPosts,
+		arg.Tag,
+		arg.Category,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -213,8 +657,15 @@ func (q *Queries) GetPublicPosts(ctx context.Context, arg GetPublicPostsParams)
 			&i.Title,
 			&i.Slug,
 			&i.File,
+			&i.Excerpt,
+			&i.ReadingTime,
+			&i.AuthorID,
 			&i.MetaTitle,
 			&i.MetaDescription,
+			&i.CategoryName,
+			&i.CategorySlug,
+			&i.Tags,
+			&i.PublishedAt,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -227,29 +678,184 @@ func (q *Queries) GetPublicPosts(ctx context.Context, arg GetPublicPostsParams)
 	return items, nil
 }
 
+const getScheduledPosts = `-- name: GetScheduledPosts :many
+SELECT
+  p.id,
+  p.title,
+  p.slug,
+  p.author_id,
+  c.name AS category_name,
+  p.published_at::date AS publish_date,
+  p.published_at
+FROM
+  posts p
+  LEFT JOIN post_categories c ON c.id = p.category_id
+WHERE
+  p.is_scheduled = true
+  AND p.published_at >= $1::date
+  AND p.published_at < $2::date + 1
+ORDER BY
+  p.published_at,
+  p.id
+`
+
+type GetScheduledPostsParams struct {
+	FromDate pgtype.Date `json:"from_date"`
+	ToDate   pgtype.Date `json:"to_date"`
+}
+
+type GetScheduledPostsRow struct {
+	ID           int64            `json:"id"`
+	Title        string           `json:"title"`
+	Slug         string           `json:"slug"`
+	AuthorID     pgtype.UUID      `json:"author_id"`
+	CategoryName pgtype.Text      `json:"category_name"`
+	PublishDate  pgtype.Date      `json:"publish_date"`
+	PublishedAt  pgtype.Timestamp `json:"published_at"`
+}
+
+func (q *Queries) GetScheduledPosts(ctx context.Context, arg GetScheduledPostsParams) ([]GetScheduledPostsRow, error) {
+	rows, err := q.db.Query(ctx, getScheduledPosts, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetScheduledPostsRow
+	for rows.Next() {
+		var i GetScheduledPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.AuthorID,
+			&i.CategoryName,
+			&i.PublishDate,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertPostTags = `-- name: InsertPostTags :exec
+INSERT INTO
+  post_tags (post_id, name)
+SELECT
+  $1,
+  unnest($2::text[])
+ON CONFLICT DO NOTHING
+`
+
+type InsertPostTagsParams struct {
+	PostID int64    `json:"post_id"`
+	Names  []string `json:"names"`
+}
+
+func (q *Queries) InsertPostTags(ctx context.Context, arg InsertPostTagsParams) error {
+	_, err := q.db.Exec(ctx, insertPostTags, arg.PostID, arg.Names)
+	return err
+}
+
+const publishDuePosts = `-- name: PublishDuePosts :many
+UPDATE posts
+SET
+  is_active = true,
+  is_scheduled = false,
+  updated_at = NOW()
+WHERE
+  is_scheduled = true
+  AND published_at <= NOW()
+RETURNING
+  id,
+  slug,
+  published_at
+`
+
+type PublishDuePostsRow struct {
+	ID          int64            `json:"id"`
+	Slug        string           `json:"slug"`
+	PublishedAt pgtype.Timestamp `json:"published_at"`
+}
+
+func (q *Queries) PublishDuePosts(ctx context.Context) ([]PublishDuePostsRow, error) {
+	rows, err := q.db.Query(ctx, publishDuePosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PublishDuePostsRow
+	for rows.Next() {
+		var i PublishDuePostsRow
+		if err := rows.Scan(&i.ID, &i.Slug, &i.PublishedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePost = `-- name: UpdatePost :exec
 UPDATE posts
 SET
-  title = $2,
-  slug = $3,
-  file = $4
+  title = $1,
+  slug = $2,
+  file = $3,
+  body = $4,
+  body_format = $5,
+  excerpt = $6,
+  author_id = $7,
+  category_id = $8,
+  reading_time = $9,
+  published_at = COALESCE($10::timestamptz, published_at),
+  is_active = CASE
+    WHEN $10::timestamptz IS NULL THEN is_active
+    WHEN $10::timestamptz > NOW() THEN false
+    WHEN is_scheduled THEN true
+    ELSE is_active
+  END,
+  is_scheduled = COALESCE($10::timestamptz > NOW(), is_scheduled),
+  updated_at = NOW()
 WHERE
-  id = $1
+  id = $11
 `
 
 type UpdatePostParams struct {
-	ID    int64       `json:"id"`
-	Title string      `json:"title"`
-	Slug  string      `json:"slug"`
-	File  pgtype.Text `json:"file"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	File        pgtype.Text        `json:"file"`
+	Body        string             `json:"body"`
+	BodyFormat  string             `json:"body_format"`
+	Excerpt     string             `json:"excerpt"`
+	AuthorID    pgtype.UUID        `json:"author_id"`
+	CategoryID  pgtype.Int8        `json:"category_id"`
+	ReadingTime int32              `json:"reading_time"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	ID          int64              `json:"id"`
 }
 
+// Without published_at the schedule is left as is. A future date schedules
+// the post; a past one publishes a scheduled post now.
 func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) error {
 	_, err := q.db.Exec(ctx, updatePost,
-		arg.ID,
 		arg.Title,
 		arg.Slug,
 		arg.File,
+		arg.Body,
+		arg.BodyFormat,
+		arg.Excerpt,
+		arg.AuthorID,
+		arg.CategoryID,
+		arg.ReadingTime,
+		arg.PublishedAt,
+		arg.ID,
 	)
 	return err
 }
@@ -123,6 +123,68 @@ func (q *Queries) GetCategory(ctx context.Context, id int64) (GetCategoryRow, er
 	return i, err
 }
 
+const getCategoryPath = `-- name: GetCategoryPath :many
+WITH RECURSIVE
+  path AS (
+    SELECT
+      id,
+      name,
+      slug,
+      parent_id,
+      0 AS depth
+    FROM
+      categories
+    WHERE
+      categories.id = $1
+    UNION ALL
+    SELECT
+      c.id,
+      c.name,
+      c.slug,
+      c.parent_id,
+      path.depth + 1
+    FROM
+      categories c
+      JOIN path ON c.id = path.parent_id
+    WHERE
+      path.depth < 10
+  )
+SELECT
+  id,
+  name,
+  slug
+FROM
+  path
+ORDER BY
+  depth DESC
+`
+
+type GetCategoryPathRow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func (q *Queries) GetCategoryPath(ctx context.Context, id int64) ([]GetCategoryPathRow, error) {
+	rows, err := q.db.Query(ctx, getCategoryPath, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCategoryPathRow
+	for rows.Next() {
+		var i GetCategoryPathRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Slug); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCategory = `-- name: UpdateCategory :exec
 UPDATE categories
 SET
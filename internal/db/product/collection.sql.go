@@ -117,6 +117,57 @@ func (q *Queries) GetCollection(ctx context.Context, id int64) (GetCollectionRow
 	return i, err
 }
 
+const getCollectionSeoBySlug = `-- name: GetCollectionSeoBySlug :one
+SELECT
+  id,
+  name,
+  slug,
+  file,
+  meta_title,
+  meta_description,
+  canonical_url,
+  og_title,
+  og_description,
+  og_image
+FROM
+  collections
+WHERE
+  slug = $1
+LIMIT
+  1
+`
+
+type GetCollectionSeoBySlugRow struct {
+	ID              int64       `json:"id"`
+	Name            string      `json:"name"`
+	Slug            string      `json:"slug"`
+	File            pgtype.Text `json:"file"`
+	MetaTitle       pgtype.Text `json:"meta_title"`
+	MetaDescription pgtype.Text `json:"meta_description"`
+	CanonicalUrl    pgtype.Text `json:"canonical_url"`
+	OgTitle         pgtype.Text `json:"og_title"`
+	OgDescription   pgtype.Text `json:"og_description"`
+	OgImage         pgtype.Text `json:"og_image"`
+}
+
+func (q *Queries) GetCollectionSeoBySlug(ctx context.Context, slug string) (GetCollectionSeoBySlugRow, error) {
+	row := q.db.QueryRow(ctx, getCollectionSeoBySlug, slug)
+	var i GetCollectionSeoBySlugRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.File,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+	)
+	return i, err
+}
+
 const getCollections = `-- name: GetCollections :many
 SELECT
   id,
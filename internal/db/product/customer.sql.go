@@ -38,27 +38,21 @@ func (q *Queries) CountCustomers(ctx context.Context) (int64, error) {
 
 const createCustomer = `-- name: CreateCustomer :one
 INSERT INTO
-  customers (name, phone, password, zns_otp)
+  customers (name, phone, password)
 VALUES
-  ($1, $2, $3, $4)
+  ($1, $2, $3)
 RETURNING
   id
 `
 
 type CreateCustomerParams struct {
-	Name     string      `json:"name"`
-	Phone    string      `json:"phone"`
-	Password string      `json:"password"`
-	ZnsOtp   pgtype.Text `json:"zns_otp"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	Password string `json:"password"`
 }
 
 func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams) (int64, error) {
-	row := q.db.QueryRow(ctx, createCustomer,
-		arg.Name,
-		arg.Phone,
-		arg.Password,
-		arg.ZnsOtp,
-	)
+	row := q.db.QueryRow(ctx, createCustomer, arg.Name, arg.Phone, arg.Password)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -90,6 +84,33 @@ func (q *Queries) GetCustomer(ctx context.Context, id int64) (GetCustomerRow, er
 	return i, err
 }
 
+const getCustomerByIdentify = `-- name: GetCustomerByIdentify :one
+SELECT id, name, phone, email
+FROM customers
+WHERE phone = $1 OR email = $1
+ORDER BY id
+LIMIT 1
+`
+
+type GetCustomerByIdentifyRow struct {
+	ID    int64       `json:"id"`
+	Name  string      `json:"name"`
+	Phone string      `json:"phone"`
+	Email pgtype.Text `json:"email"`
+}
+
+func (q *Queries) GetCustomerByIdentify(ctx context.Context, phone string) (GetCustomerByIdentifyRow, error) {
+	row := q.db.QueryRow(ctx, getCustomerByIdentify, phone)
+	var i GetCustomerByIdentifyRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Phone,
+		&i.Email,
+	)
+	return i, err
+}
+
 const getCustomerByPhone = `-- name: GetCustomerByPhone :one
 SELECT id, name, phone, password, phone_verified
 FROM customers
@@ -194,19 +215,29 @@ func (q *Queries) UpdateCustomer(ctx context.Context, arg UpdateCustomerParams)
 	return id, err
 }
 
-const verifyPhone = `-- name: VerifyPhone :exec
+const updateCustomerPassword = `-- name: UpdateCustomerPassword :exec
 UPDATE customers
-SET phone_verified = true,
-    zns_otp = NULL
-WHERE phone = $1 AND zns_otp = $2
+SET password = $2
+WHERE id = $1
 `
 
-type VerifyPhoneParams struct {
-	Phone  string      `json:"phone"`
-	ZnsOtp pgtype.Text `json:"zns_otp"`
+type UpdateCustomerPasswordParams struct {
+	ID       int64  `json:"id"`
+	Password string `json:"password"`
 }
 
-func (q *Queries) VerifyPhone(ctx context.Context, arg VerifyPhoneParams) error {
-	_, err := q.db.Exec(ctx, verifyPhone, arg.Phone, arg.ZnsOtp)
+func (q *Queries) UpdateCustomerPassword(ctx context.Context, arg UpdateCustomerPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateCustomerPassword, arg.ID, arg.Password)
+	return err
+}
+
+const verifyPhone = `-- name: VerifyPhone :exec
+UPDATE customers
+SET phone_verified = true
+WHERE id = $1
+`
+
+func (q *Queries) VerifyPhone(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, verifyPhone, id)
 	return err
 }
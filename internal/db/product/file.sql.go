@@ -7,6 +7,8 @@ package product_db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const bulkDeleteFiles = `-- name: BulkDeleteFiles :exec
@@ -32,46 +34,296 @@ func (q *Queries) BulkInsertFiles(ctx context.Context, names []string) error {
 	return err
 }
 
+const clearFilesUnreferenced = `-- name: ClearFilesUnreferenced :exec
+UPDATE files
+SET
+  unreferenced_since = NULL
+WHERE
+  id = ANY ($1::bigint[])
+`
+
+func (q *Queries) ClearFilesUnreferenced(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, clearFilesUnreferenced, ids)
+	return err
+}
+
 const countFiles = `-- name: CountFiles :one
 SELECT
   COUNT(*)
 FROM
   files
+WHERE
+  (
+    $1::text IS NULL
+    OR name ILIKE '%' || $1 || '%'
+    OR original_name ILIKE '%' || $1 || '%'
+    OR title ILIKE '%' || $1 || '%'
+    OR alt_text ILIKE '%' || $1 || '%'
+  )
+  AND (
+    $2::text IS NULL
+    OR folder = $2
+    OR folder LIKE $2 || '/%'
+  )
+  AND (
+    $3::text IS NULL
+    OR $3 = ANY (tags)
+  )
+  AND (
+    $4::text IS NULL
+    OR content_type LIKE $4 || '%'
+  )
 `
 
-func (q *Queries) CountFiles(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countFiles)
+type CountFilesParams struct {
+	Search      pgtype.Text `json:"search"`
+	Folder      pgtype.Text `json:"folder"`
+	Tag         pgtype.Text `json:"tag"`
+	ContentType pgtype.Text `json:"content_type"`
+}
+
+func (q *Queries) CountFiles(ctx context.Context, arg CountFilesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countFiles,
+		arg.Search,
+		arg.Folder,
+		arg.Tag,
+		arg.ContentType,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
+const createUploadedFile = `-- name: CreateUploadedFile :one
+INSERT INTO
+  files (
+    name,
+    hash,
+    size,
+    content_type,
+    original_name,
+    width,
+    height,
+    blurhash
+  )
+VALUES
+  ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (hash) DO NOTHING
+RETURNING
+  id,
+  name
+`
+
+type CreateUploadedFileParams struct {
+	Name         string      `json:"name"`
+	Hash         pgtype.Text `json:"hash"`
+	Size         pgtype.Int8 `json:"size"`
+	ContentType  pgtype.Text `json:"content_type"`
+	OriginalName pgtype.Text `json:"original_name"`
+	Width        pgtype.Int4 `json:"width"`
+	Height       pgtype.Int4 `json:"height"`
+	Blurhash     pgtype.Text `json:"blurhash"`
+}
+
+type CreateUploadedFileRow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) CreateUploadedFile(ctx context.Context, arg CreateUploadedFileParams) (CreateUploadedFileRow, error) {
+	row := q.db.QueryRow(ctx, createUploadedFile,
+		arg.Name,
+		arg.Hash,
+		arg.Size,
+		arg.ContentType,
+		arg.OriginalName,
+		arg.Width,
+		arg.Height,
+		arg.Blurhash,
+	)
+	var i CreateUploadedFileRow
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const getFile = `-- name: GetFile :one
+SELECT
+  id,
+  name,
+  content_type,
+  size,
+  original_name,
+  width,
+  height,
+  blurhash,
+  alt_text,
+  title,
+  folder,
+  tags,
+  created_at
+FROM
+  files
+WHERE
+  id = $1
+`
+
+type GetFileRow struct {
+	ID           int64              `json:"id"`
+	Name         string             `json:"name"`
+	ContentType  pgtype.Text        `json:"content_type"`
+	Size         pgtype.Int8        `json:"size"`
+	OriginalName pgtype.Text        `json:"original_name"`
+	Width        pgtype.Int4        `json:"width"`
+	Height       pgtype.Int4        `json:"height"`
+	Blurhash     pgtype.Text        `json:"blurhash"`
+	AltText      string             `json:"alt_text"`
+	Title        string             `json:"title"`
+	Folder       string             `json:"folder"`
+	Tags         []string           `json:"tags"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetFile(ctx context.Context, id int64) (GetFileRow, error) {
+	row := q.db.QueryRow(ctx, getFile, id)
+	var i GetFileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContentType,
+		&i.Size,
+		&i.OriginalName,
+		&i.Width,
+		&i.Height,
+		&i.Blurhash,
+		&i.AltText,
+		&i.Title,
+		&i.Folder,
+		&i.Tags,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFileByHash = `-- name: GetFileByHash :one
+SELECT
+  id,
+  name,
+  content_type,
+  size,
+  width,
+  height,
+  blurhash
+FROM
+  files
+WHERE
+  hash = $1
+LIMIT
+  1
+`
+
+type GetFileByHashRow struct {
+	ID          int64       `json:"id"`
+	Name        string      `json:"name"`
+	ContentType pgtype.Text `json:"content_type"`
+	Size        pgtype.Int8 `json:"size"`
+	Width       pgtype.Int4 `json:"width"`
+	Height      pgtype.Int4 `json:"height"`
+	Blurhash    pgtype.Text `json:"blurhash"`
+}
+
+func (q *Queries) GetFileByHash(ctx context.Context, hash pgtype.Text) (GetFileByHashRow, error) {
+	row := q.db.QueryRow(ctx, getFileByHash, hash)
+	var i GetFileByHashRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContentType,
+		&i.Size,
+		&i.Width,
+		&i.Height,
+		&i.Blurhash,
+	)
+	return i, err
+}
+
 const getFiles = `-- name: GetFiles :many
 SELECT
   id,
-  name
+  name,
+  content_type,
+  size,
+  original_name,
+  width,
+  height,
+  alt_text,
+  title,
+  folder,
+  tags,
+  created_at
 FROM
   files
+WHERE
+  (
+    $1::text IS NULL
+    OR name ILIKE '%' || $1 || '%'
+    OR original_name ILIKE '%' || $1 || '%'
+    OR title ILIKE '%' || $1 || '%'
+    OR alt_text ILIKE '%' || $1 || '%'
+  )
+  AND (
+    $2::text IS NULL
+    OR folder = $2
+    OR folder LIKE $2 || '/%'
+  )
+  AND (
+    $3::text IS NULL
+    OR $3 = ANY (tags)
+  )
+  AND (
+    $4::text IS NULL
+    OR content_type LIKE $4 || '%'
+  )
 ORDER BY
   created_at DESC
 LIMIT
-  $1
+  $5
 OFFSET
-  $2
+  $6
 `
 
 type GetFilesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Search      pgtype.Text `json:"search"`
+	Folder      pgtype.Text `json:"folder"`
+	Tag         pgtype.Text `json:"tag"`
+	ContentType pgtype.Text `json:"content_type"`
+	LimitCount  int32       `json:"limit_count"`
+	OffsetCount int32       `json:"offset_count"`
 }
 
 type GetFilesRow struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID           int64              `json:"id"`
+	Name         string             `json:"name"`
+	ContentType  pgtype.Text        `json:"content_type"`
+	Size         pgtype.Int8        `json:"size"`
+	OriginalName pgtype.Text        `json:"original_name"`
+	Width        pgtype.Int4        `json:"width"`
+	Height       pgtype.Int4        `json:"height"`
+	AltText      string             `json:"alt_text"`
+	Title        string             `json:"title"`
+	Folder       string             `json:"folder"`
+	Tags         []string           `json:"tags"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
 
 func (q *Queries) GetFiles(ctx context.Context, arg GetFilesParams) ([]GetFilesRow, error) {
-	rows, err := q.db.Query(ctx, getFiles, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getFiles,
+		arg.Search,
+		arg.Folder,
+		arg.Tag,
+		arg.ContentType,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +331,20 @@ func (q *Queries) GetFiles(ctx context.Context, arg GetFilesParams) ([]GetFilesR
 	var items []GetFilesRow
 	for rows.Next() {
 		var i GetFilesRow
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ContentType,
+			&i.Size,
+			&i.OriginalName,
+			&i.Width,
+			&i.Height,
+			&i.AltText,
+			&i.Title,
+			&i.Folder,
+			&i.Tags,
+			&i.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -89,3 +354,184 @@ func (q *Queries) GetFiles(ctx context.Context, arg GetFilesParams) ([]GetFilesR
 	}
 	return items, nil
 }
+
+const getFilesByIDs = `-- name: GetFilesByIDs :many
+SELECT
+  id,
+  name,
+  hash
+FROM
+  files
+WHERE
+  id = ANY ($1::bigint[])
+`
+
+type GetFilesByIDsRow struct {
+	ID   int64       `json:"id"`
+	Name string      `json:"name"`
+	Hash pgtype.Text `json:"hash"`
+}
+
+func (q *Queries) GetFilesByIDs(ctx context.Context, ids []int64) ([]GetFilesByIDsRow, error) {
+	rows, err := q.db.Query(ctx, getFilesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFilesByIDsRow
+	for rows.Next() {
+		var i GetFilesByIDsRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Hash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFilesForCleanup = `-- name: GetFilesForCleanup :many
+SELECT
+  id,
+  name,
+  unreferenced_since
+FROM
+  files
+ORDER BY
+  id
+`
+
+type GetFilesForCleanupRow struct {
+	ID                int64              `json:"id"`
+	Name              string             `json:"name"`
+	UnreferencedSince pgtype.Timestamptz `json:"unreferenced_since"`
+}
+
+func (q *Queries) GetFilesForCleanup(ctx context.Context) ([]GetFilesForCleanupRow, error) {
+	rows, err := q.db.Query(ctx, getFilesForCleanup)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFilesForCleanupRow
+	for rows.Next() {
+		var i GetFilesForCleanupRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.UnreferencedSince); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFolders = `-- name: GetFolders :many
+SELECT
+  folder,
+  COUNT(*) AS file_count
+FROM
+  files
+WHERE
+  folder <> ''
+GROUP BY
+  folder
+ORDER BY
+  folder
+`
+
+type GetFoldersRow struct {
+	Folder    string `json:"folder"`
+	FileCount int64  `json:"file_count"`
+}
+
+func (q *Queries) GetFolders(ctx context.Context) ([]GetFoldersRow, error) {
+	rows, err := q.db.Query(ctx, getFolders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFoldersRow
+	for rows.Next() {
+		var i GetFoldersRow
+		if err := rows.Scan(&i.Folder, &i.FileCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markFilesUnreferenced = `-- name: MarkFilesUnreferenced :exec
+UPDATE files
+SET
+  unreferenced_since = NOW()
+WHERE
+  id = ANY ($1::bigint[])
+  AND unreferenced_since IS NULL
+`
+
+func (q *Queries) MarkFilesUnreferenced(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, markFilesUnreferenced, ids)
+	return err
+}
+
+const moveFiles = `-- name: MoveFiles :execrows
+UPDATE files
+SET
+  folder = $1
+WHERE
+  id = ANY ($2::bigint[])
+`
+
+type MoveFilesParams struct {
+	Folder string  `json:"folder"`
+	Ids    []int64 `json:"ids"`
+}
+
+func (q *Queries) MoveFiles(ctx context.Context, arg MoveFilesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveFiles, arg.Folder, arg.Ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateFile = `-- name: UpdateFile :execrows
+UPDATE files
+SET
+  alt_text = $2,
+  title = $3,
+  folder = $4,
+  tags = $5
+WHERE
+  id = $1
+`
+
+type UpdateFileParams struct {
+	ID      int64    `json:"id"`
+	AltText string   `json:"alt_text"`
+	Title   string   `json:"title"`
+	Folder  string   `json:"folder"`
+	Tags    []string `json:"tags"`
+}
+
+func (q *Queries) UpdateFile(ctx context.Context, arg UpdateFileParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateFile,
+		arg.ID,
+		arg.AltText,
+		arg.Title,
+		arg.Folder,
+		arg.Tags,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
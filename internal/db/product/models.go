@@ -21,6 +21,18 @@ type Address struct {
 	Email       pgtype.Text `json:"email"`
 }
 
+type Attribute struct {
+	ID           int64              `json:"id"`
+	Code         string             `json:"code"`
+	Name         string             `json:"name"`
+	Type         string             `json:"type"`
+	Unit         string             `json:"unit"`
+	Options      []string           `json:"options"`
+	IsFilterable bool               `json:"is_filterable"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Category struct {
 	ID        int64              `json:"id"`
 	Name      string             `json:"name"`
@@ -30,6 +42,13 @@ type Category struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type CategoryAttribute struct {
+	CategoryID  int64 `json:"category_id"`
+	AttributeID int64 `json:"attribute_id"`
+	IsRequired  bool  `json:"is_required"`
+	No          int32 `json:"no"`
+}
+
 type Collection struct {
 	ID              int64              `json:"id"`
 	Name            string             `json:"name"`
@@ -53,13 +72,44 @@ type Customer struct {
 	Name          string             `json:"name"`
 	Phone         string             `json:"phone"`
 	PhoneVerified pgtype.Bool        `json:"phone_verified"`
-	ZnsOtp        pgtype.Text        `json:"zns_otp"`
 	Avatar        pgtype.Text        `json:"avatar"`
 	Email         pgtype.Text        `json:"email"`
 	Password      string             `json:"password"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 }
 
+type CustomerOtp struct {
+	ID         int64              `json:"id"`
+	CustomerID int64              `json:"customer_id"`
+	Purpose    string             `json:"purpose"`
+	CodeHash   string             `json:"code_hash"`
+	Attempts   int32              `json:"attempts"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	UsedAt     pgtype.Timestamptz `json:"used_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type CustomerRefreshToken struct {
+	ID         int64              `json:"id"`
+	CustomerID int64              `json:"customer_id"`
+	SessionID  int64              `json:"session_id"`
+	TokenHash  string             `json:"token_hash"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type CustomerSession struct {
+	ID         int64              `json:"id"`
+	CustomerID int64              `json:"customer_id"`
+	UserAgent  string             `json:"user_agent"`
+	Ip         string             `json:"ip"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type Discount struct {
 	ID               int64              `json:"id"`
 	Title            string             `json:"title"`
@@ -107,9 +157,32 @@ type DiscountTarget struct {
 }
 
 type File struct {
-	ID        int64              `json:"id"`
-	Name      string             `json:"name"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ID                int64              `json:"id"`
+	Name              string             `json:"name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	Hash              pgtype.Text        `json:"hash"`
+	Size              pgtype.Int8        `json:"size"`
+	ContentType       pgtype.Text        `json:"content_type"`
+	OriginalName      pgtype.Text        `json:"original_name"`
+	Width             pgtype.Int4        `json:"width"`
+	Height            pgtype.Int4        `json:"height"`
+	Blurhash          pgtype.Text        `json:"blurhash"`
+	AltText           string             `json:"alt_text"`
+	Title             string             `json:"title"`
+	Folder            string             `json:"folder"`
+	Tags              []string           `json:"tags"`
+	UnreferencedSince pgtype.Timestamptz `json:"unreferenced_since"`
+}
+
+type FileRendition struct {
+	ID       int64  `json:"id"`
+	FileID   int64  `json:"file_id"`
+	Name     string `json:"name"`
+	SizeName string `json:"size_name"`
+	Format   string `json:"format"`
+	Width    int32  `json:"width"`
+	Height   int32  `json:"height"`
+	Size     int64  `json:"size"`
 }
 
 type Hotspot struct {
@@ -124,6 +197,19 @@ type Menu struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type MenuItem struct {
+	ID        int64              `json:"id"`
+	MenuID    int64              `json:"menu_id"`
+	ParentID  pgtype.Int8        `json:"parent_id"`
+	Label     string             `json:"label"`
+	LinkType  string             `json:"link_type"`
+	Url       string             `json:"url"`
+	TargetID  pgtype.Int8        `json:"target_id"`
+	No        int32              `json:"no"`
+	IsVisible bool               `json:"is_visible"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Option struct {
 	ID        int64  `json:"id"`
 	Name      string `json:"name"`
@@ -160,38 +246,63 @@ type OrderItem struct {
 	VariantID pgtype.Int8 `json:"variant_id"`
 }
 
-type Page struct {
+type OtpDelivery struct {
 	ID        int64              `json:"id"`
-	Name      string             `json:"name"`
-	Slug      string             `json:"slug"`
+	OtpID     int64              `json:"otp_id"`
+	Channel   string             `json:"channel"`
+	Provider  string             `json:"provider"`
+	Recipient string             `json:"recipient"`
+	Status    string             `json:"status"`
+	Error     pgtype.Text        `json:"error"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
-type Product struct {
+type Page struct {
 	ID              int64              `json:"id"`
 	Name            string             `json:"name"`
 	Slug            string             `json:"slug"`
-	OriginPrice     int32              `json:"origin_price"`
-	SalePrice       int32              `json:"sale_price"`
-	Stock           pgtype.Int4        `json:"stock"`
-	Sku             pgtype.Text        `json:"sku"`
-	Weight          pgtype.Int4        `json:"weight"`
-	Long            pgtype.Int4        `json:"long"`
-	Wide            pgtype.Int4        `json:"wide"`
-	High            pgtype.Int4        `json:"high"`
-	MetaTitle       string             `json:"meta_title"`
-	MetaDescription string             `json:"meta_description"`
-	MetaKeywords    string             `json:"meta_keywords"`
-	CanonicalUrl    string             `json:"canonical_url"`
-	OgTitle         string             `json:"og_title"`
-	OgDescription   string             `json:"og_description"`
-	OgImage         string             `json:"og_image"`
-	IsActive        bool               `json:"is_active"`
-	CategoryID      pgtype.Int8        `json:"category_id"`
+	DraftBlocks     []byte             `json:"draft_blocks"`
+	PublishedBlocks []byte             `json:"published_blocks"`
+	PublishedAt     pgtype.Timestamptz `json:"published_at"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 }
 
+type Product struct {
+	ID               int64              `json:"id"`
+	Name             string             `json:"name"`
+	Slug             string             `json:"slug"`
+	OriginPrice      int32              `json:"origin_price"`
+	SalePrice        int32              `json:"sale_price"`
+	Stock            pgtype.Int4        `json:"stock"`
+	Sku              pgtype.Text        `json:"sku"`
+	Weight           pgtype.Int4        `json:"weight"`
+	Long             pgtype.Int4        `json:"long"`
+	Wide             pgtype.Int4        `json:"wide"`
+	High             pgtype.Int4        `json:"high"`
+	MetaTitle        string             `json:"meta_title"`
+	MetaDescription  string             `json:"meta_description"`
+	MetaKeywords     string             `json:"meta_keywords"`
+	CanonicalUrl     string             `json:"canonical_url"`
+	OgTitle          string             `json:"og_title"`
+	OgDescription    string             `json:"og_description"`
+	OgImage          string             `json:"og_image"`
+	IsActive         bool               `json:"is_active"`
+	CategoryID       pgtype.Int8        `json:"category_id"`
+	Brand            string             `json:"brand"`
+	Gtin             string             `json:"gtin"`
+	ExcludeFromFeeds bool               `json:"exclude_from_feeds"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductAttributeValue struct {
+	ProductID   int64         `json:"product_id"`
+	AttributeID int64         `json:"attribute_id"`
+	Value       string        `json:"value"`
+	ValueNumber pgtype.Float8 `json:"value_number"`
+}
+
 type ProductCollection struct {
 	ProductID    int64 `json:"product_id"`
 	CollectionID int64 `json:"collection_id"`
@@ -212,11 +323,41 @@ type ProductHotspot struct {
 	Y         float32 `json:"y"`
 }
 
+type ProductQuestion struct {
+	ID         int64              `json:"id"`
+	ProductID  int64              `json:"product_id"`
+	CustomerID int64              `json:"customer_id"`
+	Question   string             `json:"question"`
+	Answer     pgtype.Text        `json:"answer"`
+	Status     string             `json:"status"`
+	AnsweredAt pgtype.Timestamptz `json:"answered_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
 type ProductTag struct {
 	Name      string `json:"name"`
 	ProductID int64  `json:"product_id"`
 }
 
+type ProductView struct {
+	ID         int64              `json:"id"`
+	ProductID  int64              `json:"product_id"`
+	CustomerID pgtype.Int8        `json:"customer_id"`
+	SessionID  pgtype.Text        `json:"session_id"`
+	ViewedAt   pgtype.Timestamptz `json:"viewed_at"`
+}
+
+type Redirect struct {
+	ID         int64              `json:"id"`
+	EntityType string             `json:"entity_type"`
+	FromSlug   string             `json:"from_slug"`
+	ToSlug     string             `json:"to_slug"`
+	StatusCode int32              `json:"status_code"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Review struct {
 	ID         int64              `json:"id"`
 	ProductID  pgtype.Int8        `json:"product_id"`
@@ -264,3 +405,23 @@ type VariantOption struct {
 	OptionValueID int64 `json:"option_value_id"`
 	OptionID      int64 `json:"option_id"`
 }
+
+type WishlistItem struct {
+	ID              int64              `json:"id"`
+	CustomerID      int64              `json:"customer_id"`
+	ProductID       int64              `json:"product_id"`
+	VariantID       pgtype.Int8        `json:"variant_id"`
+	NotifyPriceDrop bool               `json:"notify_price_drop"`
+	LastPrice       int32              `json:"last_price"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type WishlistPriceDrop struct {
+	ID         int64              `json:"id"`
+	CustomerID int64              `json:"customer_id"`
+	ProductID  int64              `json:"product_id"`
+	VariantID  pgtype.Int8        `json:"variant_id"`
+	OldPrice   int32              `json:"old_price"`
+	NewPrice   int32              `json:"new_price"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
@@ -7,6 +7,8 @@ package product_db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const bulkDeletePages = `-- name: BulkDeletePages :exec
@@ -36,20 +38,21 @@ func (q *Queries) CountPages(ctx context.Context) (int64, error) {
 
 const createPage = `-- name: CreatePage :one
 INSERT INTO
-  pages (name, slug)
+  pages (name, slug, draft_blocks)
 VALUES
-  ($1, $2)
+  ($1, $2, $3)
 RETURNING
   id
 `
 
 type CreatePageParams struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	DraftBlocks []byte `json:"draft_blocks"`
 }
 
 func (q *Queries) CreatePage(ctx context.Context, arg CreatePageParams) (int64, error) {
-	row := q.db.QueryRow(ctx, createPage, arg.Name, arg.Slug)
+	row := q.db.QueryRow(ctx, createPage, arg.Name, arg.Slug, arg.DraftBlocks)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -59,7 +62,11 @@ const getPage = `-- name: GetPage :one
 SELECT
   id,
   name,
-  slug
+  slug,
+  draft_blocks,
+  published_blocks,
+  published_at,
+  updated_at
 FROM
   pages
 WHERE
@@ -67,15 +74,27 @@ WHERE
 `
 
 type GetPageRow struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	ID              int64              `json:"id"`
+	Name            string             `json:"name"`
+	Slug            string             `json:"slug"`
+	DraftBlocks     []byte             `json:"draft_blocks"`
+	PublishedBlocks []byte             `json:"published_blocks"`
+	PublishedAt     pgtype.Timestamptz `json:"published_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) GetPage(ctx context.Context, id int64) (GetPageRow, error) {
 	row := q.db.QueryRow(ctx, getPage, id)
 	var i GetPageRow
-	err := row.Scan(&i.ID, &i.Name, &i.Slug)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.DraftBlocks,
+		&i.PublishedBlocks,
+		&i.PublishedAt,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
 
@@ -83,23 +102,34 @@ const getPageBySlug = `-- name: GetPageBySlug :one
 SELECT
   id,
   name,
-  slug
+  slug,
+  published_blocks,
+  published_at
 FROM
   pages
 WHERE
   slug = $1
+  AND published_blocks IS NOT NULL
 `
 
 type GetPageBySlugRow struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	ID              int64              `json:"id"`
+	Name            string             `json:"name"`
+	Slug            string             `json:"slug"`
+	PublishedBlocks []byte             `json:"published_blocks"`
+	PublishedAt     pgtype.Timestamptz `json:"published_at"`
 }
 
 func (q *Queries) GetPageBySlug(ctx context.Context, slug string) (GetPageBySlugRow, error) {
 	row := q.db.QueryRow(ctx, getPageBySlug, slug)
 	var i GetPageBySlugRow
-	err := row.Scan(&i.ID, &i.Name, &i.Slug)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.PublishedBlocks,
+		&i.PublishedAt,
+	)
 	return i, err
 }
 
@@ -107,7 +137,9 @@ const getPages = `-- name: GetPages :many
 SELECT
   id,
   name,
-  slug
+  slug,
+  published_at,
+  updated_at
 FROM
   pages
 LIMIT
@@ -122,9 +154,11 @@ type GetPagesParams struct {
 }
 
 type GetPagesRow struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Slug        string             `json:"slug"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
 func (q *Queries) GetPages(ctx context.Context, arg GetPagesParams) ([]GetPagesRow, error) {
@@ -136,7 +170,13 @@ func (q *Queries) GetPages(ctx context.Context, arg GetPagesParams) ([]GetPagesR
 	var items []GetPagesRow
 	for rows.Next() {
 		var i GetPagesRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.Slug); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.PublishedAt,
+			&i.UpdatedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -147,11 +187,46 @@ func (q *Queries) GetPages(ctx context.Context, arg GetPagesParams) ([]GetPagesR
 	return items, nil
 }
 
+const publishPage = `-- name: PublishPage :execrows
+UPDATE pages
+SET
+  published_blocks = draft_blocks,
+  published_at = NOW()
+WHERE
+  id = $1
+`
+
+func (q *Queries) PublishPage(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, publishPage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const unpublishPage = `-- name: UnpublishPage :execrows
+UPDATE pages
+SET
+  published_blocks = NULL,
+  published_at = NULL
+WHERE
+  id = $1
+`
+
+func (q *Queries) UnpublishPage(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, unpublishPage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updatePage = `-- name: UpdatePage :exec
 UPDATE pages
 SET
   name = $2,
-  slug = $3
+  slug = $3,
+  updated_at = NOW()
 WHERE
   id = $1
 `
@@ -166,3 +241,25 @@ func (q *Queries) UpdatePage(ctx context.Context, arg UpdatePageParams) error {
 	_, err := q.db.Exec(ctx, updatePage, arg.ID, arg.Name, arg.Slug)
 	return err
 }
+
+const updatePageDraft = `-- name: UpdatePageDraft :execrows
+UPDATE pages
+SET
+  draft_blocks = $2,
+  updated_at = NOW()
+WHERE
+  id = $1
+`
+
+type UpdatePageDraftParams struct {
+	ID          int64  `json:"id"`
+	DraftBlocks []byte `json:"draft_blocks"`
+}
+
+func (q *Queries) UpdatePageDraft(ctx context.Context, arg UpdatePageDraftParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePageDraft, arg.ID, arg.DraftBlocks)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -78,6 +78,74 @@ func (q *Queries) DeleteProductsByCollectionID(ctx context.Context, collectionID
 	return err
 }
 
+const getCollectionProductCards = `-- name: GetCollectionProductCards :many
+SELECT
+  p.id,
+  p.name,
+  p.slug,
+  p.origin_price,
+  p.sale_price,
+  (
+    SELECT
+      COALESCE(json_agg(pf.name), '[]'::json)
+    FROM
+      product_files pf
+    WHERE
+      pf.product_id = p.id
+  ) AS files
+FROM
+  product_collections pc
+  JOIN products p ON p.id = pc.product_id
+WHERE
+  pc.collection_id = $1
+  AND p.is_active
+ORDER BY
+  p.id DESC
+LIMIT
+  $2
+`
+
+type GetCollectionProductCardsParams struct {
+	CollectionID int64 `json:"collection_id"`
+	LimitCount   int32 `json:"limit_count"`
+}
+
+type GetCollectionProductCardsRow struct {
+	ID          int64       `json:"id"`
+	Name        string      `json:"name"`
+	Slug        string      `json:"slug"`
+	OriginPrice int32       `json:"origin_price"`
+	SalePrice   int32       `json:"sale_price"`
+	Files       interface{} `json:"files"`
+}
+
+func (q *Queries) GetCollectionProductCards(ctx context.Context, arg GetCollectionProductCardsParams) ([]GetCollectionProductCardsRow, error) {
+	rows, err := q.db.Query(ctx, getCollectionProductCards, arg.CollectionID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionProductCardsRow
+	for rows.Next() {
+		var i GetCollectionProductCardsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.OriginPrice,
+			&i.SalePrice,
+			&i.Files,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCollectionsByProductID = `-- name: GetCollectionsByProductID :many
 SELECT
   c.id,
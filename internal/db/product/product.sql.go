@@ -26,11 +26,46 @@ const countProducts = `-- name: CountProducts :one
 SELECT
   COUNT(*)
 FROM
-  products
+  products p
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset($1::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  )
 `
 
-func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countProducts)
+func (q *Queries) CountProducts(ctx context.Context, attributeFilters []byte) (int64, error) {
+	row := q.db.QueryRow(ctx, countProducts, attributeFilters)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -40,13 +75,52 @@ const countProductsByCategory = `-- name: CountProductsByCategory :one
 SELECT
   COUNT(*)
 FROM
-  products
+  products p
 WHERE
-  category_id = $1
+  p.category_id = $1
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset($2::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  )
 `
 
-func (q *Queries) CountProductsByCategory(ctx context.Context, categoryID pgtype.Int8) (int64, error) {
-	row := q.db.QueryRow(ctx, countProductsByCategory, categoryID)
+type CountProductsByCategoryParams struct {
+	CategoryID       pgtype.Int8 `json:"category_id"`
+	AttributeFilters []byte      `json:"attribute_filters"`
+}
+
+func (q *Queries) CountProductsByCategory(ctx context.Context, arg CountProductsByCategoryParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductsByCategory, arg.CategoryID, arg.AttributeFilters)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -80,28 +154,51 @@ INSERT INTO
     weight,
     long,
     wide,
-    high
+    high,
+    brand,
+    gtin,
+    exclude_from_feeds
   )
 VALUES
-  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+  (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15,
+    $16
+  )
 RETURNING
   id
 `
 
 type CreateProductParams struct {
-	Name            string      `json:"name"`
-	Slug            string      `json:"slug"`
-	OriginPrice     int32       `json:"origin_price"`
-	SalePrice       int32       `json:"sale_price"`
-	Stock           pgtype.Int4 `json:"stock"`
-	Sku             pgtype.Text `json:"sku"`
-	MetaTitle       string      `json:"meta_title"`
-	MetaDescription string      `json:"meta_description"`
-	CategoryID      pgtype.Int8 `json:"category_id"`
-	Weight          pgtype.Int4 `json:"weight"`
-	Long            pgtype.Int4 `json:"long"`
-	Wide            pgtype.Int4 `json:"wide"`
-	High            pgtype.Int4 `json:"high"`
+	Name             string      `json:"name"`
+	Slug             string      `json:"slug"`
+	OriginPrice      int32       `json:"origin_price"`
+	SalePrice        int32       `json:"sale_price"`
+	Stock            pgtype.Int4 `json:"stock"`
+	Sku              pgtype.Text `json:"sku"`
+	MetaTitle        string      `json:"meta_title"`
+	MetaDescription  string      `json:"meta_description"`
+	CategoryID       pgtype.Int8 `json:"category_id"`
+	Weight           pgtype.Int4 `json:"weight"`
+	Long             pgtype.Int4 `json:"long"`
+	Wide             pgtype.Int4 `json:"wide"`
+	High             pgtype.Int4 `json:"high"`
+	Brand            string      `json:"brand"`
+	Gtin             string      `json:"gtin"`
+	ExcludeFromFeeds bool        `json:"exclude_from_feeds"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (int64, error) {
@@ -119,6 +216,9 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (i
 		arg.Long,
 		arg.Wide,
 		arg.High,
+		arg.Brand,
+		arg.Gtin,
+		arg.ExcludeFromFeeds,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -141,7 +241,10 @@ SELECT
   weight,
   long,
   wide,
-  high
+  high,
+  brand,
+  gtin,
+  exclude_from_feeds
 FROM
   products
 WHERE
@@ -151,21 +254,24 @@ LIMIT
 `
 
 type GetProductRow struct {
-	ID              int64       `json:"id"`
-	Name            string      `json:"name"`
-	Slug            string      `json:"slug"`
-	OriginPrice     int32       `json:"origin_price"`
-	SalePrice       int32       `json:"sale_price"`
-	Stock           pgtype.Int4 `json:"stock"`
-	Sku             pgtype.Text `json:"sku"`
-	MetaTitle       string      `json:"meta_title"`
-	MetaDescription string      `json:"meta_description"`
-	CategoryID      pgtype.Int8 `json:"category_id"`
-	IsActive        bool        `json:"is_active"`
-	Weight          pgtype.Int4 `json:"weight"`
-	Long            pgtype.Int4 `json:"long"`
-	Wide            pgtype.Int4 `json:"wide"`
-	High            pgtype.Int4 `json:"high"`
+	ID               int64       `json:"id"`
+	Name             string      `json:"name"`
+	Slug             string      `json:"slug"`
+	OriginPrice      int32       `json:"origin_price"`
+	SalePrice        int32       `json:"sale_price"`
+	Stock            pgtype.Int4 `json:"stock"`
+	Sku              pgtype.Text `json:"sku"`
+	MetaTitle        string      `json:"meta_title"`
+	MetaDescription  string      `json:"meta_description"`
+	CategoryID       pgtype.Int8 `json:"category_id"`
+	IsActive         bool        `json:"is_active"`
+	Weight           pgtype.Int4 `json:"weight"`
+	Long             pgtype.Int4 `json:"long"`
+	Wide             pgtype.Int4 `json:"wide"`
+	High             pgtype.Int4 `json:"high"`
+	Brand            string      `json:"brand"`
+	Gtin             string      `json:"gtin"`
+	ExcludeFromFeeds bool        `json:"exclude_from_feeds"`
 }
 
 func (q *Queries) GetProduct(ctx context.Context, id int64) (GetProductRow, error) {
@@ -187,6 +293,9 @@ func (q *Queries) GetProduct(ctx context.Context, id int64) (GetProductRow, erro
 		&i.Long,
 		&i.Wide,
 		&i.High,
+		&i.Brand,
+		&i.Gtin,
+		&i.ExcludeFromFeeds,
 	)
 	return i, err
 }
@@ -284,7 +393,38 @@ SELECT
       variants v
     WHERE
       v.product_id = p.id
-  ) AS variants
+  ) AS variants,
+  (
+    SELECT
+      COALESCE(
+        json_agg(
+          json_build_object(
+            'code',
+            a.code,
+            'name',
+            a.name,
+            'type',
+            a.type,
+            'unit',
+            a.unit,
+            'value',
+            CASE a.type
+              WHEN 'number' THEN to_json(pav.value_number)
+              WHEN 'boolean' THEN to_json(pav.value::boolean)
+              ELSE to_json(pav.value)
+            END
+          )
+          ORDER BY
+            a.id
+        ),
+        '[]'::json
+      )
+    FROM
+      product_attribute_values pav
+      JOIN attributes a ON a.id = pav.attribute_id
+    WHERE
+      pav.product_id = p.id
+  ) AS attributes
 FROM
   products p
 WHERE
@@ -312,6 +452,7 @@ type GetProductBySlugRow struct {
 	Files           interface{} `json:"files"`
 	Options         interface{} `json:"options"`
 	Variants        interface{} `json:"variants"`
+	Attributes      interface{} `json:"attributes"`
 }
 
 func (q *Queries) GetProductBySlug(ctx context.Context, slug string) (GetProductBySlugRow, error) {
@@ -336,6 +477,70 @@ func (q *Queries) GetProductBySlug(ctx context.Context, slug string) (GetProduct
 		&i.Files,
 		&i.Options,
 		&i.Variants,
+		&i.Attributes,
+	)
+	return i, err
+}
+
+const getProductSeoBySlug = `-- name: GetProductSeoBySlug :one
+SELECT
+  id,
+  name,
+  slug,
+  sku,
+  origin_price,
+  sale_price,
+  stock,
+  meta_title,
+  meta_description,
+  canonical_url,
+  og_title,
+  og_description,
+  og_image,
+  category_id
+FROM
+  products
+WHERE
+  slug = $1
+LIMIT
+  1
+`
+
+type GetProductSeoBySlugRow struct {
+	ID              int64       `json:"id"`
+	Name            string      `json:"name"`
+	Slug            string      `json:"slug"`
+	Sku             pgtype.Text `json:"sku"`
+	OriginPrice     int32       `json:"origin_price"`
+	SalePrice       int32       `json:"sale_price"`
+	Stock           pgtype.Int4 `json:"stock"`
+	MetaTitle       string      `json:"meta_title"`
+	MetaDescription string      `json:"meta_description"`
+	CanonicalUrl    string      `json:"canonical_url"`
+	OgTitle         string      `json:"og_title"`
+	OgDescription   string      `json:"og_description"`
+	OgImage         string      `json:"og_image"`
+	CategoryID      pgtype.Int8 `json:"category_id"`
+}
+
+func (q *Queries) GetProductSeoBySlug(ctx context.Context, slug string) (GetProductSeoBySlugRow, error) {
+	row := q.db.QueryRow(ctx, getProductSeoBySlug, slug)
+	var i GetProductSeoBySlugRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Sku,
+		&i.OriginPrice,
+		&i.SalePrice,
+		&i.Stock,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgTitle,
+		&i.OgDescription,
+		&i.OgImage,
+		&i.CategoryID,
 	)
 	return i, err
 }
@@ -359,17 +564,53 @@ FROM
     LIMIT
       1
   ) f ON true
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset($1::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  )
 ORDER BY
   created_at DESC
 LIMIT
-  $1
-OFFSET
   $2
+OFFSET
+  $3
 `
 
 type GetProductsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AttributeFilters []byte `json:"attribute_filters"`
+	Limit            int32  `json:"limit"`
+	Offset           int32  `json:"offset"`
 }
 
 type GetProductsRow struct {
@@ -380,7 +621,7 @@ type GetProductsRow struct {
 }
 
 func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]GetProductsRow, error) {
-	rows, err := q.db.Query(ctx, getProducts, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getProducts, arg.AttributeFilters, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -422,17 +663,52 @@ SELECT
 FROM
   products p
 WHERE
-  category_id = $3
+  p.category_id = $1
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jsonb_to_recordset($2::jsonb) AS af (
+        code TEXT,
+        "values" TEXT[],
+        min FLOAT8,
+        max FLOAT8
+      )
+    WHERE
+      NOT EXISTS (
+        SELECT
+          1
+        FROM
+          product_attribute_values pav
+          JOIN attributes a ON a.id = pav.attribute_id
+        WHERE
+          pav.product_id = p.id
+          AND a.code = af.code
+          AND (
+            af."values" IS NULL
+            OR pav.value = ANY (af."values")
+          )
+          AND (
+            af.min IS NULL
+            OR pav.value_number >= af.min
+          )
+          AND (
+            af.max IS NULL
+            OR pav.value_number <= af.max
+          )
+      )
+  )
 LIMIT
-  $1
+  $3
 OFFSET
-  $2
+  $4
 `
 
 type GetProductsByCategoryParams struct {
-	Limit      int32       `json:"limit"`
-	Offset     int32       `json:"offset"`
-	CategoryID pgtype.Int8 `json:"category_id"`
+	CategoryID       pgtype.Int8 `json:"category_id"`
+	AttributeFilters []byte      `json:"attribute_filters"`
+	Limit            int32       `json:"limit"`
+	Offset           int32       `json:"offset"`
 }
 
 type GetProductsByCategoryRow struct {
@@ -445,7 +721,12 @@ type GetProductsByCategoryRow struct {
 }
 
 func (q *Queries) GetProductsByCategory(ctx context.Context, arg GetProductsByCategoryParams) ([]GetProductsByCategoryRow, error) {
-	rows, err := q.db.Query(ctx, getProductsByCategory, arg.Limit, arg.Offset, arg.CategoryID)
+	rows, err := q.db.Query(ctx, getProductsByCategory,
+		arg.CategoryID,
+		arg.AttributeFilters,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -549,26 +830,32 @@ SET
   weight = $11,
   long = $12,
   wide = $13,
-  high = $14
+  high = $14,
+  brand = $15,
+  gtin = $16,
+  exclude_from_feeds = $17
 WHERE
   id = $1
 `
 
 type UpdateProductParams struct {
-	ID              int64       `json:"id"`
-	Name            string      `json:"name"`
-	Slug            string      `json:"slug"`
-	OriginPrice     int32       `json:"origin_price"`
-	SalePrice       int32       `json:"sale_price"`
-	Stock           pgtype.Int4 `json:"stock"`
-	Sku             pgtype.Text `json:"sku"`
-	MetaTitle       string      `json:"meta_title"`
-	MetaDescription string      `json:"meta_description"`
-	CategoryID      pgtype.Int8 `json:"category_id"`
-	Weight          pgtype.Int4 `json:"weight"`
-	Long            pgtype.Int4 `json:"long"`
-	Wide            pgtype.Int4 `json:"wide"`
-	High            pgtype.Int4 `json:"high"`
+	ID               int64       `json:"id"`
+	Name             string      `json:"name"`
+	Slug             string      `json:"slug"`
+	OriginPrice      int32       `json:"origin_price"`
+	SalePrice        int32       `json:"sale_price"`
+	Stock            pgtype.Int4 `json:"stock"`
+	Sku              pgtype.Text `json:"sku"`
+	MetaTitle        string      `json:"meta_title"`
+	MetaDescription  string      `json:"meta_description"`
+	CategoryID       pgtype.Int8 `json:"category_id"`
+	Weight           pgtype.Int4 `json:"weight"`
+	Long             pgtype.Int4 `json:"long"`
+	Wide             pgtype.Int4 `json:"wide"`
+	High             pgtype.Int4 `json:"high"`
+	Brand            string      `json:"brand"`
+	Gtin             string      `json:"gtin"`
+	ExcludeFromFeeds bool        `json:"exclude_from_feeds"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) error {
@@ -587,6 +874,9 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) er
 		arg.Long,
 		arg.Wide,
 		arg.High,
+		arg.Brand,
+		arg.Gtin,
+		arg.ExcludeFromFeeds,
 	)
 	return err
 }
@@ -0,0 +1,145 @@
+// Package imaging decodes uploaded images, removes their metadata and
+// renders the resized copies served to clients.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels bounds the images Decode accepts, so a small file declaring
+// huge dimensions cannot exhaust memory.
+const MaxPixels = 50_000_000
+
+var ErrTooManyPixels = errors.New("imaging: image has too many pixels")
+
+// Output formats for Encode.
+const (
+	FormatWebP = "webp"
+	FormatJPEG = "jpeg"
+)
+
+// ContentTypes and Extensions map each output format to what it is stored
+// as.
+var (
+	ContentTypes = map[string]string{FormatWebP: "image/webp", FormatJPEG: "image/jpeg"}
+	Extensions   = map[string]string{FormatWebP: ".webp", FormatJPEG: ".jpg"}
+)
+
+// Decodable are the content types Decode reads.
+var Decodable = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// Decode reads a JPEG, PNG, GIF or WebP, turned upright according to its
+// EXIF orientation. For a GIF only the first frame is read.
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if o := jpegOrientation(data); o > 1 {
+		img = orient(img, o)
+	}
+	return img, nil
+}
+
+// Strip returns the file without EXIF and other metadata, such as camera
+// details and GPS positions. A JPEG that its EXIF rotates is re-encoded
+// upright instead, since dropping the tag alone would turn it.
+func Strip(data []byte, contentType string, quality int) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		if jpegOrientation(data) > 1 {
+			img, err := Decode(data)
+			if err != nil {
+				return nil, err
+			}
+			return Encode(img, FormatJPEG, quality)
+		}
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	}
+	return data, nil
+}
+
+// Resize scales img to width pixels wide, keeping its aspect ratio.
+func Resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	height := max(1, (b.Dy()*width+b.Dx()/2)/b.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// Encode writes img in one of the output formats. JPEG has no
+// transparency, so transparent areas come out white.
+func Encode(img image.Image, format string, quality int) ([]byte, error) {
+	switch format {
+	case FormatWebP:
+		return EncodeWebP(img)
+	case FormatJPEG:
+		b := img.Bounds()
+		flat := image.NewRGBA(b)
+		draw.Draw(flat, b, image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, b, img, b.Min, draw.Over)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("imaging: unknown format %q", format)
+}
+
+// orient applies an EXIF orientation (2-8) to img.
+func orient(img image.Image, o int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := x, y
+			switch o {
+			case 2:
+				sx = w - 1 - x
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sy = h - 1 - y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
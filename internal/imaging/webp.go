@@ -0,0 +1,430 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"sort"
+)
+
+// This is a lossless WebP (VP8L) encoder, as described in RFC 9649. It only
+// applies the subtract-green and predictor transforms and entropy-codes
+// every pixel as a literal, without backward references or a color cache.
+// That keeps it small and is enough for the sizes renditions are made at.
+
+const (
+	vp8lSignature = 0x2f
+	vp8lMaxSize   = 1 << 14
+
+	predictorTransform     = 0
+	subtractGreenTransform = 2
+
+	// The predictor transform picks a predictor per block. One predictor
+	// serves the whole image here, so the blocks are as large as allowed.
+	predictorBlockBits = 9
+	gradientPredictor  = 12
+
+	maxCodeLength           = 15
+	maxCodeLengthCodeLength = 7
+
+	// Alphabet sizes of the five prefix codes: green (with the backward
+	// reference lengths), red, blue, alpha and distance.
+	greenAlphabet    = 256 + 24
+	literalAlphabet  = 256
+	distanceAlphabet = 40
+)
+
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+var errWebPTooLarge = errors.New("imaging: image too large for WebP")
+
+// EncodeWebP writes img as a lossless WebP.
+func EncodeWebP(img image.Image) ([]byte, error) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w < 1 || h < 1 || w > vp8lMaxSize || h > vp8lMaxSize {
+		return nil, errWebPTooLarge
+	}
+	nrgba := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(nrgba, nrgba.Bounds(), img, b.Min, draw.Src)
+
+	// Subtract green from red and blue, which leaves most photos with far
+	// fewer distinct red and blue values, then replace every pixel with its
+	// difference from the gradient predictor.
+	pix := nrgba.Pix
+	alpha := false
+	for i := 0; i < len(pix); i += 4 {
+		g := pix[i+1]
+		pix[i] -= g
+		pix[i+2] -= g
+		if pix[i+3] != 0xff {
+			alpha = true
+		}
+	}
+	residuals := predict(pix, w, h)
+
+	var bw bitWriter
+	bw.write(vp8lSignature, 8)
+	bw.write(uint32(w-1), 14)
+	bw.write(uint32(h-1), 14)
+	if alpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // version
+
+	bw.write(1, 1)
+	bw.write(subtractGreenTransform, 2)
+	bw.write(1, 1)
+	bw.write(predictorTransform, 2)
+	bw.write(predictorBlockBits-2, 3)
+	blocks := make([]byte, 4*divRoundUp(w, 1<<predictorBlockBits)*divRoundUp(h, 1<<predictorBlockBits))
+	for i := 0; i < len(blocks); i += 4 {
+		blocks[i+1] = gradientPredictor
+	}
+	writeEntropyImage(&bw, blocks, false)
+	bw.write(0, 1) // no more transforms
+
+	writeEntropyImage(&bw, residuals, true)
+	data := bw.bytes()
+
+	var out bytes.Buffer
+	size := 4 + 8 + len(data) + len(data)&1
+	out.WriteString("RIFF")
+	binary.Write(&out, binary.LittleEndian, uint32(size))
+	out.WriteString("WEBPVP8L")
+	binary.Write(&out, binary.LittleEndian, uint32(len(data)))
+	out.Write(data)
+	if len(data)&1 == 1 {
+		out.WriteByte(0)
+	}
+	return out.Bytes(), nil
+}
+
+// writeEntropyImage writes ARGB pixels, given as R, G, B, A bytes, with one
+// group of prefix codes built from their histograms. Only the main image
+// says whether it uses several groups.
+func writeEntropyImage(w *bitWriter, pix []byte, main bool) {
+	var hist [4][]int
+	hist[0] = make([]int, greenAlphabet)
+	for i := 1; i < 4; i++ {
+		hist[i] = make([]int, literalAlphabet)
+	}
+	for i := 0; i < len(pix); i += 4 {
+		hist[0][pix[i+1]]++
+		hist[1][pix[i]]++
+		hist[2][pix[i+2]]++
+		hist[3][pix[i+3]]++
+	}
+
+	w.write(0, 1) // no color cache
+	if main {
+		w.write(0, 1) // a single prefix code group
+	}
+	var codes [4]prefixCode
+	for i := range codes {
+		codes[i] = writePrefixCode(w, hist[i])
+	}
+	writePrefixCode(w, make([]int, distanceAlphabet))
+
+	for i := 0; i < len(pix); i += 4 {
+		codes[0].write(w, pix[i+1])
+		codes[1].write(w, pix[i])
+		codes[2].write(w, pix[i+2])
+		codes[3].write(w, pix[i+3])
+	}
+}
+
+// predict returns each pixel minus its prediction, channel by channel. The
+// first pixel is predicted as opaque black, the rest of the top row from
+// the left and the left column from above; everything else uses the
+// gradient predictor, left + top - top-left clamped to 0-255.
+func predict(pix []byte, w, h int) []byte {
+	out := make([]byte, len(pix))
+	stride := 4 * w
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*stride + 4*x
+			for c := 0; c < 4; c++ {
+				var p byte
+				switch {
+				case x == 0 && y == 0:
+					if c == 3 {
+						p = 0xff
+					}
+				case y == 0:
+					p = pix[i-4+c]
+				case x == 0:
+					p = pix[i-stride+c]
+				default:
+					v := int(pix[i-4+c]) + int(pix[i-stride+c]) - int(pix[i-stride-4+c])
+					p = byte(min(max(v, 0), 255))
+				}
+				out[i+c] = pix[i+c] - p
+			}
+		}
+	}
+	return out
+}
+
+func divRoundUp(n, d int) int {
+	return (n + d - 1) / d
+}
+
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+// write appends the n low bits of v, least significant first.
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc |= uint64(v) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf
+}
+
+// prefixCode holds the bit-reversed canonical code of each symbol, ready to
+// be written least significant bit first.
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint32
+}
+
+func (c prefixCode) write(w *bitWriter, symbol byte) {
+	if n := c.lengths[symbol]; n > 0 {
+		w.write(c.codes[symbol], uint(n))
+	}
+}
+
+// writePrefixCode writes the code for a histogram and returns it. One or
+// two symbols below 256 use the simple code; anything else gets a normal
+// code with its lengths themselves prefix coded.
+func writePrefixCode(w *bitWriter, hist []int) prefixCode {
+	var used []int
+	for s, n := range hist {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+	code := prefixCode{lengths: make([]uint8, len(hist)), codes: make([]uint32, len(hist))}
+	if len(used) <= 2 && (len(used) == 0 || used[len(used)-1] < 256) {
+		if len(used) == 0 {
+			used = []int{0}
+		}
+		w.write(1, 1) // simple code
+		w.write(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			w.write(0, 1)
+			w.write(uint32(used[0]), 1)
+		} else {
+			w.write(1, 1)
+			w.write(uint32(used[0]), 8)
+		}
+		if len(used) == 2 {
+			w.write(uint32(used[1]), 8)
+			// The decoder gives the listed symbols the codes 0 and 1.
+			code.lengths[used[0]], code.codes[used[0]] = 1, 0
+			code.lengths[used[1]], code.codes[used[1]] = 1, 1
+		}
+		return code
+	}
+
+	lengths := codeLengths(hist, maxCodeLength)
+	code.lengths = lengths
+	code.codes = canonicalCodes(lengths)
+
+	// Code lengths are sent as literals 0-15, with runs of zeros folded
+	// into 17 (3-10 zeros) and 18 (11-138 zeros).
+	type token struct {
+		symbol     int
+		extra      uint32
+		extraWidth uint
+	}
+	var tokens []token
+	tokenHist := make([]int, len(codeLengthCodeOrder))
+	for i := 0; i < len(lengths); {
+		if lengths[i] != 0 {
+			tokens = append(tokens, token{symbol: int(lengths[i])})
+			tokenHist[lengths[i]]++
+			i++
+			continue
+		}
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == 0 {
+			run++
+		}
+		i += run
+		for run > 0 {
+			switch {
+			case run >= 11:
+				n := min(run, 138)
+				tokens = append(tokens, token{symbol: 18, extra: uint32(n - 11), extraWidth: 7})
+				tokenHist[18]++
+				run -= n
+			case run >= 3:
+				tokens = append(tokens, token{symbol: 17, extra: uint32(run - 3), extraWidth: 3})
+				tokenHist[17]++
+				run = 0
+			default:
+				tokens = append(tokens, token{symbol: 0})
+				tokenHist[0]++
+				run--
+			}
+		}
+	}
+
+	clLengths := codeLengths(tokenHist, maxCodeLengthCodeLength)
+	nonzero := 0
+	for _, n := range clLengths {
+		if n > 0 {
+			nonzero++
+		}
+	}
+	if nonzero == 1 {
+		// A lone symbol would be read with zero bits. Pair it with a
+		// second one so every token costs exactly one bit.
+		for s := range clLengths {
+			if clLengths[s] == 0 {
+				clLengths[s] = 1
+				break
+			}
+		}
+		for s := range clLengths {
+			if tokenHist[s] > 0 {
+				clLengths[s] = 1
+			}
+		}
+	}
+	clCodes := canonicalCodes(clLengths)
+
+	count := len(codeLengthCodeOrder)
+	for count > 4 && clLengths[codeLengthCodeOrder[count-1]] == 0 {
+		count--
+	}
+	w.write(0, 1) // normal code
+	w.write(uint32(count-4), 4)
+	for _, s := range codeLengthCodeOrder[:count] {
+		w.write(uint32(clLengths[s]), 3)
+	}
+	w.write(0, 1) // lengths for the whole alphabet follow
+	for _, t := range tokens {
+		w.write(clCodes[t.symbol], uint(clLengths[t.symbol]))
+		if t.extraWidth > 0 {
+			w.write(t.extra, t.extraWidth)
+		}
+	}
+	return code
+}
+
+// codeLengths builds Huffman code lengths no longer than limit. When the
+// tree comes out too deep, rare symbols are counted as more frequent and it
+// is built again, which flattens it while keeping the code complete.
+func codeLengths(hist []int, limit int) []uint8 {
+	lengths := make([]uint8, len(hist))
+	for minCount := 1; ; minCount *= 2 {
+		type node struct {
+			weight      int
+			left, right int
+		}
+		var nodes []node
+		var leaves []int
+		for s, n := range hist {
+			if n > 0 {
+				nodes = append(nodes, node{weight: max(n, minCount), left: -1, right: s})
+				leaves = append(leaves, len(nodes)-1)
+			}
+		}
+		if len(leaves) < 2 {
+			for _, l := range leaves {
+				lengths[nodes[l].right] = 1
+			}
+			return lengths
+		}
+		sort.SliceStable(leaves, func(i, j int) bool {
+			return nodes[leaves[i]].weight < nodes[leaves[j]].weight
+		})
+
+		// Two-queue Huffman: merged nodes are created in weight order, so
+		// the smallest remaining node is at the front of one of the queues.
+		var merged []int
+		pop := func() int {
+			if len(merged) == 0 || (len(leaves) > 0 && nodes[leaves[0]].weight <= nodes[merged[0]].weight) {
+				n := leaves[0]
+				leaves = leaves[1:]
+				return n
+			}
+			n := merged[0]
+			merged = merged[1:]
+			return n
+		}
+		for len(leaves)+len(merged) > 1 {
+			a, b := pop(), pop()
+			nodes = append(nodes, node{weight: nodes[a].weight + nodes[b].weight, left: a, right: b})
+			merged = append(merged, len(nodes)-1)
+		}
+
+		clear(lengths)
+		deepest := 0
+		var walk func(n, depth int)
+		walk = func(n, depth int) {
+			if nodes[n].left < 0 {
+				lengths[nodes[n].right] = uint8(depth)
+				deepest = max(deepest, depth)
+				return
+			}
+			walk(nodes[n].left, depth+1)
+			walk(nodes[n].right, depth+1)
+		}
+		walk(merged[0], 0)
+		if deepest <= limit {
+			return lengths
+		}
+	}
+}
+
+// canonicalCodes assigns canonical codes to the lengths, bit-reversed for
+// writing.
+func canonicalCodes(lengths []uint8) []uint32 {
+	var count [maxCodeLength + 1]uint32
+	for _, n := range lengths {
+		count[n]++
+	}
+	count[0] = 0
+	var next [maxCodeLength + 1]uint32
+	code := uint32(0)
+	for n := 1; n <= maxCodeLength; n++ {
+		code = (code + count[n-1]) << 1
+		next[n] = code
+	}
+	codes := make([]uint32, len(lengths))
+	for s, n := range lengths {
+		if n == 0 {
+			continue
+		}
+		c := next[n]
+		next[n]++
+		var r uint32
+		for i := uint8(0); i < n; i++ {
+			r = r<<1 | c&1
+			c >>= 1
+		}
+		codes[s] = r
+	}
+	return codes
+}
@@ -0,0 +1,75 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func TestEncodeWebPRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{"single pixel", fill(1, 1, func(x, y int) color.NRGBA { return color.NRGBA{200, 30, 90, 255} })},
+		{"gradient", fill(64, 48, func(x, y int) color.NRGBA {
+			return color.NRGBA{uint8(x * 4), uint8(y * 5), uint8(x + y), 255}
+		})},
+		{"noise", fill(37, 23, func(x, y int) color.NRGBA {
+			return color.NRGBA{uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), 255}
+		})},
+		{"transparency", fill(33, 17, func(x, y int) color.NRGBA {
+			return color.NRGBA{uint8(rng.Intn(256)), uint8(x * 7), uint8(y * 15), uint8(rng.Intn(256))}
+		})},
+		{"wider than a predictor block", fill(600, 3, func(x, y int) color.NRGBA {
+			return color.NRGBA{uint8(x), uint8(x >> 1), uint8(y * 80), 255}
+		})},
+		{"offset bounds", image.NewNRGBA(image.Rect(5, 7, 20, 19))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeWebP(tt.img)
+			if err != nil {
+				t.Fatalf("EncodeWebP: %v", err)
+			}
+			got, err := webp.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			want := tt.img.Bounds()
+			if got.Bounds().Dx() != want.Dx() || got.Bounds().Dy() != want.Dy() {
+				t.Fatalf("size %v, want %v", got.Bounds().Size(), want.Size())
+			}
+			for y := 0; y < want.Dy(); y++ {
+				for x := 0; x < want.Dx(); x++ {
+					g := color.NRGBAModel.Convert(got.At(got.Bounds().Min.X+x, got.Bounds().Min.Y+y)).(color.NRGBA)
+					w := color.NRGBAModel.Convert(tt.img.At(want.Min.X+x, want.Min.Y+y)).(color.NRGBA)
+					if g != w {
+						t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, g, w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWebPTooLarge(t *testing.T) {
+	if _, err := EncodeWebP(image.NewNRGBA(image.Rect(0, 0, vp8lMaxSize+1, 1))); err != errWebPTooLarge {
+		t.Fatalf("err = %v, want %v", err, errWebPTooLarge)
+	}
+}
+
+func fill(w, h int, at func(x, y int) color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, at(x, y))
+		}
+	}
+	return img
+}
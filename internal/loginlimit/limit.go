@@ -0,0 +1,214 @@
+package loginlimit
+
+import (
+	"app/internal/config"
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// policy decides how a key is slowed down. After free failures every further
+// attempt waits baseDelay, doubled per failure up to maxDelay; at lockAfter
+// failures the key is locked for lockFor. Failures older than failureWindow
+// are forgotten.
+type policy struct {
+	free      int
+	lockAfter int
+	lockFor   time.Duration
+}
+
+const (
+	failureWindow = time.Hour
+	baseDelay     = time.Second
+	maxDelay      = 5 * time.Minute
+	pruneInterval = 10 * time.Minute
+)
+
+var (
+	// Identifiers are accounts: few guesses before they are locked.
+	identifierPolicy = policy{free: 3, lockAfter: 10, lockFor: 15 * time.Minute}
+	// Addresses may be shared by many people behind one NAT.
+	ipPolicy = policy{free: 20, lockAfter: 100, lockFor: 15 * time.Minute}
+)
+
+var (
+	store Store = newMemoryStore()
+	done        = make(chan struct{})
+	wg    sync.WaitGroup
+)
+
+// LimitError is returned by Attempt while a key must wait. Reject answers it
+// with 429.
+type LimitError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LimitError) Error() string {
+	if e.Locked {
+		return "login temporarily locked after too many failed attempts"
+	}
+	return "too many failed attempts, try again later"
+}
+
+// StaffKey and CustomerKey name the identifier keys of the two login flows.
+func StaffKey(identify string) string {
+	return "staff:" + strings.ToLower(strings.TrimSpace(identify))
+}
+
+func CustomerKey(phone string) string {
+	return "customer:" + strings.TrimSpace(phone)
+}
+
+// OTPKey names the key of one-time code checks for the customer identify
+// names, so codes are guessed no faster than passwords.
+func OTPKey(identify string) string {
+	return "otp:" + strings.ToLower(strings.TrimSpace(identify))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Start selects the store from config and runs the background pruning of
+// old entries.
+func Start() {
+	switch config.LoginLimitStore {
+	case "memory":
+		store = newMemoryStore()
+	case "postgres":
+		store = postgresStore{}
+	default:
+		log.Fatalf("Unknown LOGIN_LIMIT_STORE %q", config.LoginLimitStore)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := store.Prune(context.Background(), time.Now().Add(-failureWindow)); err != nil {
+					log.Printf("loginlimit: prune: %v", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func Stop() {
+	close(done)
+	wg.Wait()
+}
+
+// Attempt must run before the password is compared. It counts the attempt
+// as a failure of ip and key until Succeed takes it back, and returns a
+// *LimitError while either is locked or backing off. The decision is made
+// from the count the store returns for this very attempt, so a burst of
+// parallel logins can't all pass on the same count; attempts made while
+// backing off count too.
+func Attempt(ctx context.Context, ip, key string) error {
+	now := time.Now()
+	var wait time.Duration
+	locked := false
+	for _, k := range []struct {
+		key string
+		p   policy
+	}{{ipKey(ip), ipPolicy}, {key, identifierPolicy}} {
+		e, previous, err := store.RecordFailure(ctx, k.key, now, now.Add(-failureWindow))
+		if err != nil {
+			return err
+		}
+		// Lock on the first attempt past the threshold, and again on the
+		// second attempt after a lock ran out.
+		if e.Failures > k.p.lockAfter && !e.LockedUntil.After(previous) {
+			e.LockedUntil = now.Add(k.p.lockFor)
+			if err := store.Lock(ctx, k.key, e.LockedUntil); err != nil {
+				return err
+			}
+		}
+		if e.LockedUntil.After(now) {
+			locked = true
+		}
+		before := Entry{Failures: e.Failures - 1, LastFailedAt: previous, LockedUntil: e.LockedUntil}
+		if w := retryAt(before, k.p).Sub(now); w > wait {
+			wait = w
+		}
+	}
+	if wait > 0 {
+		return &LimitError{RetryAfter: wait, Locked: locked}
+	}
+	return nil
+}
+
+// Succeed takes back the attempt: it clears the failures of key and forgives
+// the one counted for ip. The address keeps its earlier failures so one
+// valid account cannot be used to reset it.
+func Succeed(ctx context.Context, ip, key string) error {
+	if err := store.Forgive(ctx, ipKey(ip)); err != nil {
+		return err
+	}
+	return store.Delete(ctx, key)
+}
+
+// Locked lists the keys locked right now, latest lock first.
+func Locked(ctx context.Context) ([]Entry, error) {
+	entries, err := store.Locked(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LockedUntil.After(entries[j].LockedUntil)
+	})
+	return entries, nil
+}
+
+// Unlock clears keys, both their lock and their failures.
+func Unlock(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return store.Delete(ctx, keys...)
+}
+
+// Reject answers an error from Attempt or Succeed.
+func Reject(c *fiber.Ctx, err error) error {
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		seconds := int(math.Ceil(limitErr.RetryAfter.Seconds()))
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error":       limitErr.Error(),
+			"retry_after": seconds,
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+func retryAt(e Entry, p policy) time.Time {
+	if e.LockedUntil.After(e.LastFailedAt) {
+		return e.LockedUntil
+	}
+	if e.Failures <= p.free || time.Since(e.LastFailedAt) > failureWindow {
+		return time.Time{}
+	}
+	delay := maxDelay
+	if shift := e.Failures - p.free - 1; shift < 20 {
+		delay = min(baseDelay<<shift, maxDelay)
+	}
+	return e.LastFailedAt.Add(delay)
+}
@@ -0,0 +1,72 @@
+package loginlimit
+
+import (
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// postgresStore keeps counters in the login_attempts table of the auth
+// database.
+type postgresStore struct{}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+func toEntry(a auth_db.LoginAttempt) Entry {
+	return Entry{
+		Key:          a.Key,
+		Failures:     int(a.Failures),
+		LastFailedAt: a.LastFailedAt.Time,
+		LockedUntil:  a.LockedUntil.Time,
+	}
+}
+
+// RecordFailure increments and reads the counter in one upsert, so
+// concurrent attempts on a key can't read the same count.
+func (postgresStore) RecordFailure(ctx context.Context, key string, now, windowStart time.Time) (Entry, time.Time, error) {
+	a, err := db.AuthQueries.RecordLoginFailure(ctx, auth_db.RecordLoginFailureParams{
+		Key:         key,
+		Now:         timestamptz(now),
+		WindowStart: timestamptz(windowStart),
+	})
+	if err != nil {
+		return Entry{}, time.Time{}, err
+	}
+	return toEntry(a), a.PreviousFailedAt.Time, nil
+}
+
+func (postgresStore) Forgive(ctx context.Context, key string) error {
+	return db.AuthQueries.ForgiveLoginFailure(ctx, key)
+}
+
+func (postgresStore) Lock(ctx context.Context, key string, until time.Time) error {
+	return db.AuthQueries.LockLoginAttempt(ctx, auth_db.LockLoginAttemptParams{
+		Key:         key,
+		LockedUntil: timestamptz(until),
+	})
+}
+
+func (postgresStore) Delete(ctx context.Context, keys ...string) error {
+	return db.AuthQueries.DeleteLoginAttempts(ctx, keys)
+}
+
+func (postgresStore) Locked(ctx context.Context, now time.Time) ([]Entry, error) {
+	rows, err := db.AuthQueries.GetLockedLoginAttempts(ctx, timestamptz(now))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Entry, len(rows))
+	for i, r := range rows {
+		result[i] = toEntry(r)
+	}
+	return result, nil
+}
+
+func (postgresStore) Prune(ctx context.Context, before time.Time) error {
+	return db.AuthQueries.DeleteStaleLoginAttempts(ctx, timestamptz(before))
+}
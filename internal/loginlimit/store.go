@@ -0,0 +1,110 @@
+package loginlimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Entry is the failure state of one key.
+type Entry struct {
+	Key          string    `json:"key"`
+	Failures     int       `json:"failures"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+	LockedUntil  time.Time `json:"locked_until"`
+}
+
+// Store keeps failure counters. The memory store is enough for a single
+// instance; run several instances against the Postgres store so they share
+// counters.
+type Store interface {
+	// RecordFailure counts a failure at now and returns the entry after it
+	// together with the time of the failure before, zero if none. Failures
+	// before windowStart are forgotten first. Concurrent calls for a key
+	// each see their own count.
+	RecordFailure(ctx context.Context, key string, now, windowStart time.Time) (Entry, time.Time, error)
+	// Forgive takes back one failure of key.
+	Forgive(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, until time.Time) error
+	Delete(ctx context.Context, keys ...string) error
+	// Locked returns the entries still locked at now.
+	Locked(ctx context.Context, now time.Time) ([]Entry, error)
+	// Prune drops entries whose last failure and lock are before before.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*Entry
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{entries: map[string]*Entry{}}
+}
+
+func (s *memoryStore) RecordFailure(ctx context.Context, key string, now, windowStart time.Time) (Entry, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		e = &Entry{Key: key}
+		s.entries[key] = e
+	}
+	if e.LastFailedAt.Before(windowStart) {
+		e.Failures = 0
+	}
+	previous := e.LastFailedAt
+	e.Failures++
+	e.LastFailedAt = now
+	return *e, previous, nil
+}
+
+func (s *memoryStore) Forgive(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && e.Failures > 0 {
+		e.Failures--
+	}
+	return nil
+}
+
+func (s *memoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		e.LockedUntil = until
+	}
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) Locked(ctx context.Context, now time.Time) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []Entry
+	for _, e := range s.entries {
+		if e.LockedUntil.After(now) {
+			result = append(result, *e)
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryStore) Prune(ctx context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, e := range s.entries {
+		if e.LastFailedAt.Before(before) && e.LockedUntil.Before(before) {
+			delete(s.entries, key)
+		}
+	}
+	return nil
+}
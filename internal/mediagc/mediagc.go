@@ -0,0 +1,200 @@
+// Package mediagc removes uploaded media that nothing references anymore.
+// A file is first marked when a run finds it unused and only deleted once
+// it has stayed unused for config.MediaGCAfter, so a file uploaded for a
+// product that is still being written is not lost.
+package mediagc
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	"app/internal/storage"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// batchSize bounds the names looked up per usage query.
+const batchSize = 1000
+
+// prefix is where Save stores uploads; objects elsewhere are not ours.
+const prefix = "media/"
+
+type File struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	UnreferencedSince time.Time `json:"unreferenced_since"`
+}
+
+// Report lists what a run deleted, or would have deleted in dry-run mode.
+// Objects are stored files that have no row in the files table.
+type Report struct {
+	DryRun  bool     `json:"dry_run"`
+	Files   []File   `json:"files"`
+	Objects []string `json:"objects"`
+}
+
+var (
+	done = make(chan struct{})
+	wg   sync.WaitGroup
+)
+
+// Run finds files unreferenced for longer than after, and stored objects
+// older than after that no file owns, and deletes them unless dryRun is
+// set. Unreferenced files are marked either way, so a dry run still starts
+// their clock.
+func Run(ctx context.Context, after time.Duration, dryRun bool) (Report, error) {
+	report := Report{DryRun: dryRun, Files: []File{}, Objects: []string{}}
+	cutoff := time.Now().Add(-after)
+
+	files, err := db.ProductQueries.GetFilesForCleanup(ctx)
+	if err != nil {
+		return report, err
+	}
+	known := make(map[string]bool, len(files))
+	var orphans []int64
+	for start := 0; start < len(files); start += batchSize {
+		batch := files[start:min(start+batchSize, len(files))]
+		names := make([]string, len(batch))
+		for i, f := range batch {
+			names[i] = f.Name
+			known[f.Name] = true
+		}
+		usages, err := storage.Usages(ctx, names)
+		if err != nil {
+			return report, err
+		}
+
+		var unused, used []int64
+		for _, f := range batch {
+			if len(usages[f.Name]) > 0 {
+				if f.UnreferencedSince.Valid {
+					used = append(used, f.ID)
+				}
+				continue
+			}
+			if !f.UnreferencedSince.Valid {
+				unused = append(unused, f.ID)
+				continue
+			}
+			if f.UnreferencedSince.Time.Before(cutoff) {
+				orphans = append(orphans, f.ID)
+				report.Files = append(report.Files, File{ID: f.ID, Name: f.Name, UnreferencedSince: f.UnreferencedSince.Time})
+			}
+		}
+		if len(unused) > 0 {
+			if err := db.ProductQueries.MarkFilesUnreferenced(ctx, unused); err != nil {
+				return report, err
+			}
+		}
+		if len(used) > 0 {
+			if err := db.ProductQueries.ClearFilesUnreferenced(ctx, used); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	renditions, err := db.ProductQueries.GetAllFileRenditionNames(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, name := range renditions {
+		known[name] = true
+	}
+	objects, err := storage.List(ctx, prefix)
+	if err != nil {
+		return report, err
+	}
+	for _, o := range objects {
+		if !known[o.Name] && o.ModTime.Before(cutoff) {
+			report.Objects = append(report.Objects, o.Name)
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	if len(orphans) > 0 {
+		rows, err := db.ProductQueries.GetFilesByIDs(ctx, orphans)
+		if err != nil {
+			return report, err
+		}
+		if err := storage.Remove(ctx, rows); err != nil {
+			return report, err
+		}
+	}
+	for _, name := range report.Objects {
+		if err := storage.Delete(ctx, name); err != nil {
+			log.Printf("mediagc: delete %s: %v", name, err)
+		}
+	}
+	return report, nil
+}
+
+// Start runs the collection every config.MediaGCInterval, not at all when
+// it is 0.
+func Start() {
+	if config.MediaGCInterval == 0 {
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(config.MediaGCInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				collect()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func Stop() {
+	close(done)
+	wg.Wait()
+}
+
+func collect() {
+	report, err := Run(context.Background(), config.MediaGCAfter, config.MediaGCDryRun)
+	if err != nil {
+		log.Printf("mediagc: %v", err)
+		return
+	}
+	verb := "deleted"
+	if report.DryRun {
+		verb = "would delete"
+	}
+	log.Printf("mediagc: %s %d files and %d stray objects", verb, len(report.Files), len(report.Objects))
+	for _, f := range report.Files {
+		log.Printf("mediagc: %s %s (unreferenced since %s)", verb, f.Name, f.UnreferencedSince.Format(time.RFC3339))
+	}
+	for _, name := range report.Objects {
+		log.Printf("mediagc: %s stray object %s", verb, name)
+	}
+}
+
+// Command runs one collection from the command line and prints the report
+// as JSON: app media-gc [-dry-run=false] [-after 720h].
+func Command(args []string) {
+	fs := flag.NewFlagSet("media-gc", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", true, "only report what would be deleted")
+	after := fs.Duration("after", config.MediaGCAfter, "how long a file must be unreferenced")
+	fs.Parse(args)
+
+	report, err := Run(context.Background(), *after, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "media-gc: %v\n", err)
+		os.Exit(1)
+	}
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(out))
+}
@@ -0,0 +1,64 @@
+package attribute
+
+type PaginatedResponse[T any] struct {
+	Page       int   `json:"page" example:"1"`
+	PageSize   int   `json:"page_size" example:"10"`
+	TotalItems int64 `json:"total_items" example:"125"`
+	TotalPages int   `json:"total_pages" example:"13"`
+	Data       []T   `json:"data"`
+}
+
+type CreateAttributeRequest struct {
+	Code         string   `json:"code" validate:"required" example:"material"`
+	Name         string   `json:"name" validate:"required" example:"Chất liệu"`
+	Type         string   `json:"type" validate:"required,oneof=text number enum boolean" example:"enum"`
+	Unit         string   `json:"unit" example:""`
+	Options      []string `json:"options" validate:"required_if=Type enum,dive,required" example:"inox 304,inox 201"`
+	IsFilterable bool     `json:"is_filterable" example:"true"`
+}
+
+type UpdateAttributeRequest struct {
+	Code         string   `json:"code" validate:"required" example:"material"`
+	Name         string   `json:"name" validate:"required" example:"Chất liệu"`
+	Type         string   `json:"type" validate:"required,oneof=text number enum boolean" example:"enum"`
+	Unit         string   `json:"unit" example:""`
+	Options      []string `json:"options" validate:"required_if=Type enum,dive,required" example:"inox 304,inox 201"`
+	IsFilterable bool     `json:"is_filterable" example:"true"`
+}
+
+type DeleteAttributesRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type CategoryAttribute struct {
+	AttributeID int64 `json:"attribute_id" validate:"required"`
+	IsRequired  bool  `json:"is_required"`
+}
+
+type SetCategoryAttributesRequest struct {
+	Attributes []CategoryAttribute `json:"attributes" validate:"dive"`
+}
+
+// Value is an attribute value as sent in product create and update requests.
+// Value holds a string, number or boolean depending on the attribute type.
+type Value struct {
+	AttributeID int64 `json:"attribute_id" validate:"required"`
+	Value       any   `json:"value"`
+}
+
+// ProductValue is an attribute value as returned in product responses.
+type ProductValue struct {
+	ID    int64  `json:"id"`
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Unit  string `json:"unit"`
+	Value any    `json:"value"`
+}
+
+type Filter struct {
+	Code   string   `json:"code"`
+	Values []string `json:"values,omitempty"`
+	Min    *float64 `json:"min,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+}
@@ -0,0 +1,356 @@
+package attribute
+
+import (
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAttributesHandler godoc
+// @Summary      Get attribute list
+// @Description  Returns a list of product attribute definitions
+// @Tags         attributes
+// @Security BearerAuth
+// @Produce      json
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Router       /attributes [get]
+func GetAttributesHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetAttributes(ctx, product_db.GetAttributesParams{
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountAttributes(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetAttributesRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+// GetAttributeHandler godoc
+// @Summary      Get an attribute
+// @Description  Returns an attribute definition by ID
+// @Tags         attributes
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes/{id} [get]
+func GetAttributeHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetAttribute(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// CreateAttributeHandler godoc
+// @Summary      Create a new attribute
+// @Description  Creates a product attribute definition
+// @Tags         attributes
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body	CreateAttributeRequest  true  "Create data"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes [post]
+func CreateAttributeHandler(c *fiber.Ctx) error {
+	var req CreateAttributeRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if req.Type != TypeEnum || req.Options == nil {
+		req.Options = []string{}
+	}
+
+	ctx := context.Background()
+	attributeID, err := db.ProductQueries.CreateAttribute(ctx, product_db.CreateAttributeParams{
+		Code:         req.Code,
+		Name:         req.Name,
+		Type:         req.Type,
+		Unit:         req.Unit,
+		Options:      req.Options,
+		IsFilterable: req.IsFilterable,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id": attributeID,
+	})
+}
+
+// UpdateAttributeHandler godoc
+// @Summary      Update an attribute
+// @Description  Updates a product attribute definition. Stored product values are not converted when the type changes.
+// @Tags         attributes
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Param        payload  body	UpdateAttributeRequest  true  "Update data"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes/{id} [put]
+func UpdateAttributeHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req UpdateAttributeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if req.Type != TypeEnum || req.Options == nil {
+		req.Options = []string{}
+	}
+
+	ctx := context.Background()
+	err = db.ProductQueries.UpdateAttribute(ctx, product_db.UpdateAttributeParams{
+		ID:           id,
+		Code:         req.Code,
+		Name:         req.Name,
+		Type:         req.Type,
+		Unit:         req.Unit,
+		Options:      req.Options,
+		IsFilterable: req.IsFilterable,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// BulkDeleteAttributesHandler godoc
+// @Summary      Delete multiple attributes
+// @Description  Deletes attribute definitions together with their category links and product values
+// @Tags         attributes
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeleteAttributesRequest  true  "List of attribute IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes [delete]
+func BulkDeleteAttributesHandler(c *fiber.Ctx) error {
+	var req DeleteAttributesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	ctx := context.Background()
+	if err := db.ProductQueries.BulkDeleteAttributes(ctx, req.IDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// GetCategoryAttributesHandler godoc
+// @Summary      Get category attributes
+// @Description  Returns the attributes attached directly to a category
+// @Tags         attributes
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {array}   map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes/categories/{id}/assigned [get]
+func GetCategoryAttributesHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetCategoryAttributes(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// SetCategoryAttributesHandler godoc
+// @Summary      Set category attributes
+// @Description  Replaces the attributes attached to a category. Order in the list is the display order.
+// @Tags         attributes
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "Category ID"
+// @Param        payload  body      SetCategoryAttributesRequest  true  "Attributes"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes/categories/{id} [put]
+func SetCategoryAttributesHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	categoryID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req SetCategoryAttributesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	if err := db.ProductQueries.DeleteCategoryAttributes(ctx, categoryID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if len(req.Attributes) > 0 {
+		params := product_db.BulkInsertCategoryAttributesParams{}
+		for i, a := range req.Attributes {
+			params.CategoryIds = append(params.CategoryIds, categoryID)
+			params.AttributeIds = append(params.AttributeIds, a.AttributeID)
+			params.IsRequireds = append(params.IsRequireds, a.IsRequired)
+			params.Nos = append(params.Nos, int32(i))
+		}
+		if err := db.ProductQueries.BulkInsertCategoryAttributes(ctx, params); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// GetAttributesByCategoryHandler godoc
+// @Summary      Get attributes for a category
+// @Description  Returns every attribute that applies to a category, including those inherited from parent categories. Use it to build product forms and listing filters.
+// @Tags         attributes
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {array}   map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /attributes/categories/{id} [get]
+func GetAttributesByCategoryHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetAttributesByCategory(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,240 @@
+package attribute
+
+import (
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	TypeText    = "text"
+	TypeNumber  = "number"
+	TypeEnum    = "enum"
+	TypeBoolean = "boolean"
+)
+
+// ValidationError reports attribute input that does not match the attribute
+// definitions. Handlers answer it with 400.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string {
+	return e.msg
+}
+
+func invalid(format string, args ...any) error {
+	return &ValidationError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Validate checks values against the attributes that apply to the category
+// and returns them normalized for storage. Values whose Value is null are
+// dropped.
+func Validate(ctx context.Context, categoryID int64, values []Value) ([]Value, error) {
+	if categoryID <= 0 {
+		if len(values) > 0 {
+			return nil, invalid("attributes require a category")
+		}
+		return nil, nil
+	}
+
+	defs, err := db.ProductQueries.GetAttributesByCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]product_db.GetAttributesByCategoryRow, len(defs))
+	for _, d := range defs {
+		byID[d.ID] = d
+	}
+
+	result := make([]Value, 0, len(values))
+	seen := make(map[int64]bool, len(values))
+	for _, v := range values {
+		def, ok := byID[v.AttributeID]
+		if !ok {
+			return nil, invalid("attribute %d does not apply to this category", v.AttributeID)
+		}
+		if seen[v.AttributeID] {
+			return nil, invalid("attribute %s is set more than once", def.Code)
+		}
+		if v.Value == nil {
+			continue
+		}
+		normalized, err := normalize(def, v.Value)
+		if err != nil {
+			return nil, err
+		}
+		seen[v.AttributeID] = true
+		result = append(result, Value{AttributeID: v.AttributeID, Value: normalized})
+	}
+
+	for _, d := range defs {
+		if d.IsRequired && !seen[d.ID] {
+			return nil, invalid("attribute %s is required", d.Code)
+		}
+	}
+	return result, nil
+}
+
+func normalize(def product_db.GetAttributesByCategoryRow, value any) (string, error) {
+	switch def.Type {
+	case TypeNumber:
+		switch n := value.(type) {
+		case float64:
+			return strconv.FormatFloat(n, 'f', -1, 64), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+			if err != nil {
+				return "", invalid("attribute %s must be a number", def.Code)
+			}
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+		return "", invalid("attribute %s must be a number", def.Code)
+	case TypeBoolean:
+		switch b := value.(type) {
+		case bool:
+			return strconv.FormatBool(b), nil
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil {
+				return "", invalid("attribute %s must be true or false", def.Code)
+			}
+			return strconv.FormatBool(parsed), nil
+		}
+		return "", invalid("attribute %s must be true or false", def.Code)
+	case TypeEnum:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(def.Options, s) {
+			return "", invalid("attribute %s must be one of: %s", def.Code, strings.Join(def.Options, ", "))
+		}
+		return s, nil
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return "", invalid("attribute %s must be text", def.Code)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return "", invalid("attribute %s must not be empty", def.Code)
+		}
+		return s, nil
+	}
+}
+
+// SaveProductValues replaces the attribute values of a product with values
+// returned by Validate.
+func SaveProductValues(ctx context.Context, productID int64, values []Value) error {
+	if err := db.ProductQueries.DeleteProductAttributeValues(ctx, productID); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	params := product_db.BulkInsertProductAttributeValuesParams{}
+	for _, v := range values {
+		params.ProductIds = append(params.ProductIds, productID)
+		params.AttributeIds = append(params.AttributeIds, v.AttributeID)
+		params.Values = append(params.Values, v.Value.(string))
+	}
+	return db.ProductQueries.BulkInsertProductAttributeValues(ctx, params)
+}
+
+// GetProductValues returns the attribute values of a product with each value
+// typed according to its attribute.
+func GetProductValues(ctx context.Context, productID int64) ([]ProductValue, error) {
+	rows, err := db.ProductQueries.GetProductAttributeValues(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ProductValue, len(rows))
+	for i, r := range rows {
+		result[i] = ProductValue{
+			ID:    r.ID,
+			Code:  r.Code,
+			Name:  r.Name,
+			Type:  r.Type,
+			Unit:  r.Unit,
+			Value: r.Value,
+		}
+		switch r.Type {
+		case TypeNumber:
+			result[i].Value = r.ValueNumber.Float64
+		case TypeBoolean:
+			result[i].Value = r.Value == "true"
+		}
+	}
+	return result, nil
+}
+
+// ParseFilters reads attribute filters from the query string and encodes
+// them for the product listing queries. It understands
+//
+//	attr[material]=inox 304,inox 201
+//	attr[capacity][min]=1&attr[capacity][max]=5
+//
+// and returns nil when no filter is given.
+func ParseFilters(c *fiber.Ctx) ([]byte, error) {
+	filters := map[string]*Filter{}
+	for key, raw := range c.Queries() {
+		rest, ok := strings.CutPrefix(key, "attr[")
+		if !ok {
+			continue
+		}
+		code, bound, _ := strings.Cut(rest, "]")
+		if code == "" {
+			continue
+		}
+		f, ok := filters[code]
+		if !ok {
+			f = &Filter{Code: code}
+			filters[code] = f
+		}
+
+		switch bound {
+		case "":
+			for _, v := range strings.Split(raw, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					f.Values = append(f.Values, v)
+				}
+			}
+		case "[min]", "[max]":
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, invalid("attribute filter %s must be a number", key)
+			}
+			if bound == "[min]" {
+				f.Min = &n
+			} else {
+				f.Max = &n
+			}
+		default:
+			return nil, invalid("unknown attribute filter %s", key)
+		}
+	}
+
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	codes := make([]string, 0, len(filters))
+	for code := range filters {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	list := make([]*Filter, 0, len(codes))
+	for _, code := range codes {
+		list = append(list, filters[code])
+	}
+	return json.Marshal(list)
+}
@@ -0,0 +1,31 @@
+package auditlog
+
+import (
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PaginatedResponse[T any] struct {
+	Page       int   `json:"page" example:"1"`
+	PageSize   int   `json:"page_size" example:"10"`
+	TotalItems int64 `json:"total_items" example:"125"`
+	TotalPages int   `json:"total_pages" example:"13"`
+	Data       []T   `json:"data"`
+}
+
+type AuditLogResponse struct {
+	ID         int64              `json:"id"`
+	ActorID    pgtype.UUID        `json:"actor_id"`
+	ActorName  pgtype.Text        `json:"actor_name"`
+	Action     string             `json:"action" example:"update"`
+	EntityType string             `json:"entity_type" example:"products"`
+	EntityID   pgtype.Text        `json:"entity_id"`
+	Method     string             `json:"method" example:"PUT"`
+	Path       string             `json:"path" example:"/api/v1/products/12"`
+	Status     int32              `json:"status" example:"200"`
+	Before     json.RawMessage    `json:"before" swaggertype:"object"`
+	After      json.RawMessage    `json:"after" swaggertype:"object"`
+	IP         string             `json:"ip"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
@@ -0,0 +1,132 @@
+package auditlog
+
+import (
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetAuditLogsHandler godoc
+// @Summary      Get audit logs
+// @Description  Returns the changes made by staff, newest first. Dates are RFC 3339 or YYYY-MM-DD; to is exclusive.
+// @Tags         audit-logs
+// @Security BearerAuth
+// @Produce      json
+// @Param        page         query     int     false  "Page number"  default(1)
+// @Param        page_size    query     int     false  "Page size"    default(10)
+// @Param        actor_id     query     string  false  "Staff user ID"
+// @Param        action       query     string  false  "create, update or delete"
+// @Param        entity_type  query     string  false  "Entity type, e.g. products"
+// @Param        entity_id    query     string  false  "Entity ID"
+// @Param        from         query     string  false  "Changed at or after"
+// @Param        to           query     string  false  "Changed before"
+// @Success      200  {object}  PaginatedResponse[AuditLogResponse]
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /audit-logs [get]
+func GetAuditLogsHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	filter := auth_db.CountAuditLogsParams{
+		Action:     optionalText(c.Query("action")),
+		EntityType: optionalText(c.Query("entity_type")),
+		EntityID:   optionalText(c.Query("entity_id")),
+	}
+	if s := c.Query("actor_id"); s != "" {
+		if err := filter.ActorID.Scan(s); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid actor_id",
+			})
+		}
+	}
+	var err error
+	if filter.CreatedFrom, err = parseTime(c.Query("from")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid from",
+		})
+	}
+	if filter.CreatedTo, err = parseTime(c.Query("to")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid to",
+		})
+	}
+
+	ctx := context.Background()
+	rows, err := db.AuthQueries.GetAuditLogs(ctx, auth_db.GetAuditLogsParams{
+		ActorID:     filter.ActorID,
+		Action:      filter.Action,
+		EntityType:  filter.EntityType,
+		EntityID:    filter.EntityID,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		LimitCount:  int32(pageSize),
+		OffsetCount: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.AuthQueries.CountAuditLogs(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	result := make([]AuditLogResponse, len(rows))
+	for i, r := range rows {
+		result[i] = AuditLogResponse{
+			ID:         r.ID,
+			ActorID:    r.ActorID,
+			ActorName:  r.ActorName,
+			Action:     r.Action,
+			EntityType: r.EntityType,
+			EntityID:   r.EntityID,
+			Method:     r.Method,
+			Path:       r.Path,
+			Status:     r.Status,
+			Before:     json.RawMessage(r.Before),
+			After:      json.RawMessage(r.After),
+			IP:         r.Ip,
+			CreatedAt:  r.CreatedAt,
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[AuditLogResponse]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func parseTime(s string) (pgtype.Timestamptz, error) {
+	if s == "" {
+		return pgtype.Timestamptz{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.Parse(time.DateOnly, s)
+		if err != nil {
+			return pgtype.Timestamptz{}, err
+		}
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}, nil
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"app/internal/token"
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateAPIKeyHandler godoc
+// @Summary      Create API key
+// @Description  Issues an API key for a server-to-server integration. The key is sent as X-API-Key or as a bearer token and acts as the current user, limited to the given permissions, which must be a subset of the user's own. The key itself is returned only once.
+// @Tags         auth
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateAPIKeyRequest  true  "API key"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/api-keys [post]
+func CreateAPIKeyHandler(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Token).Claims.(jwt.MapClaims)
+	if token.IsAPIKey(claims) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "API keys cannot create API keys",
+		})
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "expires_at must be in the future",
+		})
+	}
+
+	userID := currentUserID(c)
+	ctx := context.Background()
+
+	granted, err := db.AuthQueries.GetPermissionsByUser(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for _, p := range req.Permissions {
+		if !slices.Contains(granted, p) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "cannot grant permission " + p,
+			})
+		}
+	}
+	slices.Sort(req.Permissions)
+	req.Permissions = slices.Compact(req.Permissions)
+
+	key, prefix, hash, err := token.NewAPIKey()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	expiresAt := pgtype.Timestamptz{Time: req.ExpiresAt, Valid: !req.ExpiresAt.IsZero()}
+	row, err := db.AuthQueries.CreateApiKey(ctx, auth_db.CreateApiKeyParams{
+		Name:        req.Name,
+		Prefix:      prefix,
+		KeyHash:     hash,
+		Permissions: req.Permissions,
+		CreatedBy:   userID,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":          row.ID,
+		"name":        req.Name,
+		"key":         key,
+		"prefix":      prefix,
+		"permissions": req.Permissions,
+		"expires_at":  expiresAt,
+		"created_at":  row.CreatedAt,
+	})
+}
+
+// GetAPIKeysHandler godoc
+// @Summary      Get API keys
+// @Description  Returns every API key with its permissions, expiry and last use. Keys are identified by their prefix; the keys themselves are not stored.
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/api-keys [get]
+func GetAPIKeysHandler(c *fiber.Ctx) error {
+	rows, err := db.AuthQueries.GetApiKeys(context.Background())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	data := make([]APIKeyResponse, len(rows))
+	for i, row := range rows {
+		data[i] = APIKeyResponse{
+			ID:            row.ID,
+			Name:          row.Name,
+			Prefix:        row.Prefix,
+			Permissions:   row.Permissions,
+			CreatedBy:     row.CreatedBy,
+			CreatedByName: row.CreatedByName,
+			ExpiresAt:     row.ExpiresAt,
+			LastUsedAt:    row.LastUsedAt,
+			RevokedAt:     row.RevokedAt,
+			CreatedAt:     row.CreatedAt,
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": data,
+	})
+}
+
+// RevokeAPIKeyHandler godoc
+// @Summary      Revoke API key
+// @Description  Revokes an API key. Requests made with it are rejected from then on.
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "API key ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/api-keys/{id} [delete]
+func RevokeAPIKeyHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	n, err := db.AuthQueries.RevokeApiKey(context.Background(), id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "API key not found",
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "API key revoked",
+	})
+}
@@ -1,5 +1,11 @@
 package auth
 
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
 type LoginRequest struct {
 	Identify string `json:"identify" example:"admin"`
 	Password string `json:"password" example:"admin"`
@@ -12,3 +18,53 @@ type RegisterRequest struct {
 	Username string `json:"username" example:"admin"`
 	Password string `json:"password" validate:"required" example:"admin"`
 }
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type UnlockRequest struct {
+	Keys []string `json:"keys" validate:"required,min=1" example:"staff:admin,ip:203.0.113.7"`
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required" example:"123456"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+type SecuritySettings struct {
+	Require2FA bool `json:"require_2fa"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string    `json:"name" validate:"required,max=100" example:"ERP sync"`
+	Permissions []string  `json:"permissions" validate:"required,min=1,dive,required" example:"catalog.manage,orders.manage"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID            int64              `json:"id"`
+	Name          string             `json:"name"`
+	Prefix        string             `json:"prefix"`
+	Permissions   []string           `json:"permissions"`
+	CreatedBy     pgtype.UUID        `json:"created_by"`
+	CreatedByName string             `json:"created_by_name"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt    pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt     pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type SessionResponse struct {
+	ID         int64              `json:"id"`
+	UserAgent  string             `json:"user_agent"`
+	Ip         string             `json:"ip"`
+	Current    bool               `json:"current"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
@@ -1,22 +1,26 @@
 package auth
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	auth_db "app/internal/db/auth"
+	"app/internal/loginlimit"
+	"app/internal/token"
 	"context"
-	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // LoginHandler godoc
 // @Summary      User login
-// @Description  Authenticates a user using email or username and password.
+// @Description  Authenticates a user using email or username and password. Returns a short-lived access token and a refresh token, or a challenge token for /auth/login/2fa when the user has two-factor authentication enabled. mfa_setup_required means the account must enroll in two-factor authentication before its permissions apply.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -24,16 +28,28 @@ import (
 // @Success      200  {object}  map[string]interface{}  "Login successful"
 // @Failure      400  {object}  map[string]string  "Invalid request"
 // @Failure      401  {object}  map[string]string  "Invalid credentials"
+// @Failure      429  {object}  map[string]string  "Too many failed attempts"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /auth/login [post]
 func LoginHandler(c *fiber.Ctx) error {
 	var req LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid request body",
 		})
 	}
 	ctx := context.Background()
+
+	limitKey := loginlimit.StaffKey(req.Identify)
+	if err := loginlimit.Attempt(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
+	invalidCredentials := func() error {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid credentials",
+		})
+	}
+
 	params := auth_db.GetUserByIdentifyParams{
 		Username: pgtype.Text{String: req.Identify, Valid: true},
 		Email:    pgtype.Text{String: req.Identify, Valid: true},
@@ -42,10 +58,8 @@ func LoginHandler(c *fiber.Ctx) error {
 
 	user, err := db.AuthQueries.GetUserByIdentify(ctx, params)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "User not found",
-			})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invalidCredentials()
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -53,34 +67,213 @@ func LoginHandler(c *fiber.Ctx) error {
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Invalid credentials",
+		return invalidCredentials()
+	}
+	if err := loginlimit.Succeed(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
+
+	if user.TotpEnabled {
+		challenge, err := token.SignWithTTL(token.AudienceMFA, jwt.MapClaims{
+			"sub": user.ID.String(),
+		}, challengeTTL)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"mfa_required":    true,
+			"challenge_token": challenge,
+			"expires_in":      int(challengeTTL.Seconds()),
+		})
+	}
+
+	response, err := signIn(ctx, c, user.ID, user.Name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// RefreshHandler godoc
+// @Summary      Refresh access token
+// @Description  Exchanges a refresh token for a new access token in the same session. The refresh token is rotated: the one sent is revoked and a new one is returned.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      RefreshRequest  true  "Refresh request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/refresh [post]
+func RefreshHandler(c *fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
 		})
 	}
 
-	t := jwt.New(jwt.SigningMethodHS256)
-	jwtToken, err := t.SignedString([]byte("jwt"))
+	ctx := context.Background()
+	revoked, err := db.AuthQueries.RevokeRefreshToken(ctx, token.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "invalid or expired refresh token",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
+	err = db.AuthQueries.ExtendSession(ctx, auth_db.ExtendSessionParams{
+		ID:        revoked.SessionID,
+		Ip:        c.IP(),
+		ExpiresAt: pgtype.Timestamptz{Time: token.RefreshExpiry(), Valid: true},
+	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	jwtToken, refreshToken, _, err := issueTokens(ctx, revoked.UserID, revoked.SessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	setAccessCookie(c, jwtToken)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"token":         jwtToken,
+		"refresh_token": refreshToken,
+		"expires_in":    int(config.AccessTokenTTL.Seconds()),
+	})
+}
+
+// LogoutHandler godoc
+// @Summary      User logout
+// @Description  Ends the current session: its access and refresh tokens stop working and the access token cookie is cleared
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/logout [post]
+func LogoutHandler(c *fiber.Ctx) error {
+	_, err := db.AuthQueries.RevokeSession(context.Background(), auth_db.RevokeSessionParams{
+		ID:     currentSessionID(c),
+		UserID: currentUserID(c),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.ClearCookie("access_token")
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func issueTokens(ctx context.Context, userID pgtype.UUID, sessionID int64) (string, string, bool, error) {
+	permissions, err := db.AuthQueries.GetPermissionsByUser(ctx, userID)
+	if err != nil {
+		return "", "", false, err
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	// Until a user required to use two-factor authentication has enrolled,
+	// the token only reaches the enrollment routes.
+	setupRequired, err := twoFactorSetupRequired(ctx, userID)
+	if err != nil {
+		return "", "", false, err
+	}
+	claims := token.WithSession(jwt.MapClaims{
+		"sub":         userID.String(),
+		"permissions": permissions,
+	}, sessionID)
+	if setupRequired {
+		claims["permissions"] = []string{}
+		claims["mfa_setup_required"] = true
+	}
+
+	jwtToken, err := token.Sign(token.AudienceStaff, claims)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	refreshToken, refreshHash, err := token.NewRefreshToken()
+	if err != nil {
+		return "", "", false, err
+	}
+	err = db.AuthQueries.CreateRefreshToken(ctx, auth_db.CreateRefreshTokenParams{
+		UserID:    userID,
+		SessionID: sessionID,
+		TokenHash: refreshHash,
+		ExpiresAt: pgtype.Timestamptz{Time: token.RefreshExpiry(), Valid: true},
+	})
+	if err != nil {
+		return "", "", false, err
+	}
+	return jwtToken, refreshToken, setupRequired, nil
+}
+
+// signIn starts a session for a user who passed every required factor and
+// returns the login response.
+func signIn(ctx context.Context, c *fiber.Ctx, userID pgtype.UUID, name string) (fiber.Map, error) {
+	sessionID, err := db.AuthQueries.CreateSession(ctx, auth_db.CreateSessionParams{
+		UserID:    userID,
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		Ip:        c.IP(),
+		ExpiresAt: pgtype.Timestamptz{Time: token.RefreshExpiry(), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jwtToken, refreshToken, setupRequired, err := issueTokens(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	setAccessCookie(c, jwtToken)
+
+	return fiber.Map{
+		"user": fiber.Map{
+			"name": name,
+		},
+		"token":              jwtToken,
+		"refresh_token":      refreshToken,
+		"expires_in":         int(config.AccessTokenTTL.Seconds()),
+		"mfa_setup_required": setupRequired,
+	}, nil
+}
+
+func setAccessCookie(c *fiber.Ctx, jwtToken string) {
 	c.Cookie(&fiber.Cookie{
 		Name:     "access_token",
 		Value:    jwtToken,
 		HTTPOnly: true,
 		Secure:   true,
 		SameSite: "Strict",
-		Expires:  time.Now().Add(24 * time.Hour),
-	})
-
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"user": fiber.Map{
-			"name": user.Name,
-		},
-		"token": jwtToken,
+		Expires:  time.Now().Add(config.AccessTokenTTL),
 	})
 }
 
@@ -88,6 +281,7 @@ func LoginHandler(c *fiber.Ctx) error {
 // @Summary      User register
 // @Description  Register a new user with username, email, and password
 // @Tags         auth
+// @Security BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        payload body RegisterRequest true "Register data"
@@ -135,3 +329,62 @@ func RegisterHandler(c *fiber.Ctx) error {
 		"suceess": "Register success",
 	})
 }
+
+// GetLockoutsHandler godoc
+// @Summary      Get locked logins
+// @Description  Returns the staff accounts, customer phones and IP addresses locked after repeated failed logins. Keys are "staff:<identify>", "customer:<phone>" or "ip:<address>".
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {array}   loginlimit.Entry
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/lockouts [get]
+func GetLockoutsHandler(c *fiber.Ctx) error {
+	entries, err := loginlimit.Locked(context.Background())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if entries == nil {
+		entries = []loginlimit.Entry{}
+	}
+	return c.JSON(entries)
+}
+
+// UnlockHandler godoc
+// @Summary      Unlock logins
+// @Description  Clears the lock and failure count of the given keys
+// @Tags         auth
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      UnlockRequest  true  "Keys to unlock"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/lockouts [delete]
+func UnlockHandler(c *fiber.Ctx) error {
+	var req UnlockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := loginlimit.Unlock(context.Background(), req.Keys...); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "unlocked",
+	})
+}
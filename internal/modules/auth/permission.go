@@ -0,0 +1,14 @@
+package auth
+
+// Permissions granted to staff roles. They match the rows seeded in the
+// permissions table of the auth DB.
+const (
+	PermCatalog   = "catalog.manage"
+	PermOrders    = "orders.manage"
+	PermCustomers = "customers.manage"
+	PermContent   = "content.manage"
+	PermMarketing = "marketing.manage"
+	PermReports   = "reports.view"
+	PermUsers     = "users.manage"
+	PermAudit     = "audit.view"
+)
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"app/internal/token"
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GetSessionsHandler godoc
+// @Summary      Get sessions
+// @Description  Returns the active sessions of the current user, most recently used first. current marks the session of the request.
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/sessions [get]
+func GetSessionsHandler(c *fiber.Ctx) error {
+	currentID := currentSessionID(c)
+
+	rows, err := db.AuthQueries.GetSessionsByUser(context.Background(), currentUserID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	data := make([]SessionResponse, len(rows))
+	for i, row := range rows {
+		data[i] = SessionResponse{
+			ID:         row.ID,
+			UserAgent:  row.UserAgent,
+			Ip:         row.Ip,
+			Current:    row.ID == currentID,
+			LastSeenAt: row.LastSeenAt,
+			ExpiresAt:  row.ExpiresAt,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": data,
+	})
+}
+
+// RevokeSessionHandler godoc
+// @Summary      Revoke session
+// @Description  Signs the current user out of one of their sessions
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Session ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/sessions/{id} [delete]
+func RevokeSessionHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	n, err := db.AuthQueries.RevokeSession(context.Background(), auth_db.RevokeSessionParams{
+		ID:     id,
+		UserID: currentUserID(c),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "session not found",
+		})
+	}
+	if id == currentSessionID(c) {
+		c.ClearCookie("access_token")
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "session revoked",
+	})
+}
+
+// RevokeOtherSessionsHandler godoc
+// @Summary      Revoke other sessions
+// @Description  Signs the current user out everywhere except the session of the request. Use /auth/logout to end that one too.
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/sessions [delete]
+func RevokeOtherSessionsHandler(c *fiber.Ctx) error {
+	n, err := db.AuthQueries.RevokeOtherSessions(context.Background(), auth_db.RevokeOtherSessionsParams{
+		UserID: currentUserID(c),
+		KeepID: currentSessionID(c),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "sessions revoked",
+		"count":   n,
+	})
+}
+
+func currentSessionID(c *fiber.Ctx) int64 {
+	claims := c.Locals("user").(*jwt.Token).Claims.(jwt.MapClaims)
+	sessionID, _ := token.SessionID(claims)
+	return sessionID
+}
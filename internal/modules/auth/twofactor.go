@@ -0,0 +1,574 @@
+package auth
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"app/internal/loginlimit"
+	"app/internal/token"
+	"app/internal/totp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	challengeTTL      = 5 * time.Minute
+	recoveryCodeCount = 10
+	require2FASetting = "require_2fa"
+)
+
+// LoginTwoFactorHandler godoc
+// @Summary      Complete login with a second factor
+// @Description  Exchanges the challenge token from /auth/login and an authenticator code or a recovery code for the access and refresh tokens
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      LoginTwoFactorRequest  true  "Second factor"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      429  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/login/2fa [post]
+func LoginTwoFactorHandler(c *fiber.Ctx) error {
+	var req LoginTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	challenge, err := token.Parse(req.ChallengeToken)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid or expired challenge",
+		})
+	}
+	claims, _ := challenge.Claims.(jwt.MapClaims)
+	sub, _ := claims["sub"].(string)
+	var userID pgtype.UUID
+	if !token.HasAudience(claims, token.AudienceMFA) || userID.Scan(sub) != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid or expired challenge",
+		})
+	}
+
+	ctx := context.Background()
+	user, ok, err := checkSecondFactor(ctx, c, userID, req.Code)
+	if err != nil {
+		return loginlimit.Reject(c, err)
+	}
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid code",
+		})
+	}
+
+	response, err := signIn(ctx, c, userID, user.Name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetTwoFactorHandler godoc
+// @Summary      Get two-factor status
+// @Description  Returns whether the current user has two-factor authentication enabled, whether it is required and how many recovery codes are left
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/2fa [get]
+func GetTwoFactorHandler(c *fiber.Ctx) error {
+	userID := currentUserID(c)
+	ctx := context.Background()
+
+	user, err := db.AuthQueries.GetUserTotp(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	required, err := twoFactorRequired(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	left, err := db.AuthQueries.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"enabled":             user.TotpEnabled,
+		"required":            required,
+		"recovery_codes_left": left,
+	})
+}
+
+// SetupTwoFactorHandler godoc
+// @Summary      Start two-factor enrollment
+// @Description  Creates a new TOTP secret for the current user and returns it with the otpauth:// URI to show as a QR code. Nothing changes until /auth/2fa/enable confirms a code.
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/2fa/setup [post]
+func SetupTwoFactorHandler(c *fiber.Ctx) error {
+	userID := currentUserID(c)
+	ctx := context.Background()
+
+	user, err := db.AuthQueries.GetUserTotp(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if user.TotpEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "two-factor authentication is already enabled",
+		})
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	err = db.AuthQueries.SetUserTotpSecret(ctx, auth_db.SetUserTotpSecretParams{
+		ID:         userID,
+		TotpSecret: pgtype.Text{String: secret, Valid: true},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	account := user.Name
+	if user.Email.Valid {
+		account = user.Email.String
+	} else if user.Username.Valid {
+		account = user.Username.String
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"secret": secret,
+		"uri":    totp.URI(config.TOTPIssuer, account, secret),
+	})
+}
+
+// EnableTwoFactorHandler godoc
+// @Summary      Enable two-factor authentication
+// @Description  Confirms enrollment with a code from the authenticator app. Returns the recovery codes, shown only once, and new tokens.
+// @Tags         auth
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      TwoFactorCodeRequest  true  "Authenticator code"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      429  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/2fa/enable [post]
+func EnableTwoFactorHandler(c *fiber.Ctx) error {
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	userID := currentUserID(c)
+	ctx := context.Background()
+
+	user, err := db.AuthQueries.GetUserTotp(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if user.TotpEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "two-factor authentication is already enabled",
+		})
+	}
+	if !user.TotpSecret.Valid {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "call /auth/2fa/setup first",
+		})
+	}
+
+	ok, err := checkCode(ctx, c, user, req.Code, false)
+	if err != nil {
+		return loginlimit.Reject(c, err)
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid code",
+		})
+	}
+
+	if err := db.AuthQueries.EnableUserTotp(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	codes, err := replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// The current session gets a token with the permissions that were
+	// withheld until enrollment.
+	jwtToken, refreshToken, _, err := issueTokens(ctx, userID, currentSessionID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	setAccessCookie(c, jwtToken)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"token":          jwtToken,
+		"refresh_token":  refreshToken,
+		"expires_in":     int(config.AccessTokenTTL.Seconds()),
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactorHandler godoc
+// @Summary      Disable two-factor authentication
+// @Description  Turns two-factor authentication off for the current user after checking an authenticator or recovery code. Not allowed while it is required for all staff.
+// @Tags         auth
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      TwoFactorCodeRequest  true  "Authenticator or recovery code"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      429  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/2fa/disable [post]
+func DisableTwoFactorHandler(c *fiber.Ctx) error {
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	required, err := twoFactorRequired(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if required {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "two-factor authentication is required for staff accounts",
+		})
+	}
+
+	userID := currentUserID(c)
+	_, ok, err := checkSecondFactor(ctx, c, userID, req.Code)
+	if err != nil {
+		return loginlimit.Reject(c, err)
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid code",
+		})
+	}
+
+	if err := db.AuthQueries.DisableUserTotp(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := db.AuthQueries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "two-factor authentication disabled",
+	})
+}
+
+// RegenerateRecoveryCodesHandler godoc
+// @Summary      Regenerate recovery codes
+// @Description  Replaces the recovery codes of the current user after checking an authenticator code. The old codes stop working.
+// @Tags         auth
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      TwoFactorCodeRequest  true  "Authenticator code"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      429  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/2fa/recovery-codes [post]
+func RegenerateRecoveryCodesHandler(c *fiber.Ctx) error {
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	userID := currentUserID(c)
+	ctx := context.Background()
+
+	user, err := db.AuthQueries.GetUserTotp(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if !user.TotpEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "two-factor authentication is not enabled",
+		})
+	}
+
+	ok, err := checkCode(ctx, c, user, req.Code, false)
+	if err != nil {
+		return loginlimit.Reject(c, err)
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid code",
+		})
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"recovery_codes": codes,
+	})
+}
+
+// GetSecuritySettingsHandler godoc
+// @Summary      Get security settings
+// @Description  Returns the staff security settings
+// @Tags         auth
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  SecuritySettings
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/settings [get]
+func GetSecuritySettingsHandler(c *fiber.Ctx) error {
+	required, err := twoFactorRequired(context.Background())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(SecuritySettings{Require2FA: required})
+}
+
+// UpdateSecuritySettingsHandler godoc
+// @Summary      Update security settings
+// @Description  With require_2fa on, staff without two-factor authentication must enroll at their next login or token refresh before their permissions apply
+// @Tags         auth
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      SecuritySettings  true  "Settings"
+// @Success      200  {object}  SecuritySettings
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/settings [put]
+func UpdateSecuritySettingsHandler(c *fiber.Ctx) error {
+	var req SecuritySettings
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	err := db.AuthQueries.UpsertSetting(context.Background(), auth_db.UpsertSettingParams{
+		Key:   require2FASetting,
+		Value: strconv.FormatBool(req.Require2FA),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(req)
+}
+
+func currentUserID(c *fiber.Ctx) pgtype.UUID {
+	var userID pgtype.UUID
+	claims := c.Locals("user").(*jwt.Token).Claims.(jwt.MapClaims)
+	sub, _ := claims["sub"].(string)
+	_ = userID.Scan(sub)
+	return userID
+}
+
+func twoFactorRequired(ctx context.Context) (bool, error) {
+	value, err := db.AuthQueries.GetSetting(ctx, require2FASetting)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return value == "true", nil
+}
+
+func twoFactorSetupRequired(ctx context.Context, userID pgtype.UUID) (bool, error) {
+	user, err := db.AuthQueries.GetUserTotp(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if user.TotpEnabled {
+		return false, nil
+	}
+	return twoFactorRequired(ctx)
+}
+
+// checkSecondFactor checks an authenticator or recovery code of a user with
+// two-factor authentication enabled.
+func checkSecondFactor(ctx context.Context, c *fiber.Ctx, userID pgtype.UUID, code string) (auth_db.GetUserTotpRow, bool, error) {
+	user, err := db.AuthQueries.GetUserTotp(ctx, userID)
+	if err != nil {
+		return user, false, err
+	}
+	if !user.TotpEnabled {
+		return user, false, nil
+	}
+	ok, err := checkCode(ctx, c, user, code, true)
+	return user, ok, err
+}
+
+// checkCode verifies an authenticator code, or a recovery code when
+// allowRecovery is set. Wrong codes count towards the login limits of the
+// user so the six digits cannot be brute-forced.
+func checkCode(ctx context.Context, c *fiber.Ctx, user auth_db.GetUserTotpRow, code string, allowRecovery bool) (bool, error) {
+	limitKey := "mfa:" + user.ID.String()
+	if err := loginlimit.Attempt(ctx, c.IP(), limitKey); err != nil {
+		return false, err
+	}
+
+	ok, err := matchCode(ctx, user, strings.TrimSpace(code), allowRecovery)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+	return true, loginlimit.Succeed(ctx, c.IP(), limitKey)
+}
+
+func matchCode(ctx context.Context, user auth_db.GetUserTotpRow, code string, allowRecovery bool) (bool, error) {
+	if step, ok := totp.Validate(user.TotpSecret.String, code, time.Now()); ok {
+		// A code is only good once, even within its 30 seconds.
+		n, err := db.AuthQueries.UseUserTotpStep(ctx, auth_db.UseUserTotpStepParams{
+			Step: step,
+			ID:   user.ID,
+		})
+		return n == 1, err
+	}
+	if !allowRecovery {
+		return false, nil
+	}
+	n, err := db.AuthQueries.UseRecoveryCode(ctx, auth_db.UseRecoveryCodeParams{
+		UserID:   user.ID,
+		CodeHash: hashRecoveryCode(code),
+	})
+	return n == 1, err
+}
+
+// replaceRecoveryCodes issues a new set of recovery codes, formatted as
+// xxxxx-xxxxx, and stores their hashes.
+func replaceRecoveryCodes(ctx context.Context, userID pgtype.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		s := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+		codes[i] = s[:5] + "-" + s[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	if err := db.AuthQueries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	err := db.AuthQueries.InsertRecoveryCodes(ctx, auth_db.InsertRecoveryCodesParams{
+		UserID:     userID,
+		CodeHashes: hashes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
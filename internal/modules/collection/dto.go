@@ -1,5 +1,11 @@
 package collection
 
+import (
+	product_db "app/internal/db/product"
+	"app/internal/modules/product"
+	"app/internal/storage"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -9,14 +15,64 @@ type PaginatedResponse[T any] struct {
 }
 
 type CollectionResponse struct {
-	ID              int64  `json:"id"`
-	Name            string `json:"name"`
-	Slug            string `json:"slug"`
-	File            string `json:"file"`
-	MetaTitle       string `json:"meta_title"`
-	MetaDescription string `json:"meta_description"`
-	Layout          string `json:"layout"`
-	Products        any    `json:"products"`
+	ID              int64               `json:"id"`
+	Name            string              `json:"name"`
+	Slug            string              `json:"slug"`
+	File            string              `json:"file"`
+	FileURL         string              `json:"file_url"`
+	FileRenditions  *storage.Renditions `json:"file_renditions"`
+	MetaTitle       string              `json:"meta_title"`
+	MetaDescription string              `json:"meta_description"`
+	Layout          string              `json:"layout"`
+	Products        any                 `json:"products"`
+}
+
+type CollectionItem struct {
+	product_db.GetCollectionsRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type HeroCollection struct {
+	product_db.GetCollectionsByLayoutRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type CollectionDetail struct {
+	product_db.GetCollectionsBySlugRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type CollectionProduct struct {
+	product_db.GetProductsByCollectionRow
+	Files          []string               `json:"files"`
+	FileURLs       []string               `json:"file_urls"`
+	FileRenditions []*storage.Renditions  `json:"file_renditions"`
+	Variants       []product.StoreVariant `json:"variants"`
+}
+
+// HomeProduct is a product as GetHomeCollectionsWithProductsAndVariants
+// aggregates it into the products column.
+type HomeProduct struct {
+	ID             int64                  `json:"id"`
+	Name           string                 `json:"name"`
+	Slug           string                 `json:"slug"`
+	SalePrice      int32                  `json:"sale_price"`
+	OriginPrice    int32                  `json:"origin_price"`
+	Files          []string               `json:"files"`
+	FileURLs       []string               `json:"file_urls"`
+	FileRenditions []*storage.Renditions  `json:"file_renditions"`
+	Options        any                    `json:"options"`
+	Variants       []product.StoreVariant `json:"variants"`
+}
+
+type HomeCollection struct {
+	product_db.GetHomeCollectionsWithProductsAndVariantsRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+	Products       []HomeProduct       `json:"products"`
 }
 
 type CreateCollectionRequest struct {
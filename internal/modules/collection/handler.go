@@ -1,15 +1,20 @@
 package collection
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/modules/redirect"
+	"app/internal/storage"
 	"context"
 	"database/sql"
+	"errors"
 	"math"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -21,7 +26,7 @@ import (
 // @Produce      json
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  PaginatedResponse[CollectionItem]
 // @Router       /collections [get]
 func GetCollectionsHandler(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -46,14 +51,32 @@ func GetCollectionsHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	var media storage.ImageSet
+	for _, r := range results {
+		media.Add(r.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]CollectionItem, len(results))
+	for i, r := range results {
+		data[i] = CollectionItem{
+			GetCollectionsRow: r,
+			FileURL:           config.FileURL(r.File.String),
+			FileRenditions:    media.Renditions(r.File.String),
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.Status(fiber.StatusOK).JSON(PaginatedResponse[product_db.GetCollectionsRow]{
+	return c.Status(fiber.StatusOK).JSON(PaginatedResponse[CollectionItem]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       results,
+		Data:       data,
 	})
 }
 
@@ -62,7 +85,7 @@ func GetCollectionsHandler(c *fiber.Ctx) error {
 // @Description  Returns a list of hero collections
 // @Tags         collections
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {array}   HeroCollection
 // @Router       /collections/hero [get]
 func GetHeroCollectionsHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
@@ -72,7 +95,25 @@ func GetHeroCollectionsHandler(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
-	return c.Status(fiber.StatusOK).JSON(results)
+
+	var media storage.ImageSet
+	for _, r := range results {
+		media.Add(r.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]HeroCollection, len(results))
+	for i, r := range results {
+		data[i] = HeroCollection{
+			GetCollectionsByLayoutRow: r,
+			FileURL:                   config.FileURL(r.File.String),
+			FileRenditions:            media.Renditions(r.File.String),
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(data)
 }
 
 // GetHomeCollectionsHandler godoc
@@ -80,7 +121,7 @@ func GetHeroCollectionsHandler(c *fiber.Ctx) error {
 // @Description  Returns a list of home collections
 // @Tags         collections
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {array}   HomeCollection
 // @Router       /collections/home [get]
 func GetHomeCollectionsHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
@@ -94,7 +135,42 @@ func GetHomeCollectionsHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(results)
+	var media storage.ImageSet
+	data := make([]HomeCollection, len(results))
+	for i, r := range results {
+		data[i].GetHomeCollectionsWithProductsAndVariantsRow = r
+		if err := storage.DecodeJSON(r.Products, &data[i].Products); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		media.Add(r.File.String)
+		for _, p := range data[i].Products {
+			media.Add(p.Files...)
+			for _, v := range p.Variants {
+				media.Add(v.File)
+			}
+		}
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for i := range data {
+		data[i].FileURL = config.FileURL(data[i].File.String)
+		data[i].FileRenditions = media.Renditions(data[i].File.String)
+		for j := range data[i].Products {
+			p := &data[i].Products[j]
+			p.FileURLs = storage.URLs(p.Files)
+			p.FileRenditions = media.RenditionsOf(p.Files)
+			for k := range p.Variants {
+				p.Variants[k].SetFile(&media)
+			}
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(data)
 }
 
 // GetCollectionBySlugHandler godoc
@@ -103,7 +179,8 @@ func GetHomeCollectionsHandler(c *fiber.Ctx) error {
 // @Tags         collections
 // @Produce      json
 // @Param        slug   path      string  true  "Collection slug"
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  CollectionDetail
+// @Success      301  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -113,11 +190,26 @@ func GetCollectionBySlugHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetCollectionsBySlug(ctx, param)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityCollection, param)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var media storage.ImageSet
+	media.Add(result.File.String)
+	if err := media.Load(ctx); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-	return c.Status(fiber.StatusOK).JSON(result)
+	return c.Status(fiber.StatusOK).JSON(CollectionDetail{
+		GetCollectionsBySlugRow: result,
+		FileURL:                 config.FileURL(result.File.String),
+		FileRenditions:          media.Renditions(result.File.String),
+	})
 }
 
 // GetProductsHandler godoc
@@ -126,7 +218,7 @@ func GetCollectionBySlugHandler(c *fiber.Ctx) error {
 // @Tags         collections
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {array}   CollectionProduct
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -141,7 +233,44 @@ func GetProductsHandler(c *fiber.Ctx) error {
 	}
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetProductsByCollection(ctx, id)
-	return c.Status(fiber.StatusOK).JSON(result)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var media storage.ImageSet
+	data := make([]CollectionProduct, len(result))
+	for i, r := range result {
+		data[i].GetProductsByCollectionRow = r
+		if err := storage.DecodeJSON(r.Files, &data[i].Files); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err := storage.DecodeJSON(r.Variants, &data[i].Variants); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		media.Add(data[i].Files...)
+		for _, v := range data[i].Variants {
+			media.Add(v.File)
+		}
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for i := range data {
+		data[i].FileURLs = storage.URLs(data[i].Files)
+		data[i].FileRenditions = media.RenditionsOf(data[i].Files)
+		for j := range data[i].Variants {
+			data[i].Variants[j].SetFile(&media)
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(data)
 }
 
 // GetCollectionHandler godoc
@@ -151,7 +280,7 @@ func GetProductsHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  CollectionResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -179,9 +308,19 @@ func GetCollectionHandler(c *fiber.Ctx) error {
 
 	products, err := db.ProductQueries.GetProductsByCollectionID(ctx, result.ID)
 
+	var media storage.ImageSet
+	media.Add(result.File.String)
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(CollectionResponse{
 		ID:              result.ID,
 		File:            result.File.String,
+		FileURL:         config.FileURL(result.File.String),
+		FileRenditions:  media.Renditions(result.File.String),
 		Name:            result.Name,
 		Slug:            result.Slug,
 		Layout:          result.Layout.String,
@@ -286,6 +425,18 @@ func UpdateCollectionHandler(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
+	current, err := db.ProductQueries.GetCollection(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	params := product_db.UpdateCollectionParams{
 		ID:   id,
 		Name: req.Name,
@@ -301,7 +452,29 @@ func UpdateCollectionHandler(c *fiber.Ctx) error {
 			Valid:  true,
 		},
 	}
-	if err := db.ProductQueries.UpdateCollection(ctx, params); err != nil {
+
+	tx, err := db.ProductDBPool.Begin(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	defer tx.Rollback(ctx)
+	qtx := db.ProductQueries.WithTx(tx)
+
+	if err := qtx.UpdateCollection(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := redirect.RecordSlugChange(ctx, qtx, redirect.EntityCollection, current.Slug, req.Slug); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
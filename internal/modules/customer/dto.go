@@ -1,5 +1,7 @@
 package customer
 
+import "github.com/jackc/pgx/v5/pgtype"
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -30,7 +32,45 @@ type UpdateMeRequest struct {
 	Password string `json:"password"`
 }
 
+type SendPhoneOtpRequest struct {
+	Phone string `json:"phone" validate:"required" example:"0984807356"`
+}
+
+// VerifyPhoneRequest takes the code as otp. zns_otp, the name it had before
+// codes could go out over other channels, is still accepted.
 type VerifyPhoneRequest struct {
-	Phone  string `json:"phone"`
-	ZnsOtp string `json:"zns_otp"`
+	Phone  string `json:"phone" validate:"required" example:"0984807356"`
+	Otp    string `json:"otp" validate:"required_without=ZnsOtp" example:"123456"`
+	ZnsOtp string `json:"zns_otp" validate:"required_without=Otp"`
+}
+
+func (r VerifyPhoneRequest) code() string {
+	if r.Otp != "" {
+		return r.Otp
+	}
+	return r.ZnsOtp
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Identify string `json:"identify" validate:"required" example:"0984807356"`
+}
+
+type ResetPasswordRequest struct {
+	Identify string `json:"identify" validate:"required" example:"0984807356"`
+	Otp      string `json:"otp" validate:"required" example:"123456"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+type SessionResponse struct {
+	ID         int64              `json:"id"`
+	UserAgent  string             `json:"user_agent"`
+	Ip         string             `json:"ip"`
+	Current    bool               `json:"current"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
@@ -1,21 +1,22 @@
 package customer
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
-	"bytes"
+	"app/internal/loginlimit"
+	"app/internal/otp"
+	"app/internal/token"
 	"context"
-	"crypto/rand"
-	"database/sql"
-	"encoding/json"
+	"errors"
+	"log"
 	"math"
-	"math/big"
-	"net/http"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -63,6 +64,50 @@ func GetCustomersHandler(c *fiber.Ctx) error {
 	})
 }
 
+// GetOtpDeliveriesHandler godoc
+// @Summary      Get OTP deliveries
+// @Description  Returns the delivery log of one-time codes, newest first. Codes themselves are never stored.
+// @Tags         customers
+// @Security BearerAuth
+// @Produce      json
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/otp-deliveries [get]
+func GetOtpDeliveriesHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetOtpDeliveries(ctx, product_db.GetOtpDeliveriesParams{
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountOtpDeliveries(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetOtpDeliveriesRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
 // GetMeHandler godoc
 // @Summary      Get a customer
 // @Description  Returns a customer
@@ -144,7 +189,7 @@ func UpdateMeHandler(c *fiber.Ctx) error {
 
 // CreateCustomerHandler godoc
 // @Summary      Create a new customer
-// @Description  Creates a new customer and returns the created customer
+// @Description  Creates a new customer and returns its id. No verification code is sent; the customer can ask for one through /customers/verify-phone/send.
 // @Tags         customers
 // @Accept       json
 // @Produce      json
@@ -229,44 +274,9 @@ func BulkDeleteCustomersHandler(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusOK)
 }
 
-func GenerateOTP(length int) (string, error) {
-	otp := ""
-	for range length {
-		n, err := rand.Int(rand.Reader, big.NewInt(10))
-		if err != nil {
-			return "", err
-		}
-		otp += n.String()
-	}
-	return otp, nil
-}
-
-func SendZnsOtp(otp string) error {
-	data := map[string]interface{}{
-		"otp": otp,
-	}
-	jsonData, _ := json.Marshal(data)
-	req, err := http.NewRequest(
-		"POST",
-		"https://api.example.com/users",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{}
-	_, err = client.Do(req)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 // RegisterCustomerHandler godoc
 // @Summary      Create a new customer
-// @Description  Creates a new customer and returns the created customer
+// @Description  Creates a new customer and sends a code to verify the phone number
 // @Tags         customers
 // @Accept       json
 // @Produce      json
@@ -300,16 +310,10 @@ func RegisterCustomerHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	znsOtp, err := GenerateOTP(6)
-
 	customerParams := product_db.CreateCustomerParams{
 		Name:     req.Name,
 		Phone:    req.Phone,
 		Password: string(passwordHash),
-		ZnsOtp: pgtype.Text{
-			String: znsOtp,
-			Valid:  true,
-		},
 	}
 
 	customerID, err := db.ProductQueries.CreateCustomer(ctx, customerParams)
@@ -319,14 +323,23 @@ func RegisterCustomerHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	// The account exists either way; a failed delivery is retried through
+	// /customers/verify-phone/send.
+	otpSent := true
+	if err := otp.Send(ctx, customerID, otp.PurposeVerifyPhone, otp.ChannelPhone, req.Phone); err != nil {
+		log.Printf("customer %d: send verification code: %v", customerID, err)
+		otpSent = false
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"id": customerID,
+		"id":       customerID,
+		"otp_sent": otpSent,
 	})
 }
 
 // CustomerLoginHandler godoc
 // @Summary      Customer login
-// @Description  Authenticates a customer using phone and password.
+// @Description  Authenticates a customer using phone and password and starts a session. Returns a short-lived access token and a refresh token.
 // @Tags         customers
 // @Accept       json
 // @Produce      json
@@ -334,40 +347,55 @@ func RegisterCustomerHandler(c *fiber.Ctx) error {
 // @Success      200  {object}  map[string]interface{}  "Login successful"
 // @Failure      400  {object}  map[string]string  "Invalid request"
 // @Failure      401  {object}  map[string]string  "Invalid credentials"
+// @Failure      429  {object}  map[string]string  "Too many failed attempts"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /customers/login [post]
 func CustomerLoginHandler(c *fiber.Ctx) error {
 	var req CustomerLoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid request body",
 		})
 	}
 	ctx := context.Background()
 
+	limitKey := loginlimit.CustomerKey(req.Phone)
+	if err := loginlimit.Attempt(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
+	invalidCredentials := func() error {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid credentials",
+		})
+	}
+
 	user, err := db.ProductQueries.GetCustomerByPhone(ctx, req.Phone)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "User not found",
-			})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invalidCredentials()
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Invalid credentials",
-		})
+		return invalidCredentials()
 	}
-	claims := jwt.MapClaims{
-		"id": user.ID,
+	if err := loginlimit.Succeed(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
 	}
-
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	jwtToken, err := t.SignedString([]byte("jwt"))
-
+	sessionID, err := db.ProductQueries.CreateCustomerSession(ctx, product_db.CreateCustomerSessionParams{
+		CustomerID: user.ID,
+		UserAgent:  c.Get(fiber.HeaderUserAgent),
+		Ip:         c.IP(),
+		ExpiresAt:  pgtype.Timestamptz{Time: token.RefreshExpiry(), Valid: true},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	jwtToken, refreshToken, err := issueTokens(ctx, user.ID, sessionID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -379,38 +407,427 @@ func CustomerLoginHandler(c *fiber.Ctx) error {
 			"id":   user.ID,
 			"name": user.Name,
 		},
-		"token": jwtToken,
+		"token":         jwtToken,
+		"refresh_token": refreshToken,
+		"expires_in":    int(config.AccessTokenTTL.Seconds()),
 	})
 }
 
+// CustomerRefreshHandler godoc
+// @Summary      Refresh customer access token
+// @Description  Exchanges a refresh token for a new access token in the same session. The refresh token is rotated: the one sent is revoked and a new one is returned.
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      RefreshRequest  true  "Refresh request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/refresh [post]
+func CustomerRefreshHandler(c *fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	revoked, err := db.ProductQueries.RevokeCustomerRefreshToken(ctx, token.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "invalid or expired refresh token",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	err = db.ProductQueries.ExtendCustomerSession(ctx, product_db.ExtendCustomerSessionParams{
+		ID:        revoked.SessionID,
+		Ip:        c.IP(),
+		ExpiresAt: pgtype.Timestamptz{Time: token.RefreshExpiry(), Valid: true},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	jwtToken, refreshToken, err := issueTokens(ctx, revoked.CustomerID, revoked.SessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"token":         jwtToken,
+		"refresh_token": refreshToken,
+		"expires_in":    int(config.AccessTokenTTL.Seconds()),
+	})
+}
+
+// CustomerLogoutHandler godoc
+// @Summary      Customer logout
+// @Description  Ends the current session: its access and refresh tokens stop working
+// @Tags         customers
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/logout [post]
+func CustomerLogoutHandler(c *fiber.Ctx) error {
+	customerID, sessionID := currentSession(c)
+	_, err := db.ProductQueries.RevokeCustomerSession(context.Background(), product_db.RevokeCustomerSessionParams{
+		ID:         sessionID,
+		CustomerID: customerID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func issueTokens(ctx context.Context, customerID, sessionID int64) (string, string, error) {
+	jwtToken, err := token.Sign(token.AudienceCustomer, token.WithSession(jwt.MapClaims{
+		"id": customerID,
+	}, sessionID))
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, refreshHash, err := token.NewRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	err = db.ProductQueries.CreateCustomerRefreshToken(ctx, product_db.CreateCustomerRefreshTokenParams{
+		CustomerID: customerID,
+		SessionID:  sessionID,
+		TokenHash:  refreshHash,
+		ExpiresAt:  pgtype.Timestamptz{Time: token.RefreshExpiry(), Valid: true},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return jwtToken, refreshToken, nil
+}
+
+// ForgotPasswordHandler godoc
+// @Summary      Request a password reset
+// @Description  Sends a one-time code to the phone or email the customer is identified by. The response is the same whether or not the account exists.
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      ForgotPasswordRequest  true  "Forgot password request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/forgot-password [post]
+func ForgotPasswordHandler(c *fiber.Ctx) error {
+	var req ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	response := fiber.Map{
+		"message": "if the account exists, a reset code has been sent",
+	}
+
+	ctx := context.Background()
+	customer, err := db.ProductQueries.GetCustomerByIdentify(ctx, req.Identify)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusOK).JSON(response)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// The code goes to the email only when the customer identified with it.
+	channel, recipient := otp.ChannelPhone, customer.Phone
+	if customer.Email.Valid && customer.Email.String == req.Identify {
+		channel, recipient = otp.ChannelEmail, customer.Email.String
+	}
+
+	// Hitting the resend limit is answered like a success so the response
+	// does not tell whether the account exists.
+	err = otp.Send(ctx, customer.ID, otp.PurposeResetPassword, channel, recipient)
+	var limitErr *otp.LimitError
+	if err != nil && !errors.As(err, &limitErr) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// ResetPasswordHandler godoc
+// @Summary      Reset password
+// @Description  Sets a new password using the code sent by /customers/forgot-password. A code expires after OTP_TTL or OTP_MAX_ATTEMPTS wrong attempts. All sessions of the customer are signed out.
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      ResetPasswordRequest  true  "Reset password request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      429  {object}  map[string]string  "Too many failed attempts"
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/reset-password [post]
+func ResetPasswordHandler(c *fiber.Ctx) error {
+	var req ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	invalidCode := fiber.Map{
+		"error": "invalid or expired code",
+	}
+
+	ctx := context.Background()
+	limitKey := loginlimit.OTPKey(req.Identify)
+	if err := loginlimit.Attempt(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
+
+	customer, err := db.ProductQueries.GetCustomerByIdentify(ctx, req.Identify)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Answer an unknown account like a wrong code.
+			otp.Discard(req.Otp)
+			return c.Status(fiber.StatusBadRequest).JSON(invalidCode)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 12)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	tx, err := db.ProductDBPool.Begin(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	defer tx.Rollback(ctx)
+	qtx := db.ProductQueries.WithTx(tx)
+
+	if err := otp.Verify(ctx, qtx, customer.ID, otp.PurposeResetPassword, req.Otp); err != nil {
+		if errors.Is(err, otp.ErrInvalidCode) {
+			return c.Status(fiber.StatusBadRequest).JSON(invalidCode)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	err = qtx.UpdateCustomerPassword(ctx, product_db.UpdateCustomerPasswordParams{
+		ID:       customer.ID,
+		Password: string(passwordHash),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := qtx.RevokeCustomerSessionsByCustomer(ctx, customer.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := loginlimit.Succeed(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "password updated",
+	})
+}
+
+// SendPhoneOtpHandler godoc
+// @Summary      Send phone verification code
+// @Description  Sends a new code to verify the phone number of an unverified customer
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      SendPhoneOtpRequest  true  "Send phone code request"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      429  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/verify-phone/send [post]
+func SendPhoneOtpHandler(c *fiber.Ctx) error {
+	var req SendPhoneOtpRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	customer, err := db.ProductQueries.GetCustomerByPhone(ctx, req.Phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "customer not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if customer.PhoneVerified.Bool {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "phone already verified",
+		})
+	}
+
+	if err := otp.Send(ctx, customer.ID, otp.PurposeVerifyPhone, otp.ChannelPhone, customer.Phone); err != nil {
+		var limitErr *otp.LimitError
+		if errors.As(err, &limitErr) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
 // VerifyPhoneHandler godoc
 // @Summary      Customer verify phone
-// @Description  Verify phone
+// @Description  Verifies the phone number with the code sent at registration or by /customers/verify-phone/send, given as otp (zns_otp is still accepted)
 // @Tags         customers
 // @Accept       json
 // @Produce      json
 // @Param        payload  body      VerifyPhoneRequest  true  "Verify phone request"
-// @Success      200  {object}  map[string]interface{}  "Login successful"
-// @Failure      400  {object}  map[string]string  "Invalid request"
-// @Failure      401  {object}  map[string]string  "Invalid credentials"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string  "Invalid request or code"
+// @Failure      429  {object}  map[string]string  "Too many failed attempts"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /customers/verify-phone [post]
 func VerifyPhoneHandler(c *fiber.Ctx) error {
 	var req VerifyPhoneRequest
 	if err := c.BodyParser(&req); err != nil {
-		c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid request body",
 		})
 	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	invalidCode := fiber.Map{
+		"error": "invalid or expired code",
+	}
+
 	ctx := context.Background()
-	err := db.ProductQueries.VerifyPhone(ctx, product_db.VerifyPhoneParams{
-		Phone:  req.Phone,
-		ZnsOtp: pgtype.Text{String: req.ZnsOtp, Valid: true},
-	})
+	limitKey := loginlimit.OTPKey(req.Phone)
+	if err := loginlimit.Attempt(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
+
+	customer, err := db.ProductQueries.GetCustomerByPhone(ctx, req.Phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			otp.Discard(req.code())
+			return c.Status(fiber.StatusBadRequest).JSON(invalidCode)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	tx, err := db.ProductDBPool.Begin(ctx)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+	defer tx.Rollback(ctx)
+	qtx := db.ProductQueries.WithTx(tx)
+
+	if err := otp.Verify(ctx, qtx, customer.ID, otp.PurposeVerifyPhone, req.code()); err != nil {
+		if errors.Is(err, otp.ErrInvalidCode) {
+			return c.Status(fiber.StatusBadRequest).JSON(invalidCode)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := qtx.VerifyPhone(ctx, customer.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := loginlimit.Succeed(ctx, c.IP(), limitKey); err != nil {
+		return loginlimit.Reject(c, err)
+	}
 	return c.SendStatus(fiber.StatusOK)
 }
@@ -0,0 +1,122 @@
+package customer
+
+import (
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"app/internal/token"
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GetSessionsHandler godoc
+// @Summary      Get my sessions
+// @Description  Returns the active sessions of the current customer, most recently used first. current marks the session of the request.
+// @Tags         customers
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/sessions [get]
+func GetSessionsHandler(c *fiber.Ctx) error {
+	customerID, currentID := currentSession(c)
+
+	rows, err := db.ProductQueries.GetCustomerSessions(context.Background(), customerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	data := make([]SessionResponse, len(rows))
+	for i, row := range rows {
+		data[i] = SessionResponse{
+			ID:         row.ID,
+			UserAgent:  row.UserAgent,
+			Ip:         row.Ip,
+			Current:    row.ID == currentID,
+			LastSeenAt: row.LastSeenAt,
+			ExpiresAt:  row.ExpiresAt,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": data,
+	})
+}
+
+// RevokeSessionHandler godoc
+// @Summary      Revoke my session
+// @Description  Signs the current customer out of one of their sessions
+// @Tags         customers
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Session ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/sessions/{id} [delete]
+func RevokeSessionHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	customerID, _ := currentSession(c)
+	n, err := db.ProductQueries.RevokeCustomerSession(context.Background(), product_db.RevokeCustomerSessionParams{
+		ID:         id,
+		CustomerID: customerID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "session not found",
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "session revoked",
+	})
+}
+
+// RevokeOtherSessionsHandler godoc
+// @Summary      Revoke my other sessions
+// @Description  Signs the current customer out everywhere except the session of the request. Use /customers/logout to end that one too.
+// @Tags         customers
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/sessions [delete]
+func RevokeOtherSessionsHandler(c *fiber.Ctx) error {
+	customerID, sessionID := currentSession(c)
+	n, err := db.ProductQueries.RevokeOtherCustomerSessions(context.Background(), product_db.RevokeOtherCustomerSessionsParams{
+		CustomerID: customerID,
+		KeepID:     sessionID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "sessions revoked",
+		"count":   n,
+	})
+}
+
+// currentSession returns the customer and session of the request's token.
+func currentSession(c *fiber.Ctx) (int64, int64) {
+	claims := c.Locals("user").(*jwt.Token).Claims.(jwt.MapClaims)
+	id, _ := claims["id"].(float64)
+	sessionID, _ := token.SessionID(claims)
+	return int64(id), sessionID
+}
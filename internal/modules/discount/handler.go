@@ -4,13 +4,14 @@ import (
 	"app/internal/db"
 	product_db "app/internal/db/product"
 	"context"
-	"database/sql"
 	"errors"
 	"math"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -350,6 +351,7 @@ func GetDiscountsHandler(c *fiber.Ctx) error {
 // @Summary Get a discount by ID
 // @Description Returns a discount by ID
 // @Tags discounts
+// @Security BearerAuth
 // @Produce json
 // @Param id path int true "Discount ID"
 // @Success 200 {object} map[string]string
@@ -429,14 +431,16 @@ func GetValidDiscountsHandler(c *fiber.Ctx) error {
 
 // GetCustomerUsageHandler godoc
 // @Summary Get usage count for a discount by customer
-// @Description Returns how many times a customer used the discount
+// @Description Returns how many times the signed-in customer used the discount
 // @Tags discounts
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param discount_id path int true "Discount ID"
 // @Param customer_id path int true "Customer ID"
 // @Success 200 {object} map[string]int "used_count"
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /discounts/{discount_id}/customers/{customer_id}/usage [get]
@@ -450,13 +454,16 @@ func GetCustomerUsageHandler(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer_id"})
 	}
+	if id, ok := currentCustomerID(c); !ok || id != int64(customerID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "permission denied"})
+	}
 
 	usage, err := db.ProductQueries.GetCustomerUsage(c.Context(), product_db.GetCustomerUsageParams{
 		DiscountID: int64(discountID),
 		CustomerID: int64(customerID),
 	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return c.JSON(0)
 
 		}
@@ -470,6 +477,7 @@ func GetCustomerUsageHandler(c *fiber.Ctx) error {
 // @Summary Increment discount usage for customer
 // @Description Increase used_count by 1 for a given discount and customer
 // @Tags discounts
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param data body UpsertCustomerUsageRequest true "Discount and Customer IDs"
@@ -511,3 +519,9 @@ func UpsertCustomerUsageHandler(c *fiber.Ctx) error {
 	}
 	return c.JSON(fiber.Map{"message": "updated"})
 }
+
+func currentCustomerID(c *fiber.Ctx) (int64, bool) {
+	claims := c.Locals("user").(*jwt.Token).Claims.(jwt.MapClaims)
+	id, ok := claims["id"].(float64)
+	return int64(id), ok
+}
@@ -0,0 +1,59 @@
+package feed
+
+import "encoding/xml"
+
+// Item is one purchasable row of a feed: a variant, or the product itself
+// when it has no variants.
+type Item struct {
+	ID                   string
+	ItemGroupID          string
+	Title                string
+	Description          string
+	Link                 string
+	ImageLink            string
+	AdditionalImageLinks []string
+	InStock              bool
+	Price                int32
+	SalePrice            int32
+	Brand                string
+	Gtin                 string
+	Color                string
+	Size                 string
+	Material             string
+	Pattern              string
+}
+
+type GoogleRSS struct {
+	XMLName xml.Name      `xml:"rss"`
+	Version string        `xml:"version,attr"`
+	NS      string        `xml:"xmlns:g,attr"`
+	Channel GoogleChannel `xml:"channel"`
+}
+
+type GoogleChannel struct {
+	Title       string       `xml:"title"`
+	Link        string       `xml:"link"`
+	Description string       `xml:"description"`
+	Items       []GoogleItem `xml:"item"`
+}
+
+type GoogleItem struct {
+	ID                   string   `xml:"g:id"`
+	ItemGroupID          string   `xml:"g:item_group_id,omitempty"`
+	Title                string   `xml:"g:title"`
+	Description          string   `xml:"g:description"`
+	Link                 string   `xml:"g:link"`
+	ImageLink            string   `xml:"g:image_link,omitempty"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link,omitempty"`
+	Availability         string   `xml:"g:availability"`
+	Condition            string   `xml:"g:condition"`
+	Price                string   `xml:"g:price"`
+	SalePrice            string   `xml:"g:sale_price,omitempty"`
+	Brand                string   `xml:"g:brand,omitempty"`
+	Gtin                 string   `xml:"g:gtin,omitempty"`
+	IdentifierExists     string   `xml:"g:identifier_exists,omitempty"`
+	Color                string   `xml:"g:color,omitempty"`
+	Size                 string   `xml:"g:size,omitempty"`
+	Material             string   `xml:"g:material,omitempty"`
+	Pattern              string   `xml:"g:pattern,omitempty"`
+}
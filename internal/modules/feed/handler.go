@@ -0,0 +1,362 @@
+package feed
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	currency = "VND"
+	cacheTTL = time.Hour
+
+	googleFeed   = "google"
+	facebookFeed = "facebook"
+)
+
+type cachedFeed struct {
+	body        []byte
+	generatedAt time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]cachedFeed{}
+)
+
+var builders = map[string]func([]Item) ([]byte, error){
+	googleFeed:   buildGoogleFeed,
+	facebookFeed: buildFacebookFeed,
+}
+
+// GetGoogleFeedHandler godoc
+// @Summary      Google Merchant Center feed
+// @Description  Returns the product feed in Google Merchant RSS format
+// @Tags         feeds
+// @Produce      xml
+// @Success      200  {string}  string
+// @Failure      500  {object}  map[string]string
+// @Router       /feeds/google.xml [get]
+func GetGoogleFeedHandler(c *fiber.Ctx) error {
+	body, err := getFeed(context.Background(), googleFeed)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	return c.Send(body)
+}
+
+// GetFacebookFeedHandler godoc
+// @Summary      Facebook catalog feed
+// @Description  Returns the product feed as a Facebook catalog CSV
+// @Tags         feeds
+// @Produce      plain
+// @Success      200  {string}  string
+// @Failure      500  {object}  map[string]string
+// @Router       /feeds/facebook.csv [get]
+func GetFacebookFeedHandler(c *fiber.Ctx) error {
+	body, err := getFeed(context.Background(), facebookFeed)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(body)
+}
+
+// RegenerateFeedsHandler godoc
+// @Summary      Regenerate product feeds
+// @Description  Rebuilds every cached product feed from the current catalog
+// @Tags         feeds
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /feeds/regenerate [post]
+func RegenerateFeedsHandler(c *fiber.Ctx) error {
+	items, err := loadItems(context.Background())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	now := time.Now()
+	for name, build := range builders {
+		body, err := build(items)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		cacheMu.Lock()
+		cache[name] = cachedFeed{body: body, generatedAt: now}
+		cacheMu.Unlock()
+	}
+
+	return c.JSON(fiber.Map{
+		"items":        len(items),
+		"generated_at": now,
+	})
+}
+
+// getFeed returns the cached feed body, rebuilding it once it is older than
+// cacheTTL.
+func getFeed(ctx context.Context, name string) ([]byte, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if cached, ok := cache[name]; ok && time.Since(cached.generatedAt) < cacheTTL {
+		return cached.body, nil
+	}
+
+	items, err := loadItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := builders[name](items)
+	if err != nil {
+		return nil, err
+	}
+	cache[name] = cachedFeed{body: body, generatedAt: time.Now()}
+	return body, nil
+}
+
+func loadItems(ctx context.Context) ([]Item, error) {
+	rows, err := db.ProductQueries.GetFeedItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(rows))
+	for _, r := range rows {
+		productID := strconv.FormatInt(r.ID, 10)
+		link := config.AbsoluteURL("products/" + r.Slug)
+
+		images := make([]string, 0, len(r.Files)+1)
+		for _, f := range r.Files {
+			images = append(images, config.FileURL(f))
+		}
+
+		item := Item{
+			ID:          productID,
+			Title:       r.Name,
+			Description: r.MetaDescription,
+			Link:        link,
+			InStock:     r.Stock.Int32 > 0,
+			Price:       r.OriginPrice,
+			SalePrice:   r.SalePrice,
+			Brand:       r.Brand,
+			Gtin:        r.Gtin,
+		}
+
+		if r.VariantID.Valid {
+			variantID := strconv.FormatInt(r.VariantID.Int64, 10)
+			item.ID = productID + "_" + variantID
+			item.ItemGroupID = productID
+			item.Link = link + "?variant=" + variantID
+			item.InStock = r.VariantStock.Int32 > 0
+			item.Price = r.VariantOriginPrice.Int32
+			item.SalePrice = r.VariantSalePrice.Int32
+			if r.VariantFile.Valid && r.VariantFile.String != "" {
+				images = append([]string{config.FileURL(r.VariantFile.String)}, images...)
+			}
+
+			attributes := map[string]string{}
+			if len(r.Attributes) > 0 {
+				if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+					return nil, err
+				}
+			}
+			values := applyAttributes(&item, attributes)
+			if len(values) > 0 {
+				item.Title = r.Name + " - " + strings.Join(values, " / ")
+			}
+		}
+
+		if item.Price <= 0 {
+			item.Price = item.SalePrice
+		}
+		if item.SalePrice >= item.Price {
+			item.SalePrice = 0
+		}
+		if item.Description == "" {
+			item.Description = r.Name
+		}
+		if len(images) > 0 {
+			item.ImageLink = images[0]
+			item.AdditionalImageLinks = images[1:]
+			if len(item.AdditionalImageLinks) > 10 {
+				item.AdditionalImageLinks = item.AdditionalImageLinks[:10]
+			}
+		}
+
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// applyAttributes maps variant option names onto the feed attributes both
+// catalogs understand and returns every option value for the item title.
+func applyAttributes(item *Item, attributes map[string]string) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		value := attributes[name]
+		values = append(values, value)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "color", "colour", "màu", "màu sắc":
+			item.Color = value
+		case "size", "kích thước", "kích cỡ", "cỡ":
+			item.Size = value
+		case "material", "chất liệu":
+			item.Material = value
+		case "pattern", "họa tiết", "hoạ tiết":
+			item.Pattern = value
+		}
+	}
+	return values
+}
+
+func buildGoogleFeed(items []Item) ([]byte, error) {
+	rss := GoogleRSS{
+		Version: "2.0",
+		NS:      "http://base.google.com/ns/1.0",
+		Channel: GoogleChannel{
+			Title:       "Products",
+			Link:        config.AbsoluteURL(""),
+			Description: "Product feed",
+			Items:       make([]GoogleItem, 0, len(items)),
+		},
+	}
+
+	for _, item := range items {
+		g := GoogleItem{
+			ID:                   item.ID,
+			ItemGroupID:          item.ItemGroupID,
+			Title:                item.Title,
+			Description:          item.Description,
+			Link:                 item.Link,
+			ImageLink:            item.ImageLink,
+			AdditionalImageLinks: item.AdditionalImageLinks,
+			Availability:         "out_of_stock",
+			Condition:            "new",
+			Price:                formatPrice(item.Price),
+			Brand:                item.Brand,
+			Gtin:                 item.Gtin,
+			Color:                item.Color,
+			Size:                 item.Size,
+			Material:             item.Material,
+			Pattern:              item.Pattern,
+		}
+		if item.InStock {
+			g.Availability = "in_stock"
+		}
+		if item.SalePrice > 0 {
+			g.SalePrice = formatPrice(item.SalePrice)
+		}
+		if item.Gtin == "" && item.Brand == "" {
+			g.IdentifierExists = "no"
+		}
+		rss.Channel.Items = append(rss.Channel.Items, g)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(rss); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func buildFacebookFeed(items []Item) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{
+		"id",
+		"item_group_id",
+		"title",
+		"description",
+		"availability",
+		"condition",
+		"price",
+		"sale_price",
+		"link",
+		"image_link",
+		"additional_image_link",
+		"brand",
+		"gtin",
+		"color",
+		"size",
+		"material",
+		"pattern",
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		availability := "out of stock"
+		if item.InStock {
+			availability = "in stock"
+		}
+		salePrice := ""
+		if item.SalePrice > 0 {
+			salePrice = formatPrice(item.SalePrice)
+		}
+		record := []string{
+			item.ID,
+			item.ItemGroupID,
+			item.Title,
+			item.Description,
+			availability,
+			"new",
+			formatPrice(item.Price),
+			salePrice,
+			item.Link,
+			item.ImageLink,
+			strings.Join(item.AdditionalImageLinks, ","),
+			item.Brand,
+			item.Gtin,
+			item.Color,
+			item.Size,
+			item.Material,
+			item.Pattern,
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatPrice(amount int32) string {
+	return strconv.FormatInt(int64(amount), 10) + " " + currency
+}
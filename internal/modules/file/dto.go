@@ -1,5 +1,11 @@
 package file
 
+import (
+	"app/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -14,4 +20,41 @@ type CreateFileRequest struct {
 
 type DeleteFilesRequest struct {
 	IDs []int64 `json:"ids"`
+	// Force deletes files even when something still references them.
+	Force bool `json:"force"`
+}
+
+type UpdateFileRequest struct {
+	AltText string   `json:"alt_text"`
+	Title   string   `json:"title"`
+	Folder  string   `json:"folder" example:"products/summer"`
+	Tags    []string `json:"tags"`
+}
+
+type MoveFilesRequest struct {
+	IDs    []int64 `json:"ids"`
+	Folder string  `json:"folder" example:"products/summer"`
+}
+
+type FileResponse struct {
+	ID           int64               `json:"id"`
+	Name         string              `json:"name"`
+	URL          string              `json:"url"`
+	Renditions   *storage.Renditions `json:"renditions"`
+	ContentType  pgtype.Text         `json:"content_type"`
+	Size         pgtype.Int8         `json:"size"`
+	OriginalName pgtype.Text         `json:"original_name"`
+	Width        pgtype.Int4         `json:"width"`
+	Height       pgtype.Int4         `json:"height"`
+	AltText      string              `json:"alt_text"`
+	Title        string              `json:"title"`
+	Folder       string              `json:"folder"`
+	Tags         []string            `json:"tags"`
+	CreatedAt    pgtype.Timestamptz  `json:"created_at"`
+}
+
+type FileDetailResponse struct {
+	FileResponse
+	Blurhash pgtype.Text     `json:"blurhash"`
+	UsedBy   []storage.Usage `json:"used_by"`
 }
@@ -1,56 +1,319 @@
 package file
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/storage"
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"path"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // GetFilesHandler godoc
 // @Summary      Get file list
-// @Description  Returns a list of files
+// @Description  Returns a list of files, newest first. Folder matches the folder and everything below it; type matches the start of the content type, e.g. "image" or "image/png".
 // @Tags         files
 // @Security BearerAuth
 // @Produce      json
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  PaginatedResponse[any]
+// @Param        q         query     string  false  "Search in name, original name, title and alt text"
+// @Param        folder    query     string  false  "Folder"
+// @Param        tag       query     string  false  "Tag"
+// @Param        type      query     string  false  "Content type prefix"
+// @Success      200  {object}  PaginatedResponse[FileResponse]
+// @Failure      500  {object}  map[string]string
 // @Router       /files [get]
 func GetFilesHandler(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
 	offset := (page - 1) * pageSize
 
+	search := queryText(c, "q")
+	folder := queryText(c, "folder")
+	if folder.Valid {
+		folder.String, _ = cleanFolder(folder.String)
+	}
+	tag := queryText(c, "tag")
+	contentType := queryText(c, "type")
+
 	ctx := context.Background()
 
 	files, err := db.ProductQueries.GetFiles(ctx, product_db.GetFilesParams{
-		Limit:  int32(pageSize),
-		Offset: int32(offset),
+		Search:      search,
+		Folder:      folder,
+		Tag:         tag,
+		ContentType: contentType,
+		LimitCount:  int32(pageSize),
+		OffsetCount: int32(offset),
 	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-	total, err := db.ProductQueries.CountFiles(c.Context())
+	total, err := db.ProductQueries.CountFiles(ctx, product_db.CountFilesParams{
+		Search:      search,
+		Folder:      folder,
+		Tag:         tag,
+		ContentType: contentType,
+	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	var media storage.ImageSet
+	for _, f := range files {
+		media.Add(f.Name)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.JSON(PaginatedResponse[product_db.GetFilesRow]{
+	data := make([]FileResponse, len(files))
+	for i, f := range files {
+		data[i] = FileResponse{
+			ID:           f.ID,
+			Name:         f.Name,
+			URL:          config.FileURL(f.Name),
+			Renditions:   media.Renditions(f.Name),
+			ContentType:  f.ContentType,
+			Size:         f.Size,
+			OriginalName: f.OriginalName,
+			Width:        f.Width,
+			Height:       f.Height,
+			AltText:      f.AltText,
+			Title:        f.Title,
+			Folder:       f.Folder,
+			Tags:         f.Tags,
+			CreatedAt:    f.CreatedAt,
+		}
+	}
+
+	return c.JSON(PaginatedResponse[FileResponse]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       files,
+		Data:       data,
+	})
+}
+
+// GetFileHandler godoc
+// @Summary      Get a file
+// @Description  Returns a file with its metadata and everything that references it: products, variants, collections, hotspots, reviews and blog posts.
+// @Tags         files
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "File ID"
+// @Success      200  {object}  FileDetailResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /files/{id} [get]
+func GetFileHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	ctx := context.Background()
+	f, err := db.ProductQueries.GetFile(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "file not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	usages, err := storage.Usages(ctx, []string{f.Name})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var media storage.ImageSet
+	media.Add(f.Name)
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	usedBy := usages[f.Name]
+	if usedBy == nil {
+		usedBy = []storage.Usage{}
+	}
+	return c.JSON(FileDetailResponse{
+		FileResponse: FileResponse{
+			ID:           f.ID,
+			Name:         f.Name,
+			URL:          config.FileURL(f.Name),
+			Renditions:   media.Renditions(f.Name),
+			ContentType:  f.ContentType,
+			Size:         f.Size,
+			OriginalName: f.OriginalName,
+			Width:        f.Width,
+			Height:       f.Height,
+			AltText:      f.AltText,
+			Title:        f.Title,
+			Folder:       f.Folder,
+			Tags:         f.Tags,
+			CreatedAt:    f.CreatedAt,
+		},
+		Blurhash: f.Blurhash,
+		UsedBy:   usedBy,
+	})
+}
+
+// UpdateFileHandler godoc
+// @Summary      Update file metadata
+// @Description  Sets the alt text, title, folder and tags of a file. Folders are slash-separated paths such as "products/summer"; an empty folder is the root.
+// @Tags         files
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                true  "File ID"
+// @Param        payload  body      UpdateFileRequest  true  "File metadata"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /files/{id} [put]
+func UpdateFileHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req UpdateFileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	folder, ok := cleanFolder(req.Folder)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder",
+		})
+	}
+
+	ctx := context.Background()
+	n, err := db.ProductQueries.UpdateFile(ctx, product_db.UpdateFileParams{
+		ID:      id,
+		AltText: strings.TrimSpace(req.AltText),
+		Title:   strings.TrimSpace(req.Title),
+		Folder:  folder,
+		Tags:    cleanTags(req.Tags),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "file not found",
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "file updated successfully",
+	})
+}
+
+// MoveFilesHandler godoc
+// @Summary      Move files to a folder
+// @Description  Puts several files in one folder. An empty folder moves them to the root.
+// @Tags         files
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      MoveFilesRequest  true  "File IDs and folder"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /files/move [put]
+func MoveFilesHandler(c *fiber.Ctx) error {
+	var req MoveFilesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+	if len(req.IDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "no file IDs provided",
+		})
+	}
+	folder, ok := cleanFolder(req.Folder)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid folder",
+		})
+	}
+
+	ctx := context.Background()
+	n, err := db.ProductQueries.MoveFiles(ctx, product_db.MoveFilesParams{
+		Folder: folder,
+		Ids:    req.IDs,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "files moved successfully",
+		"count":   n,
+	})
+}
+
+// GetFoldersHandler godoc
+// @Summary      Get folders
+// @Description  Returns every folder holding files, with how many files each holds directly
+// @Tags         files
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /files/folders [get]
+func GetFoldersHandler(c *fiber.Ctx) error {
+	folders, err := db.ProductQueries.GetFolders(context.Background())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if folders == nil {
+		folders = []product_db.GetFoldersRow{}
+	}
+	return c.JSON(fiber.Map{
+		"data": folders,
 	})
 }
 
@@ -86,9 +349,56 @@ func CreateFileHandler(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(params)
 }
 
+// UploadFilesHandler godoc
+// @Summary      Upload files
+// @Description  Stores uploaded images, PDFs and MP4 videos and adds them to the file list. The type is detected from the content. A file whose content was uploaded before is not stored again: the existing file is returned with duplicate set. AVIF images cannot be decoded here, so they are stored as uploaded without renditions and come back with unprocessed set.
+// @Tags         files
+// @Security BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        files  formData  []file  true  "Files to upload"  collectionFormat(multi)
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      413  {object}  map[string]string
+// @Failure      415  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /files/upload [post]
+func UploadFilesHandler(c *fiber.Ctx) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid form data",
+		})
+	}
+	headers := form.File["files"]
+	if len(headers) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "no files provided",
+		})
+	}
+	if len(headers) > config.UploadMaxFiles {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("at most %d files per upload", config.UploadMaxFiles),
+		})
+	}
+
+	ctx := context.Background()
+	files := make([]storage.File, 0, len(headers))
+	for _, fh := range headers {
+		f, err := storage.Save(ctx, fh, storage.Media)
+		if err != nil {
+			return storage.Reject(c, err)
+		}
+		files = append(files, f)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": files,
+	})
+}
+
 // DeleteFilesHandler godoc
 // @Summary      Delete multiple files
-// @Description  Deletes multiple files by their IDs
+// @Description  Deletes multiple files by their IDs, along with their stored content and renditions. Files that are still referenced are not deleted unless force is set: the answer is 409 with what references each of them.
 // @Tags         files
 // @Security BearerAuth
 // @Accept       json
@@ -96,6 +406,7 @@ func CreateFileHandler(c *fiber.Ctx) error {
 // @Param        ids  body      DeleteFilesRequest  true  "List of file IDs"
 // @Success      200  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]string
 // @Router       /files [delete]
 func DeleteFilesHandler(c *fiber.Ctx) error {
@@ -108,18 +419,80 @@ func DeleteFilesHandler(c *fiber.Ctx) error {
 
 	if len(req.IDs) == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "no user IDs provided",
+			"error": "no file IDs provided",
 		})
 	}
 
 	ctx := context.Background()
-	if err := db.ProductQueries.BulkDeleteFiles(ctx, req.IDs); err != nil {
+	files, err := db.ProductQueries.GetFilesByIDs(ctx, req.IDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if !req.Force {
+		names := make([]string, len(files))
+		for i, f := range files {
+			names[i] = f.Name
+		}
+		usages, err := storage.Usages(ctx, names)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		usedBy := map[int64][]storage.Usage{}
+		for _, f := range files {
+			if u := usages[f.Name]; len(u) > 0 {
+				usedBy[f.ID] = u
+			}
+		}
+		if len(usedBy) > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "files are still in use, delete with force to remove them anyway",
+				"used_by": usedBy,
+			})
+		}
+	}
+
+	if err := storage.Remove(ctx, files); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 	return c.JSON(fiber.Map{
-		"message": "users deleted successfully",
-		"count":   len(req.IDs),
+		"message": "files deleted successfully",
+		"count":   len(files),
 	})
 }
+
+func queryText(c *fiber.Ctx, key string) pgtype.Text {
+	v := strings.TrimSpace(c.Query(key))
+	return pgtype.Text{String: v, Valid: v != ""}
+}
+
+// cleanFolder normalizes a folder path to "a/b" form and reports whether it
+// is valid. Paths stepping out with ".." are not.
+func cleanFolder(folder string) (string, bool) {
+	folder = strings.Trim(strings.TrimSpace(folder), "/")
+	if folder == "" {
+		return "", true
+	}
+	cleaned := path.Clean(folder)
+	if cleaned != folder || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// cleanTags trims tags and drops empty and repeated ones.
+func cleanTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.TrimSpace(t); t != "" && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
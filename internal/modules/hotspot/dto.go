@@ -1,8 +1,15 @@
 package hotspot
 
+import (
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+)
+
 type HotspotWithSpots struct {
-	File  string `json:"file"`
-	Spots []Spot `json:"spots"`
+	File           string              `json:"file"`
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+	Spots          []Spot              `json:"spots"`
 }
 
 type Spot struct {
@@ -12,12 +19,26 @@ type Spot struct {
 }
 
 type Product struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Slug        string `json:"slug"`
-	SalePrice   int32  `json:"sale_price"`
-	OriginPrice int32  `json:"ogirin_price"`
-	File        string `json:"file"`
+	ID             int64               `json:"id"`
+	Name           string              `json:"name"`
+	Slug           string              `json:"slug"`
+	SalePrice      int32               `json:"sale_price"`
+	OriginPrice    int32               `json:"ogirin_price"`
+	File           string              `json:"file"`
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type HotspotItem struct {
+	product_db.Hotspot
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type HotspotResponse struct {
+	product_db.GetHotspotRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
 }
 
 type PaginatedResponse[T any] struct {
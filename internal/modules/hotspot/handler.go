@@ -1,8 +1,10 @@
 package hotspot
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/storage"
 	"context"
 	"database/sql"
 	"math"
@@ -19,7 +21,7 @@ import (
 // @Produce      json
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  PaginatedResponse[any]
+// @Success      200  {object}  PaginatedResponse[HotspotItem]
 // @Router       /hotspots [get]
 func GetHotspotsHandler(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -44,14 +46,32 @@ func GetHotspotsHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	var media storage.ImageSet
+	for _, h := range files {
+		media.Add(h.File)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]HotspotItem, len(files))
+	for i, h := range files {
+		data[i] = HotspotItem{
+			Hotspot:        h,
+			FileURL:        config.FileURL(h.File),
+			FileRenditions: media.Renditions(h.File),
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.JSON(PaginatedResponse[product_db.Hotspot]{
+	return c.JSON(PaginatedResponse[HotspotItem]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       files,
+		Data:       data,
 	})
 }
 
@@ -62,7 +82,7 @@ func GetHotspotsHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  HotspotResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -88,7 +108,18 @@ func GetHotspotHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	var media storage.ImageSet
+	media.Add(result.File)
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(HotspotResponse{
+		GetHotspotRow:  result,
+		FileURL:        config.FileURL(result.File),
+		FileRenditions: media.Renditions(result.File),
+	})
 }
 
 // GetHotspotByProductHandler godoc
@@ -98,7 +129,7 @@ func GetHotspotHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {array}   HotspotWithSpots
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -121,6 +152,7 @@ func GetHotspotByProductHandler(c *fiber.Ctx) error {
 	}
 
 	var results []HotspotWithSpots
+	var media storage.ImageSet
 	for _, hotspot := range hotspots {
 		products, err := db.ProductQueries.GetProductsByHotspotId(ctx, hotspot.ID)
 		if err != nil {
@@ -143,7 +175,9 @@ func GetHotspotByProductHandler(c *fiber.Ctx) error {
 				X: p.X,
 				Y: p.Y,
 			}
+			media.Add(p.File.String)
 		}
+		media.Add(hotspot.File)
 
 		results = append(results, HotspotWithSpots{
 			File:  hotspot.File,
@@ -151,6 +185,22 @@ func GetHotspotByProductHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for i := range results {
+		h := &results[i]
+		h.FileURL = config.FileURL(h.File)
+		h.FileRenditions = media.Renditions(h.File)
+		for j := range h.Spots {
+			p := &h.Spots[j].Product
+			p.FileURL = config.FileURL(p.File)
+			p.FileRenditions = media.Renditions(p.File)
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(results)
 }
 
@@ -21,3 +21,55 @@ type UpdateMenuRequest struct {
 type DeleteMenusRequest struct {
 	IDs []int64 `json:"ids"`
 }
+
+// MenuItemRequest describes where a menu item links. URL is used by "url"
+// links and TargetID, the ID of the linked entity, by the others.
+type MenuItemRequest struct {
+	Label     string `json:"label" validate:"required"`
+	LinkType  string `json:"link_type" validate:"required,oneof=url product collection category page post" example:"collection"`
+	URL       string `json:"url" example:"https://example.com/sale"`
+	TargetID  int64  `json:"target_id" example:"3"`
+	IsVisible *bool  `json:"is_visible"`
+}
+
+// CreateMenuItemRequest adds an item at the end of its parent's children;
+// ParentID 0 adds it at the top level.
+type CreateMenuItemRequest struct {
+	ParentID int64 `json:"parent_id"`
+	MenuItemRequest
+}
+
+// MoveMenuItemsRequest moves and reorders items; items not listed stay
+// where they are.
+type MoveMenuItemsRequest struct {
+	Items []MenuItemPosition `json:"items" validate:"required,dive"`
+}
+
+type MenuItemPosition struct {
+	ID       int64 `json:"id" validate:"required"`
+	ParentID int64 `json:"parent_id"`
+	No       int32 `json:"no"`
+}
+
+type MenuResponse struct {
+	ID       int64              `json:"id"`
+	Name     string             `json:"name"`
+	Position string             `json:"position"`
+	Items    []MenuItemResponse `json:"items"`
+}
+
+// MenuItemResponse is a menu item with its link resolved: URL is the
+// storefront path of the linked entity under its current slug, or the URL
+// given for "url" links. Available is false when the linked entity was
+// deleted or is not published; the storefront never sees such items.
+type MenuItemResponse struct {
+	ID        int64              `json:"id"`
+	Label     string             `json:"label"`
+	LinkType  string             `json:"link_type"`
+	TargetID  int64              `json:"target_id,omitempty"`
+	Slug      string             `json:"slug,omitempty"`
+	URL       string             `json:"url"`
+	IsVisible bool               `json:"is_visible"`
+	Available bool               `json:"available"`
+	Children  []MenuItemResponse `json:"children"`
+}
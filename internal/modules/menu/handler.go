@@ -4,12 +4,13 @@ import (
 	"app/internal/db"
 	product_db "app/internal/db/product"
 	"context"
-	"database/sql"
+	"errors"
 	"math"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
 )
 
 // GetMenusHandler godoc
@@ -62,7 +63,7 @@ func GetMenusHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  MenuResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -78,7 +79,7 @@ func GetMenuHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetMenu(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "not found",
 			})
@@ -87,18 +88,29 @@ func GetMenuHandler(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	items, err := loadItems(ctx, result.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	return c.Status(fiber.StatusOK).JSON(MenuResponse{
+		ID:       result.ID,
+		Name:     result.Name,
+		Position: result.Position,
+		Items:    buildTree(items, false),
+	})
 }
 
 // GetMenuByPositionHandler godoc
 // @Summary      Get a menu
-// @Description  Returns a menu by ID
+// @Description  Returns the visible items of the menu at a position as a tree, with links to the current slugs of their targets
 // @Tags         menus
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      string  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  MenuResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -108,7 +120,7 @@ func GetMenuByPositionHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetMenuByPosition(ctx, param)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "not found",
 			})
@@ -117,14 +129,26 @@ func GetMenuByPositionHandler(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	items, err := loadItems(ctx, result.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	return c.Status(fiber.StatusOK).JSON(MenuResponse{
+		ID:       result.ID,
+		Name:     result.Name,
+		Position: result.Position,
+		Items:    buildTree(items, true),
+	})
 }
 
 // CreateMenuHandler godoc
 // @Summary      Create a new menu
 // @Description  Creates a new menu and returns the created menu
 // @Tags         menus
+// @Security BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        payload  body	CreateMenuRequest  true  "Create data"
@@ -0,0 +1,465 @@
+package menu
+
+import (
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	LinkURL        = "url"
+	LinkProduct    = "product"
+	LinkCollection = "collection"
+	LinkCategory   = "category"
+	LinkPage       = "page"
+	LinkPost       = "post"
+)
+
+// maxDepth is how many levels a menu may nest.
+const maxDepth = 3
+
+// linkPaths are the storefront paths entity links resolve to, followed by
+// the slug.
+var linkPaths = map[string]string{
+	LinkProduct:    "/products/",
+	LinkCollection: "/collections/",
+	LinkCategory:   "/categories/",
+	LinkPage:       "/pages/",
+	LinkPost:       "/posts/",
+}
+
+// loadItems returns the items of a menu with their targets resolved. Post
+// links are resolved separately since posts live in the blog database.
+func loadItems(ctx context.Context, menuID int64) ([]product_db.GetMenuItemsRow, error) {
+	rows, err := db.ProductQueries.GetMenuItems(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	var postIDs []int64
+	for _, r := range rows {
+		if r.LinkType == LinkPost && r.TargetID.Valid {
+			postIDs = append(postIDs, r.TargetID.Int64)
+		}
+	}
+	if len(postIDs) == 0 {
+		return rows, nil
+	}
+	posts, err := db.BlogQueries.GetPostLinks(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range posts {
+		for i, r := range rows {
+			if r.LinkType == LinkPost && r.TargetID.Int64 == p.ID {
+				rows[i].TargetSlug = p.Slug
+				rows[i].TargetAvailable = p.IsActive
+			}
+		}
+	}
+	return rows, nil
+}
+
+// buildTree nests rows under their parents. With public set, hidden items
+// and items whose target is unavailable are left out along with their
+// children.
+func buildTree(rows []product_db.GetMenuItemsRow, public bool) []MenuItemResponse {
+	children := map[int64][]product_db.GetMenuItemsRow{}
+	for _, r := range rows {
+		children[r.ParentID.Int64] = append(children[r.ParentID.Int64], r)
+	}
+	var build func(parent int64, depth int) []MenuItemResponse
+	build = func(parent int64, depth int) []MenuItemResponse {
+		items := []MenuItemResponse{}
+		if depth >= maxDepth {
+			return items
+		}
+		for _, r := range children[parent] {
+			item := MenuItemResponse{
+				ID:        r.ID,
+				Label:     r.Label,
+				LinkType:  r.LinkType,
+				TargetID:  r.TargetID.Int64,
+				IsVisible: r.IsVisible,
+				Available: r.LinkType == LinkURL || r.TargetAvailable,
+			}
+			if public && (!item.IsVisible || !item.Available) {
+				continue
+			}
+			if r.LinkType == LinkURL {
+				item.URL = r.Url
+			} else if r.TargetSlug != "" {
+				item.Slug = r.TargetSlug
+				item.URL = linkPaths[r.LinkType] + r.TargetSlug
+			}
+			item.Children = build(r.ID, depth+1)
+			items = append(items, item)
+		}
+		return items
+	}
+	return build(0, 0)
+}
+
+// checkLink validates the target of an item. It returns a message for the
+// client when the link is invalid.
+func checkLink(ctx context.Context, req MenuItemRequest) (string, error) {
+	if req.LinkType == LinkURL {
+		u := strings.TrimSpace(req.URL)
+		for _, prefix := range []string{"/", "https://", "http://", "mailto:", "tel:"} {
+			if strings.HasPrefix(u, prefix) {
+				return "", nil
+			}
+		}
+		return "url must be a path or an http(s), mailto: or tel: link", nil
+	}
+	if req.TargetID <= 0 {
+		return "target_id is required", nil
+	}
+
+	var err error
+	switch req.LinkType {
+	case LinkProduct:
+		_, err = db.ProductQueries.GetProduct(ctx, req.TargetID)
+	case LinkCollection:
+		_, err = db.ProductQueries.GetCollection(ctx, req.TargetID)
+	case LinkCategory:
+		_, err = db.ProductQueries.GetCategory(ctx, req.TargetID)
+	case LinkPage:
+		_, err = db.ProductQueries.GetPage(ctx, req.TargetID)
+	case LinkPost:
+		_, err = db.BlogQueries.GetPost(ctx, req.TargetID)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return req.LinkType + " not found", nil
+	}
+	return "", err
+}
+
+// depthOf returns the level of an item, 0 at the top, or -1 when its
+// parents loop or run deeper than maxDepth.
+func depthOf(id int64, parents map[int64]int64) int {
+	depth := 0
+	for p := parents[id]; p != 0; p = parents[p] {
+		depth++
+		if depth >= maxDepth {
+			return -1
+		}
+	}
+	return depth
+}
+
+func itemParams(req MenuItemRequest) (url string, target pgtype.Int8, visible bool) {
+	if req.LinkType == LinkURL {
+		url = strings.TrimSpace(req.URL)
+	} else {
+		target = pgtype.Int8{Int64: req.TargetID, Valid: true}
+	}
+	return url, target, req.IsVisible == nil || *req.IsVisible
+}
+
+// CreateMenuItemHandler godoc
+// @Summary      Add a menu item
+// @Description  Adds an item at the end of the children of parent_id, or of the top level
+// @Tags         menus
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Menu id"
+// @Param        payload  body	CreateMenuItemRequest  true  "Item"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /menus/{id}/items [post]
+func CreateMenuItemHandler(c *fiber.Ctx) error {
+	menuID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req CreateMenuItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	if _, err := db.ProductQueries.GetMenu(ctx, menuID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	msg, err := checkLink(ctx, req.MenuItemRequest)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	var parent pgtype.Int8
+	if req.ParentID != 0 {
+		rows, err := db.ProductQueries.GetMenuItems(ctx, menuID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		parents := map[int64]int64{}
+		for _, r := range rows {
+			parents[r.ID] = r.ParentID.Int64
+		}
+		if _, ok := parents[req.ParentID]; !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "parent_id is not an item of this menu",
+			})
+		}
+		if d := depthOf(req.ParentID, parents); d < 0 || d+1 >= maxDepth {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "menus nest at most " + strconv.Itoa(maxDepth) + " levels deep",
+			})
+		}
+		parent = pgtype.Int8{Int64: req.ParentID, Valid: true}
+	}
+
+	url, target, visible := itemParams(req.MenuItemRequest)
+	id, err := db.ProductQueries.CreateMenuItem(ctx, product_db.CreateMenuItemParams{
+		MenuID:    menuID,
+		ParentID:  parent,
+		Label:     req.Label,
+		LinkType:  req.LinkType,
+		Url:       url,
+		TargetID:  target,
+		IsVisible: visible,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id": id,
+	})
+}
+
+// UpdateMenuItemHandler godoc
+// @Summary      Update a menu item
+// @Description  Updates the label, link and visibility of a menu item
+// @Tags         menus
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Menu id"
+// @Param        item_id  path      int  true  "Item id"
+// @Param        payload  body	MenuItemRequest  true  "Item"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /menus/{id}/items/{item_id} [put]
+func UpdateMenuItemHandler(c *fiber.Ctx) error {
+	menuID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	itemID, err := strconv.ParseInt(c.Params("item_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req MenuItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	msg, err := checkLink(ctx, req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": msg,
+		})
+	}
+
+	url, target, visible := itemParams(req)
+	n, err := db.ProductQueries.UpdateMenuItem(ctx, product_db.UpdateMenuItemParams{
+		ID:        itemID,
+		MenuID:    menuID,
+		Label:     req.Label,
+		LinkType:  req.LinkType,
+		Url:       url,
+		TargetID:  target,
+		IsVisible: visible,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "not found",
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// MoveMenuItemsHandler godoc
+// @Summary      Move menu items
+// @Description  Sets the parent and order of menu items, e.g. after a drag and drop. parent_id 0 moves an item to the top level.
+// @Tags         menus
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Menu id"
+// @Param        payload  body	MoveMenuItemsRequest  true  "Positions"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /menus/{id}/items [put]
+func MoveMenuItemsHandler(c *fiber.Ctx) error {
+	menuID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req MoveMenuItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	rows, err := db.ProductQueries.GetMenuItems(ctx, menuID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	parents := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		parents[r.ID] = r.ParentID.Int64
+	}
+	params := product_db.MoveMenuItemsParams{MenuID: menuID}
+	for _, p := range req.Items {
+		if _, ok := parents[p.ID]; !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "item " + strconv.FormatInt(p.ID, 10) + " is not an item of this menu",
+			})
+		}
+		if _, ok := parents[p.ParentID]; p.ParentID != 0 && !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "parent " + strconv.FormatInt(p.ParentID, 10) + " is not an item of this menu",
+			})
+		}
+		parents[p.ID] = p.ParentID
+		params.Ids = append(params.Ids, p.ID)
+		params.ParentIds = append(params.ParentIds, p.ParentID)
+		params.Nos = append(params.Nos, p.No)
+	}
+	for id := range parents {
+		if depthOf(id, parents) < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "items cannot be nested in themselves or more than " + strconv.Itoa(maxDepth) + " levels deep",
+			})
+		}
+	}
+
+	if err := db.ProductQueries.MoveMenuItems(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// DeleteMenuItemHandler godoc
+// @Summary      Delete a menu item
+// @Description  Deletes a menu item along with its children
+// @Tags         menus
+// @Security BearerAuth
+// @Produce      json
+// @Param        id       path      int  true  "Menu id"
+// @Param        item_id  path      int  true  "Item id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /menus/{id}/items/{item_id} [delete]
+func DeleteMenuItemHandler(c *fiber.Ctx) error {
+	menuID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	itemID, err := strconv.ParseInt(c.Params("item_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	n, err := db.ProductQueries.DeleteMenuItem(context.Background(), product_db.DeleteMenuItemParams{
+		ID:     itemID,
+		MenuID: menuID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "not found",
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -93,6 +93,7 @@ func GetOrdersHandler(c *fiber.Ctx) error {
 // @Summary      Get count status order
 // @Description  Returns count of status order
 // @Tags         orders
+// @Security BearerAuth
 // @Produce      json
 // @Success      200  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
@@ -114,6 +115,7 @@ func CountOrderHandler(c *fiber.Ctx) error {
 // @Summary      Get a order
 // @Description  Returns a order by ID
 // @Tags         orders
+// @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
 // @Success      200  {object}  map[string]interface{}
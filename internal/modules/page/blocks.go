@@ -0,0 +1,86 @@
+package page
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	BlockRichText        = "rich_text"
+	BlockImage           = "image"
+	BlockImageText       = "image_text"
+	BlockProductCarousel = "product_carousel"
+	BlockLookbook        = "lookbook"
+	BlockFAQ             = "faq"
+	BlockHTMLEmbed       = "html_embed"
+)
+
+// maxBlocks bounds the blocks of one page.
+const maxBlocks = 100
+
+// blockSchemas holds the schema of the data of each block type. Images are
+// stored file names under "file"; responses give their URL and renditions
+// next to the block data.
+var blockSchemas = map[string]*schema{
+	BlockRichText: object([]string{"html"}, map[string]*schema{
+		"html": text("Content", 1, 100000),
+	}),
+	BlockImage: object([]string{"file"}, map[string]*schema{
+		"file":    text("Image", 1, 500),
+		"alt":     text("Alt text", 0, 300),
+		"caption": text("Caption", 0, 500),
+		"link":    text("Link", 0, 2000),
+	}),
+	BlockImageText: object([]string{"file", "html"}, map[string]*schema{
+		"file":           text("Image", 1, 500),
+		"alt":            text("Alt text", 0, 300),
+		"html":           text("Content", 1, 100000),
+		"image_position": enum("Image position", "left", "right"),
+	}),
+	BlockProductCarousel: object([]string{"collection_id"}, map[string]*schema{
+		"title":         text("Title", 0, 200),
+		"collection_id": id("Collection"),
+		"limit":         integer("Products shown", 1, 48),
+	}),
+	BlockLookbook: object([]string{"hotspot_ids"}, map[string]*schema{
+		"title":       text("Title", 0, 200),
+		"hotspot_ids": list(id("Hotspot"), 1, 12),
+	}),
+	BlockFAQ: object([]string{"items"}, map[string]*schema{
+		"title": text("Title", 0, 200),
+		"items": list(object([]string{"question", "answer"}, map[string]*schema{
+			"question": text("Question", 1, 500),
+			"answer":   text("Answer", 1, 5000),
+		}), 1, 100),
+	}),
+	BlockHTMLEmbed: object([]string{"html"}, map[string]*schema{
+		"html": text("HTML", 1, 50000),
+	}),
+}
+
+// defaultCarouselLimit is how many products a carousel shows without a
+// limit.
+const defaultCarouselLimit = 12
+
+// ValidateBlocks checks every block against the schema of its type.
+func ValidateBlocks(blocks []Block) error {
+	if len(blocks) > maxBlocks {
+		return fmt.Errorf("a page can have at most %d blocks", maxBlocks)
+	}
+	for i, b := range blocks {
+		s, ok := blockSchemas[b.Type]
+		if !ok {
+			return fmt.Errorf("blocks[%d].type must be one of: %s", i, blockTypeList())
+		}
+		if err := s.validate(fmt.Sprintf("blocks[%d].data", i), b.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blockTypeList() string {
+	return strings.Join(slices.Sorted(maps.Keys(blockSchemas)), ", ")
+}
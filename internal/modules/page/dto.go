@@ -1,5 +1,12 @@
 package page
 
+import (
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -8,9 +15,25 @@ type PaginatedResponse[T any] struct {
 	Data       []T   `json:"data"`
 }
 
+// Block is one piece of page content. Data depends on Type; GET
+// /pages/blocks returns the JSON Schema of each type.
+type Block struct {
+	Type string         `json:"type" example:"rich_text"`
+	Data map[string]any `json:"data"`
+}
+
+// BlockResponse is a block with the URL and renditions of the image it
+// shows, if any.
+type BlockResponse struct {
+	Block
+	FileURL        string              `json:"file_url,omitempty"`
+	FileRenditions *storage.Renditions `json:"file_renditions,omitempty"`
+}
+
 type CreatePageRequest struct {
-	Name string `json:"name" validate:"required"`
-	Slug string `json:"slug" validate:"required"`
+	Name   string  `json:"name" validate:"required"`
+	Slug   string  `json:"slug" validate:"required"`
+	Blocks []Block `json:"blocks"`
 }
 
 type UpdatePageRequest struct {
@@ -21,3 +44,65 @@ type UpdatePageRequest struct {
 type DeletePagesRequest struct {
 	IDs []int64 `json:"ids"`
 }
+
+type UpdatePageBlocksRequest struct {
+	Blocks []Block `json:"blocks"`
+}
+
+// PageResponse is a page as the admin edits it. PublishedBlocks is null
+// until the page is first published.
+type PageResponse struct {
+	ID                    int64              `json:"id"`
+	Name                  string             `json:"name"`
+	Slug                  string             `json:"slug"`
+	DraftBlocks           []BlockResponse    `json:"draft_blocks"`
+	PublishedBlocks       []BlockResponse    `json:"published_blocks"`
+	PublishedAt           pgtype.Timestamptz `json:"published_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	HasUnpublishedChanges bool               `json:"has_unpublished_changes"`
+}
+
+// PublicPageResponse is a page as the storefront renders it, with the
+// collections, products and hotspots its blocks reference.
+type PublicPageResponse struct {
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Slug        string             `json:"slug"`
+	Blocks      []ResolvedBlock    `json:"blocks"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+}
+
+// ResolvedBlock is a block with its references loaded: a product carousel
+// gets Collection and Products, a lookbook Hotspots. References to deleted
+// entities are left out.
+type ResolvedBlock struct {
+	Type           string              `json:"type"`
+	Data           map[string]any      `json:"data"`
+	FileURL        string              `json:"file_url,omitempty"`
+	FileRenditions *storage.Renditions `json:"file_renditions,omitempty"`
+	Collection     *CollectionSummary  `json:"collection,omitempty"`
+	Products       []ProductCard       `json:"products,omitempty"`
+	Hotspots       []HotspotSummary    `json:"hotspots,omitempty"`
+}
+
+type CollectionSummary struct {
+	ID             int64               `json:"id"`
+	Name           string              `json:"name"`
+	Slug           string              `json:"slug"`
+	File           pgtype.Text         `json:"file"`
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type ProductCard struct {
+	product_db.GetCollectionProductCardsRow
+	Files          []string              `json:"files"`
+	FileURLs       []string              `json:"file_urls"`
+	FileRenditions []*storage.Renditions `json:"file_renditions"`
+}
+
+type HotspotSummary struct {
+	product_db.GetHotspotRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
@@ -3,13 +3,17 @@ package page
 import (
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/modules/redirect"
+	"app/internal/storage"
+	"bytes"
 	"context"
-	"database/sql"
+	"errors"
 	"math"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
 )
 
 // GetPagesHandler godoc
@@ -62,7 +66,7 @@ func GetPagesHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  PageResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -78,7 +82,7 @@ func GetPageHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetPage(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "not found",
 			})
@@ -87,18 +91,25 @@ func GetPageHandler(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	res, err := pageResponse(ctx, result)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	return c.Status(fiber.StatusOK).JSON(res)
 }
 
 // GetPageBySlugHandler godoc
 // @Summary      Get a page
-// @Description  Returns a page by ID
+// @Description  Returns a published page by slug, with the collections, products and hotspots its blocks reference
 // @Tags         pages
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      string  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  PublicPageResponse
+// @Success      301  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -108,7 +119,58 @@ func GetPageBySlugHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetPageBySlug(ctx, param)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityPage, param)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	blocks, err := decodeBlocks(result.PublishedBlocks)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	resolved, err := resolveBlocks(ctx, blocks)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(PublicPageResponse{
+		ID:          result.ID,
+		Name:        result.Name,
+		Slug:        result.Slug,
+		Blocks:      resolved,
+		PublishedAt: result.PublishedAt,
+	})
+}
+
+// PreviewPageHandler godoc
+// @Summary      Preview a page
+// @Description  Returns the draft of a page as GetPageBySlugHandler would once published
+// @Tags         pages
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  PublicPageResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /pages/{id}/preview [get]
+func PreviewPageHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetPage(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "not found",
 			})
@@ -117,14 +179,45 @@ func GetPageBySlugHandler(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	blocks, err := decodeBlocks(result.DraftBlocks)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	resolved, err := resolveBlocks(ctx, blocks)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	return c.Status(fiber.StatusOK).JSON(PublicPageResponse{
+		ID:          result.ID,
+		Name:        result.Name,
+		Slug:        result.Slug,
+		Blocks:      resolved,
+		PublishedAt: result.PublishedAt,
+	})
+}
+
+// GetBlockSchemasHandler godoc
+// @Summary      Get block types
+// @Description  Returns the JSON Schema of the data of each page block type
+// @Tags         pages
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /pages/blocks [get]
+func GetBlockSchemasHandler(c *fiber.Ctx) error {
+	return c.JSON(blockSchemas)
 }
 
 // CreatePageHandler godoc
 // @Summary      Create a new page
 // @Description  Creates a new page and returns the created page
 // @Tags         pages
+// @Security BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        payload  body	CreatePageRequest  true  "Create data"
@@ -148,11 +241,24 @@ func CreatePageHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := ValidateBlocks(req.Blocks); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	blocks, err := encodeBlocks(req.Blocks)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	ctx := context.Background()
 
 	pageParams := product_db.CreatePageParams{
-		Name: req.Name,
-		Slug: req.Slug,
+		Name:        req.Name,
+		Slug:        req.Slug,
+		DraftBlocks: blocks,
 	}
 
 	pageID, err := db.ProductQueries.CreatePage(ctx, pageParams)
@@ -203,17 +309,160 @@ func UpdatePageHandler(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
+	current, err := db.ProductQueries.GetPage(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	params := product_db.UpdatePageParams{
 		ID:   id,
 		Name: req.Name,
 		Slug: req.Slug,
 	}
-	if err := db.ProductQueries.UpdatePage(ctx, params); err != nil {
+
+	tx, err := db.ProductDBPool.Begin(ctx)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+	defer tx.Rollback(ctx)
+	qtx := db.ProductQueries.WithTx(tx)
 
+	if err := qtx.UpdatePage(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := redirect.RecordSlugChange(ctx, qtx, redirect.EntityPage, current.Slug, req.Slug); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// UpdatePageBlocksHandler godoc
+// @Summary      Save page content
+// @Description  Replaces the draft blocks of a page. The storefront keeps showing the published blocks until the page is published again.
+// @Tags         pages
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Param        payload  body	UpdatePageBlocksRequest  true  "Blocks"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /pages/{id}/blocks [put]
+func UpdatePageBlocksHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req UpdatePageBlocksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if err := ValidateBlocks(req.Blocks); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	blocks, err := encodeBlocks(req.Blocks)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	n, err := db.ProductQueries.UpdatePageDraft(context.Background(), product_db.UpdatePageDraftParams{
+		ID:          id,
+		DraftBlocks: blocks,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "not found",
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// PublishPageHandler godoc
+// @Summary      Publish a page
+// @Description  Makes the draft blocks of a page the ones the storefront shows
+// @Tags         pages
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /pages/{id}/publish [post]
+func PublishPageHandler(c *fiber.Ctx) error {
+	return setPublished(c, db.ProductQueries.PublishPage)
+}
+
+// UnpublishPageHandler godoc
+// @Summary      Unpublish a page
+// @Description  Takes a page off the storefront. Its draft is kept.
+// @Tags         pages
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /pages/{id}/unpublish [post]
+func UnpublishPageHandler(c *fiber.Ctx) error {
+	return setPublished(c, db.ProductQueries.UnpublishPage)
+}
+
+func setPublished(c *fiber.Ctx, update func(context.Context, int64) (int64, error)) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	n, err := update(context.Background(), id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "not found",
+		})
+	}
 	return c.SendStatus(fiber.StatusOK)
 }
 
@@ -250,3 +499,30 @@ func BulkDeletePagesHandler(c *fiber.Ctx) error {
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
+
+func pageResponse(ctx context.Context, p product_db.GetPageRow) (PageResponse, error) {
+	draft, err := decodeBlocks(p.DraftBlocks)
+	if err != nil {
+		return PageResponse{}, err
+	}
+	published, err := decodeBlocks(p.PublishedBlocks)
+	if err != nil {
+		return PageResponse{}, err
+	}
+	var media storage.ImageSet
+	AddBlockFiles(&media, draft)
+	AddBlockFiles(&media, published)
+	if err := media.Load(ctx); err != nil {
+		return PageResponse{}, err
+	}
+	return PageResponse{
+		ID:                    p.ID,
+		Name:                  p.Name,
+		Slug:                  p.Slug,
+		DraftBlocks:           BlockResponses(draft, &media),
+		PublishedBlocks:       BlockResponses(published, &media),
+		PublishedAt:           p.PublishedAt,
+		UpdatedAt:             p.UpdatedAt,
+		HasUnpublishedChanges: !bytes.Equal(p.DraftBlocks, p.PublishedBlocks),
+	}, nil
+}
@@ -0,0 +1,158 @@
+package page
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+	"context"
+	"errors"
+
+	"github.com/goccy/go-json"
+	"github.com/jackc/pgx/v5"
+)
+
+// decodeBlocks reads a stored block list. NULL reads as nil.
+func decodeBlocks(raw []byte) ([]Block, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	blocks := []Block{}
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+func encodeBlocks(blocks []Block) ([]byte, error) {
+	if blocks == nil {
+		blocks = []Block{}
+	}
+	return json.Marshal(blocks)
+}
+
+// blockFile is the image a block shows, "" for blocks without one.
+func blockFile(b Block) string {
+	name, _ := b.Data["file"].(string)
+	return name
+}
+
+// AddBlockFiles queues the images of blocks for media.Load.
+func AddBlockFiles(media *storage.ImageSet, blocks []Block) {
+	for _, b := range blocks {
+		media.Add(blockFile(b))
+	}
+}
+
+// BlockResponses pairs blocks with the URLs and renditions of their images
+// once media has loaded them. Nil stays nil.
+func BlockResponses(blocks []Block, media *storage.ImageSet) []BlockResponse {
+	if blocks == nil {
+		return nil
+	}
+	res := make([]BlockResponse, len(blocks))
+	for i, b := range blocks {
+		name := blockFile(b)
+		res[i] = BlockResponse{
+			Block:          b,
+			FileURL:        config.FileURL(name),
+			FileRenditions: media.Renditions(name),
+		}
+	}
+	return res
+}
+
+// resolveBlocks loads what the blocks reference, and the URLs and
+// renditions of every image they show in one lookup. A collection or
+// hotspot deleted since the page was published is skipped rather than
+// failing the whole page.
+func resolveBlocks(ctx context.Context, blocks []Block) ([]ResolvedBlock, error) {
+	var media storage.ImageSet
+	resolved := make([]ResolvedBlock, len(blocks))
+	for i, b := range blocks {
+		r := ResolvedBlock{Type: b.Type, Data: b.Data}
+		media.Add(blockFile(b))
+		switch b.Type {
+		case BlockProductCarousel:
+			collectionID := intField(b.Data, "collection_id")
+			collection, err := db.ProductQueries.GetCollection(ctx, collectionID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return nil, err
+			}
+			if err == nil {
+				r.Collection = &CollectionSummary{
+					ID:   collection.ID,
+					Name: collection.Name,
+					Slug: collection.Slug,
+					File: collection.File,
+				}
+				media.Add(collection.File.String)
+				limit := intField(b.Data, "limit")
+				if limit == 0 {
+					limit = defaultCarouselLimit
+				}
+				products, err := db.ProductQueries.GetCollectionProductCards(ctx, product_db.GetCollectionProductCardsParams{
+					CollectionID: collectionID,
+					LimitCount:   int32(limit),
+				})
+				if err != nil {
+					return nil, err
+				}
+				r.Products = make([]ProductCard, len(products))
+				for j, p := range products {
+					r.Products[j].GetCollectionProductCardsRow = p
+					if err := storage.DecodeJSON(p.Files, &r.Products[j].Files); err != nil {
+						return nil, err
+					}
+					media.Add(r.Products[j].Files...)
+				}
+			}
+		case BlockLookbook:
+			ids, _ := b.Data["hotspot_ids"].([]any)
+			for _, v := range ids {
+				n, _ := v.(float64)
+				hotspot, err := db.ProductQueries.GetHotspot(ctx, int64(n))
+				if errors.Is(err, pgx.ErrNoRows) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				r.Hotspots = append(r.Hotspots, HotspotSummary{GetHotspotRow: hotspot})
+				media.Add(hotspot.File)
+			}
+		}
+		resolved[i] = r
+	}
+
+	if err := media.Load(ctx); err != nil {
+		return nil, err
+	}
+	for i := range resolved {
+		r := &resolved[i]
+		name := blockFile(blocks[i])
+		r.FileURL = config.FileURL(name)
+		r.FileRenditions = media.Renditions(name)
+		if r.Collection != nil {
+			r.Collection.FileURL = config.FileURL(r.Collection.File.String)
+			r.Collection.FileRenditions = media.Renditions(r.Collection.File.String)
+		}
+		for j := range r.Products {
+			p := &r.Products[j]
+			p.FileURLs = storage.URLs(p.Files)
+			p.FileRenditions = media.RenditionsOf(p.Files)
+		}
+		for j := range r.Hotspots {
+			h := &r.Hotspots[j]
+			h.FileURL = config.FileURL(h.File)
+			h.FileRenditions = media.Renditions(h.File)
+		}
+	}
+	return resolved, nil
+}
+
+// intField reads a whole number from validated block data, 0 when absent.
+func intField(data map[string]any, name string) int64 {
+	n, _ := data[name].(float64)
+	return int64(n)
+}
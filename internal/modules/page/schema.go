@@ -0,0 +1,148 @@
+package page
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// schema is the subset of JSON Schema the block definitions use: type,
+// properties, required, additionalProperties, items, enum, the length and
+// count bounds, minimum and maximum. Schemas are served as is to the admin
+// so it can build its block forms from them.
+type schema struct {
+	Type                 string             `json:"type"`
+	Title                string             `json:"title,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+// validate checks a value decoded by encoding/json against s. path names
+// the value in error messages, e.g. "blocks[2].data.items[0].question".
+func (s *schema) validate(path string, v any) error {
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s must be an object", path)
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s.%s is required", path, name)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			value := obj[name]
+			prop, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%s.%s is not allowed", path, name)
+				}
+				continue
+			}
+			if err := prop.validate(path+"."+name, value); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s must be an array", path)
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			return fmt.Errorf("%s must have at least %d items", path, *s.MinItems)
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			return fmt.Errorf("%s must have at most %d items", path, *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", path)
+		}
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			if *s.MinLength == 1 {
+				return fmt.Errorf("%s must not be empty", path)
+			}
+			return fmt.Errorf("%s must be at least %d characters", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fmt.Errorf("%s must be at most %d characters", path, *s.MaxLength)
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			return fmt.Errorf("%s must be one of: %s", path, strings.Join(s.Enum, ", "))
+		}
+	case "integer", "number":
+		n, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("%s must be a number", path)
+		}
+		if s.Type == "integer" && n != math.Trunc(n) {
+			return fmt.Errorf("%s must be a whole number", path)
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			return fmt.Errorf("%s must be at least %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return fmt.Errorf("%s must be at most %v", path, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s must be true or false", path)
+		}
+	}
+	return nil
+}
+
+// Helpers keeping the block definitions readable.
+
+func object(required []string, props map[string]*schema) *schema {
+	closed := false
+	return &schema{Type: "object", Properties: props, Required: required, AdditionalProperties: &closed}
+}
+
+func text(title string, min, max int) *schema {
+	s := &schema{Type: "string", Title: title, MaxLength: &max}
+	if min > 0 {
+		s.MinLength = &min
+	}
+	return s
+}
+
+func enum(title string, values ...string) *schema {
+	return &schema{Type: "string", Title: title, Enum: values}
+}
+
+func integer(title string, min, max float64) *schema {
+	return &schema{Type: "integer", Title: title, Minimum: &min, Maximum: &max}
+}
+
+// id is a positive integer referencing another entity.
+func id(title string) *schema {
+	min := 1.0
+	return &schema{Type: "integer", Title: title, Minimum: &min}
+}
+
+func list(items *schema, min, max int) *schema {
+	return &schema{Type: "array", Items: items, MinItems: &min, MaxItems: &max}
+}
@@ -0,0 +1,100 @@
+package post
+
+import (
+	"app/internal/db"
+	blog_db "app/internal/db/blog"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultCalendarDays is the range the calendar covers without a to date,
+// and maxCalendarDays the longest range it accepts.
+const (
+	defaultCalendarDays = 30
+	maxCalendarDays     = 366
+)
+
+// GetPostCalendarHandler godoc
+// @Summary      Get the editorial calendar
+// @Description  Returns scheduled posts grouped by publish date. Only dates with posts are listed.
+// @Tags         posts
+// @Security BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "First date (YYYY-MM-DD), default today"
+// @Param        to    query     string  false  "Last date (YYYY-MM-DD), default 30 days after from"
+// @Success      200  {array}   CalendarDay
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/calendar [get]
+func GetPostCalendarHandler(c *fiber.Ctx) error {
+	from := time.Now().Truncate(24 * time.Hour)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid from date",
+			})
+		}
+		from = t
+	}
+	to := from.AddDate(0, 0, defaultCalendarDays)
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid to date",
+			})
+		}
+		to = t
+	}
+	if to.Before(from) || to.Sub(from) > maxCalendarDays*24*time.Hour {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("to must be on or after from and at most %d days later", maxCalendarDays),
+		})
+	}
+
+	ctx := context.Background()
+	posts, err := db.BlogQueries.GetScheduledPosts(ctx, blog_db.GetScheduledPostsParams{
+		FromDate: pgtype.Date{Time: from, Valid: true},
+		ToDate:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	authorIDs := make([]pgtype.UUID, len(posts))
+	for i, p := range posts {
+		authorIDs[i] = p.AuthorID
+	}
+	authors, err := loadAuthors(ctx, authorIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Posts come ordered by publish time, so each date is one run of rows.
+	days := []CalendarDay{}
+	for _, p := range posts {
+		date := p.PublishDate.Time.Format(time.DateOnly)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, CalendarDay{Date: date})
+		}
+		day := &days[len(days)-1]
+		day.Posts = append(day.Posts, ScheduledPostItem{
+			ID:           p.ID,
+			Title:        p.Title,
+			Slug:         p.Slug,
+			Author:       authors[p.AuthorID],
+			CategoryName: p.CategoryName,
+			PublishedAt:  p.PublishedAt,
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(days)
+}
@@ -0,0 +1,183 @@
+package post
+
+import (
+	"app/internal/db"
+	blog_db "app/internal/db/blog"
+	"context"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPostCategoriesHandler godoc
+// @Summary      Get post categories
+// @Description  Returns all blog categories with their number of active posts
+// @Tags         posts
+// @Produce      json
+// @Success      200  {array}   blog_db.GetPostCategoriesRow
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/categories [get]
+func GetPostCategoriesHandler(c *fiber.Ctx) error {
+	ctx := context.Background()
+	result, err := db.BlogQueries.GetPostCategories(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if result == nil {
+		result = []blog_db.GetPostCategoriesRow{}
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// CreatePostCategoryHandler godoc
+// @Summary      Create a post category
+// @Description  Creates a blog category
+// @Tags         posts
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body	PostCategoryRequest  true  "Create data"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/categories [post]
+func CreatePostCategoryHandler(c *fiber.Ctx) error {
+	var req PostCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	id, err := db.BlogQueries.CreatePostCategory(ctx, blog_db.CreatePostCategoryParams{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id": id,
+	})
+}
+
+// UpdatePostCategoryHandler godoc
+// @Summary      Update a post category
+// @Description  Updates a blog category
+// @Tags         posts
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Param        payload  body	PostCategoryRequest  true  "Update data"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/categories/{id} [put]
+func UpdatePostCategoryHandler(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req PostCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	n, err := db.BlogQueries.UpdatePostCategory(ctx, blog_db.UpdatePostCategoryParams{
+		ID:          id,
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "not found",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// BulkDeletePostCategoriesHandler godoc
+// @Summary      Delete post categories
+// @Description  Deletes blog categories by their IDs. Their posts are left without a category.
+// @Tags         posts
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeletePostCategoriesRequest  true  "List of category IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/categories [delete]
+func BulkDeletePostCategoriesHandler(c *fiber.Ctx) error {
+	var req DeletePostCategoriesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	ctx := context.Background()
+	if err := db.BlogQueries.BulkDeletePostCategories(ctx, req.IDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// GetPostTagsHandler godoc
+// @Summary      Get post tags
+// @Description  Returns the tags of active posts with their number of posts
+// @Tags         posts
+// @Produce      json
+// @Success      200  {array}   blog_db.GetPostTagsRow
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/tags [get]
+func GetPostTagsHandler(c *fiber.Ctx) error {
+	ctx := context.Background()
+	result, err := db.BlogQueries.GetPostTags(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if result == nil {
+		result = []blog_db.GetPostTagsRow{}
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,191 @@
+package post
+
+import (
+	"app/internal/modules/page"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	FormatHTML   = "html"
+	FormatBlocks = "blocks"
+)
+
+// wordsPerMinute is the reading speed reading_time is estimated with.
+const wordsPerMinute = 200
+
+// excerptLength bounds the excerpt generated when none is given, in
+// characters.
+const excerptLength = 200
+
+// content is a post body ready to be stored.
+type content struct {
+	Body        string
+	Excerpt     string
+	ReadingTime int32
+}
+
+// prepareContent sanitises an HTML body, or validates a block body and
+// sanitises the HTML of its blocks, and fills in the reading time and, when excerpt is empty, an excerpt taken
+// from the start of the text.
+func prepareContent(format, body string, blocks []page.Block, excerpt string) (content, error) {
+	var c content
+	var text string
+	switch format {
+	case FormatBlocks:
+		if err := page.ValidateBlocks(blocks); err != nil {
+			return c, err
+		}
+		for _, b := range blocks {
+			// Post bodies are sanitised HTML, which raw markup would break.
+			if b.Type == page.BlockHTMLEmbed {
+				return c, errors.New("html_embed blocks are not allowed in posts")
+			}
+			if err := sanitizeBlockHTML(b.Data); err != nil {
+				return c, err
+			}
+		}
+		if blocks == nil {
+			blocks = []page.Block{}
+		}
+		raw, err := json.Marshal(blocks)
+		if err != nil {
+			return c, err
+		}
+		c.Body = string(raw)
+		var parts []string
+		for _, b := range blocks {
+			parts = append(parts, blockText(b.Data)...)
+		}
+		text = strings.Join(parts, " ")
+	case FormatHTML:
+		clean, t, err := sanitizeHTML(body)
+		if err != nil {
+			return c, err
+		}
+		c.Body, text = clean, t
+	default:
+		return c, errors.New("body_format must be one of: html, blocks")
+	}
+
+	words := len(strings.Fields(text))
+	if words > 0 {
+		c.ReadingTime = int32(math.Ceil(float64(words) / wordsPerMinute))
+	}
+	c.Excerpt = strings.TrimSpace(excerpt)
+	if c.Excerpt == "" {
+		c.Excerpt = truncateWords(text, excerptLength)
+	}
+	return c, nil
+}
+
+// textFields are the block data fields holding prose, in reading order.
+// Other fields hold references such as files, links and ids.
+var textFields = []string{"title", "question", "answer", "html", "caption", "alt"}
+
+// blockText collects the readable text of block data. HTML fields are
+// reduced to their text.
+func blockText(v any) []string {
+	switch v := v.(type) {
+	case map[string]any:
+		var parts []string
+		for _, name := range textFields {
+			if s, ok := v[name].(string); ok {
+				if name == "html" {
+					if _, t, err := sanitizeHTML(s); err == nil {
+						s = t
+					}
+				}
+				parts = append(parts, s)
+			}
+		}
+		for _, value := range v {
+			if items, ok := value.([]any); ok {
+				parts = append(parts, blockText(items)...)
+			}
+		}
+		return parts
+	case []any:
+		var parts []string
+		for _, item := range v {
+			parts = append(parts, blockText(item)...)
+		}
+		return parts
+	}
+	return nil
+}
+
+// sanitizeBlockHTML sanitises every html field of block data in place,
+// nested items included.
+func sanitizeBlockHTML(v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			if s, ok := value.(string); ok && name == "html" {
+				clean, _, err := sanitizeHTML(s)
+				if err != nil {
+					return err
+				}
+				v[name] = clean
+				continue
+			}
+			if err := sanitizeBlockHTML(value); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := sanitizeBlockHTML(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// truncateWords shortens s to at most max characters, cutting at a word
+// boundary and marking the cut with an ellipsis.
+func truncateWords(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)[:max]
+	cut := len(runes)
+	for i := len(runes) - 1; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsPunct) + "…"
+}
+
+// decodeBlocks reads a block body stored by prepareContent.
+func decodeBlocks(body string) []page.Block {
+	blocks := []page.Block{}
+	_ = json.Unmarshal([]byte(body), &blocks)
+	return blocks
+}
+
+func normalizeTag(t string) string {
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// normalizeTags normalizes and de-duplicates tags, dropping empty ones.
+func normalizeTags(tags []string) []string {
+	out := []string{}
+	for _, t := range tags {
+		t = normalizeTag(t)
+		if t != "" && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
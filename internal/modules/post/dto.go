@@ -1,5 +1,14 @@
 package post
 
+import (
+	blog_db "app/internal/db/blog"
+	"app/internal/modules/page"
+	"app/internal/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -8,18 +17,118 @@ type PaginatedResponse[T any] struct {
 	Data       []T   `json:"data"`
 }
 
+// CreatePostRequest holds HTML in Body when BodyFormat is "html" (the
+// default) and content blocks in Blocks when it is "blocks"; both are
+// sanitised, and html_embed blocks are rejected. AuthorID defaults to the
+// current user.
 type CreatePostRequest struct {
-	Title string `json:"title" validate:"required"`
-	Slug  string `json:"slug" validate:"required"`
-	File  string `json:"file"`
+	Title      string       `json:"title" validate:"required"`
+	Slug       string       `json:"slug" validate:"required"`
+	File       string       `json:"file"`
+	BodyFormat string       `json:"body_format" validate:"omitempty,oneof=html blocks" example:"html"`
+	Body       string       `json:"body"`
+	Blocks     []page.Block `json:"blocks"`
+	Excerpt    string       `json:"excerpt" validate:"max=1000"`
+	AuthorID   string       `json:"author_id" validate:"omitempty,uuid"`
+	CategoryID int64        `json:"category_id" validate:"min=0"`
+	Tags       []string     `json:"tags" validate:"dive,max=100"`
+	// PublishedAt defaults to now. A future date schedules the post.
+	PublishedAt *time.Time `json:"published_at" example:"2026-01-20T08:00:00Z"`
 }
 
 type UpdatePostRequest struct {
-	Title string `json:"title" validate:"required"`
-	Slug  string `json:"slug" validate:"required"`
-	File  string `json:"file"`
+	Title      string       `json:"title" validate:"required"`
+	Slug       string       `json:"slug" validate:"required"`
+	File       string       `json:"file"`
+	BodyFormat string       `json:"body_format" validate:"omitempty,oneof=html blocks" example:"html"`
+	Body       string       `json:"body"`
+	Blocks     []page.Block `json:"blocks"`
+	Excerpt    string       `json:"excerpt" validate:"max=1000"`
+	AuthorID   string       `json:"author_id" validate:"omitempty,uuid"`
+	CategoryID int64        `json:"category_id" validate:"min=0"`
+	Tags       []string     `json:"tags" validate:"dive,max=100"`
+	// PublishedAt reschedules the post when set. A past date publishes a
+	// scheduled post now.
+	PublishedAt *time.Time `json:"published_at" example:"2026-01-20T08:00:00Z"`
 }
 
 type DeletePostsRequest struct {
 	IDs []int64 `json:"ids"`
 }
+
+type Author struct {
+	ID   pgtype.UUID `json:"id"`
+	Name string      `json:"name"`
+}
+
+type Category struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// PostResponse is a post as the admin edits it.
+type PostResponse struct {
+	blog_db.GetPostRow
+	FileURL        string               `json:"file_url"`
+	FileRenditions *storage.Renditions  `json:"file_renditions"`
+	Blocks         []page.BlockResponse `json:"blocks,omitempty"`
+	Author         *Author              `json:"author"`
+}
+
+type PostItem struct {
+	blog_db.GetPostsRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type PublicPostSummary struct {
+	ID              int64               `json:"id"`
+	Title           string              `json:"title"`
+	Slug            string              `json:"slug"`
+	File            pgtype.Text         `json:"file"`
+	FileURL         string              `json:"file_url"`
+	FileRenditions  *storage.Renditions `json:"file_renditions"`
+	Excerpt         string              `json:"excerpt"`
+	ReadingTime     int32               `json:"reading_time"`
+	MetaTitle       string              `json:"meta_title"`
+	MetaDescription string              `json:"meta_description"`
+	Author          *Author             `json:"author"`
+	Category        *Category           `json:"category"`
+	Tags            []string            `json:"tags"`
+	PublishedAt     pgtype.Timestamp    `json:"published_at"`
+	CreatedAt       pgtype.Timestamp    `json:"created_at"`
+}
+
+// PublicPostResponse is a published post. Body is set for HTML posts,
+// Blocks for block posts.
+type PublicPostResponse struct {
+	PublicPostSummary
+	BodyFormat string               `json:"body_format"`
+	Body       string               `json:"body,omitempty"`
+	Blocks     []page.BlockResponse `json:"blocks,omitempty"`
+}
+
+type PostCategoryRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Slug        string `json:"slug" validate:"required"`
+	Description string `json:"description"`
+}
+
+type DeletePostCategoriesRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// CalendarDay lists the posts scheduled on one date.
+type CalendarDay struct {
+	Date  string              `json:"date" example:"2026-01-20"`
+	Posts []ScheduledPostItem `json:"posts"`
+}
+
+type ScheduledPostItem struct {
+	ID           int64            `json:"id"`
+	Title        string           `json:"title"`
+	Slug         string           `json:"slug"`
+	Author       *Author          `json:"author"`
+	CategoryName pgtype.Text      `json:"category_name"`
+	PublishedAt  pgtype.Timestamp `json:"published_at"`
+}
@@ -1,15 +1,22 @@
 package post
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	blog_db "app/internal/db/blog"
+	"app/internal/modules/page"
+	"app/internal/modules/redirect"
+	"app/internal/storage"
 	"context"
-	"database/sql"
+	"errors"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -21,7 +28,7 @@ import (
 // @Produce      json
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  PaginatedResponse[any]
+// @Success      200  {object}  PaginatedResponse[PostItem]
 // @Router       /posts [get]
 func GetPostsHandler(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -45,26 +52,45 @@ func GetPostsHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	var media storage.ImageSet
+	for _, p := range result {
+		media.Add(p.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]PostItem, len(result))
+	for i, p := range result {
+		data[i] = PostItem{
+			GetPostsRow:    p,
+			FileURL:        config.FileURL(p.File.String),
+			FileRenditions: media.Renditions(p.File.String),
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.JSON(PaginatedResponse[blog_db.GetPostsRow]{
+	return c.JSON(PaginatedResponse[PostItem]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       result,
+		Data:       data,
 	})
 }
 
 // GetPublicPostsHandler godoc
 // @Summary      Get post list
-// @Description  Returns a list of posts
+// @Description  Returns active posts, newest published first
 // @Tags         posts
-// @Security BearerAuth
 // @Produce      json
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  PaginatedResponse[any]
+// @Param        tag       query     string  false  "Tag filter"
+// @Param        category  query     string  false  "Category slug filter"
+// @Success      200  {object}  PaginatedResponse[PublicPostSummary]
 // @Router       /posts/public [get]
 func GetPublicPostsHandler(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -72,30 +98,79 @@ func GetPublicPostsHandler(c *fiber.Ctx) error {
 	offset := (page - 1) * pageSize
 
 	ctx := context.Background()
+	tag := optionalText(normalizeTag(c.Query("tag")))
+	category := optionalText(c.Query("category"))
 	result, err := db.BlogQueries.GetPublicPosts(ctx, blog_db.GetPublicPostsParams{
-		Limit:  int32(pageSize),
-		Offset: int32(offset),
+		Tag:         tag,
+		Category:    category,
+		LimitCount:  int32(pageSize),
+		OffsetCount: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.BlogQueries.CountPublicPosts(ctx, blog_db.CountPublicPostsParams{
+		Tag:      tag,
+		Category: category,
 	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-	total, err := db.BlogQueries.CountPublicPosts(ctx)
+
+	authorIDs := make([]pgtype.UUID, len(result))
+	for i, p := range result {
+		authorIDs[i] = p.AuthorID
+	}
+	authors, err := loadAuthors(ctx, authorIDs)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	var media storage.ImageSet
+	for _, p := range result {
+		media.Add(p.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	data := make([]PublicPostSummary, len(result))
+	for i, p := range result {
+		data[i] = PublicPostSummary{
+			ID:              p.ID,
+			Title:           p.Title,
+			Slug:            p.Slug,
+			File:            p.File,
+			FileURL:         config.FileURL(p.File.String),
+			FileRenditions:  media.Renditions(p.File.String),
+			Excerpt:         p.Excerpt,
+			ReadingTime:     p.ReadingTime,
+			MetaTitle:       p.MetaTitle,
+			MetaDescription: p.MetaDescription,
+			Author:          authors[p.AuthorID],
+			Category:        categoryOf(p.CategoryName, p.CategorySlug),
+			Tags:            p.Tags,
+			PublishedAt:     p.PublishedAt,
+			CreatedAt:       p.CreatedAt,
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.JSON(PaginatedResponse[blog_db.GetPublicPostsRow]{
+	return c.JSON(PaginatedResponse[PublicPostSummary]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       result,
+		Data:       data,
 	})
 }
 
@@ -106,7 +181,7 @@ func GetPublicPostsHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      int  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  PostResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -122,7 +197,7 @@ func GetPostHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.BlogQueries.GetPost(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "not found",
 			})
@@ -131,8 +206,37 @@ func GetPostHandler(c *fiber.Ctx) error {
 			"error": err.Error(),
 		})
 	}
+	authors, err := loadAuthors(ctx, []pgtype.UUID{result.AuthorID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	var blocks []page.Block
+	if result.BodyFormat == FormatBlocks {
+		blocks = decodeBlocks(result.Body)
+	}
+	var media storage.ImageSet
+	media.Add(result.File.String)
+	page.AddBlockFiles(&media, blocks)
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	response := PostResponse{
+		GetPostRow:     result,
+		FileURL:        config.FileURL(result.File.String),
+		FileRenditions: media.Renditions(result.File.String),
+		Blocks:         page.BlockResponses(blocks, &media),
+		Author:         authors[result.AuthorID],
+	}
+	if result.BodyFormat == FormatBlocks {
+		response.Body = ""
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // GetPostBySlugHandler godoc
@@ -142,7 +246,8 @@ func GetPostHandler(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Produce      json
 // @Param        id   path      string  true  "id"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  PublicPostResponse
+// @Success      301  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -152,17 +257,59 @@ func GetPostBySlugHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.BlogQueries.GetPostBySlug(ctx, param)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "not found",
-			})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityPost, param)
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
+	authors, err := loadAuthors(ctx, []pgtype.UUID{result.AuthorID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var blocks []page.Block
+	if result.BodyFormat == FormatBlocks {
+		blocks = decodeBlocks(result.Body)
+	}
+	var media storage.ImageSet
+	media.Add(result.File.String)
+	page.AddBlockFiles(&media, blocks)
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	return c.Status(fiber.StatusOK).JSON(result)
+	response := PublicPostResponse{
+		PublicPostSummary: PublicPostSummary{
+			ID:              result.ID,
+			Title:           result.Title,
+			Slug:            result.Slug,
+			File:            result.File,
+			FileURL:         config.FileURL(result.File.String),
+			FileRenditions:  media.Renditions(result.File.String),
+			Excerpt:         result.Excerpt,
+			ReadingTime:     result.ReadingTime,
+			MetaTitle:       result.MetaTitle,
+			MetaDescription: result.MetaDescription,
+			Author:          authors[result.AuthorID],
+			Category:        categoryOf(result.CategoryName, result.CategorySlug),
+			Tags:            result.Tags,
+			PublishedAt:     result.PublishedAt,
+			CreatedAt:       result.CreatedAt,
+		},
+		BodyFormat: result.BodyFormat,
+	}
+	if result.BodyFormat == FormatBlocks {
+		response.Blocks = page.BlockResponses(blocks, &media)
+	} else {
+		response.Body = result.Body
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // CreatePostHandler godoc
@@ -193,20 +340,54 @@ func CreatePostHandler(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
+	fields, status, err := postFields(ctx, c, req)
+	if err != nil {
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
 	postParams := blog_db.CreatePostParams{
-		Title: req.Title,
-		Slug:  req.Slug,
-		File:  pgtype.Text{String: req.File, Valid: true},
+		Title:       req.Title,
+		Slug:        req.Slug,
+		File:        pgtype.Text{String: req.File, Valid: true},
+		Body:        fields.Body,
+		BodyFormat:  fields.BodyFormat,
+		Excerpt:     fields.Excerpt,
+		AuthorID:    fields.AuthorID,
+		CategoryID:  fields.CategoryID,
+		ReadingTime: fields.ReadingTime,
+		PublishedAt: optionalTime(req.PublishedAt),
+	}
+
+	tx, err := db.BlogDBPool.Begin(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
 	}
+	defer tx.Rollback(ctx)
+	qtx := db.BlogQueries.WithTx(tx)
 
-	postID, err := db.BlogQueries.CreatePost(ctx, postParams)
+	postID, err := qtx.CreatePost(ctx, postParams)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	if err := saveTags(ctx, qtx, postID, req.Tags); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"id": postID,
 	})
@@ -248,13 +429,67 @@ func UpdatePostHandler(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
+	current, err := db.BlogQueries.GetPost(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	fields, status, err := postFields(ctx, c, CreatePostRequest(req))
+	if err != nil {
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	params := blog_db.UpdatePostParams{
-		ID:    id,
-		Title: req.Title,
-		Slug:  req.Slug,
-		File:  pgtype.Text{String: req.File, Valid: true},
+		ID:          id,
+		Title:       req.Title,
+		Slug:        req.Slug,
+		File:        pgtype.Text{String: req.File, Valid: true},
+		Body:        fields.Body,
+		BodyFormat:  fields.BodyFormat,
+		Excerpt:     fields.Excerpt,
+		AuthorID:    fields.AuthorID,
+		CategoryID:  fields.CategoryID,
+		ReadingTime: fields.ReadingTime,
+		PublishedAt: optionalTime(req.PublishedAt),
 	}
-	if err := db.BlogQueries.UpdatePost(ctx, params); err != nil {
+
+	tx, err := db.BlogDBPool.Begin(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	defer tx.Rollback(ctx)
+	qtx := db.BlogQueries.WithTx(tx)
+
+	if err := qtx.UpdatePost(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := redirect.RecordPostSlugChange(ctx, qtx, current.Slug, req.Slug); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := saveTags(ctx, qtx, id, req.Tags); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -296,3 +531,121 @@ func BulkDeletePostsHandler(c *fiber.Ctx) error {
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
+
+// storedFields are the stored columns derived from a create or update
+// request.
+type storedFields struct {
+	content
+	BodyFormat string
+	AuthorID   pgtype.UUID
+	CategoryID pgtype.Int8
+}
+
+// postFields prepares the body and checks the author and category. The
+// status is the one to answer with when err is set.
+func postFields(ctx context.Context, c *fiber.Ctx, req CreatePostRequest) (storedFields, int, error) {
+	f := storedFields{BodyFormat: req.BodyFormat}
+	if f.BodyFormat == "" {
+		f.BodyFormat = FormatHTML
+	}
+	var err error
+	f.content, err = prepareContent(f.BodyFormat, req.Body, req.Blocks, req.Excerpt)
+	if err != nil {
+		return f, fiber.StatusBadRequest, err
+	}
+
+	if req.AuthorID == "" {
+		f.AuthorID = currentUserID(c)
+	} else if err := f.AuthorID.Scan(req.AuthorID); err != nil {
+		return f, fiber.StatusBadRequest, errors.New("author_id must be a UUID")
+	}
+	if f.AuthorID.Valid {
+		authors, err := loadAuthors(ctx, []pgtype.UUID{f.AuthorID})
+		if err != nil {
+			return f, fiber.StatusInternalServerError, err
+		}
+		if authors[f.AuthorID] == nil {
+			return f, fiber.StatusBadRequest, errors.New("author not found")
+		}
+	}
+
+	if req.CategoryID != 0 {
+		if _, err := db.BlogQueries.GetPostCategory(ctx, req.CategoryID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return f, fiber.StatusBadRequest, errors.New("category not found")
+			}
+			return f, fiber.StatusInternalServerError, err
+		}
+		f.CategoryID = pgtype.Int8{Int64: req.CategoryID, Valid: true}
+	}
+	return f, 0, nil
+}
+
+// saveTags replaces the tags of a post through q, the transaction that
+// writes the post.
+func saveTags(ctx context.Context, q *blog_db.Queries, postID int64, tags []string) error {
+	if err := q.DeletePostTags(ctx, postID); err != nil {
+		return err
+	}
+	names := normalizeTags(tags)
+	if len(names) == 0 {
+		return nil
+	}
+	return q.InsertPostTags(ctx, blog_db.InsertPostTagsParams{
+		PostID: postID,
+		Names:  names,
+	})
+}
+
+// loadAuthors looks up the names of the given users in the auth database,
+// keyed by id. Unknown and NULL ids are left out.
+func loadAuthors(ctx context.Context, ids []pgtype.UUID) (map[pgtype.UUID]*Author, error) {
+	authors := map[pgtype.UUID]*Author{}
+	var known []pgtype.UUID
+	for _, id := range ids {
+		if id.Valid {
+			known = append(known, id)
+		}
+	}
+	if len(known) == 0 {
+		return authors, nil
+	}
+	users, err := db.AuthQueries.GetUserNames(ctx, known)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		authors[u.ID] = &Author{ID: u.ID, Name: u.Name}
+	}
+	return authors, nil
+}
+
+func currentUserID(c *fiber.Ctx) pgtype.UUID {
+	var id pgtype.UUID
+	if t, ok := c.Locals("user").(*jwt.Token); ok {
+		if claims, ok := t.Claims.(jwt.MapClaims); ok {
+			if sub, ok := claims["sub"].(string); ok {
+				_ = id.Scan(sub)
+			}
+		}
+	}
+	return id
+}
+
+func categoryOf(name, slug pgtype.Text) *Category {
+	if !name.Valid {
+		return nil
+	}
+	return &Category{Name: name.String, Slug: slug.String}
+}
+
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func optionalTime(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
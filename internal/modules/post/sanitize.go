@@ -0,0 +1,138 @@
+package post
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags are the elements kept in post bodies, with the attributes
+// each may carry. Other elements are unwrapped, keeping their text.
+var allowedTags = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil,
+	atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil, atom.S: nil,
+	atom.Sub: nil, atom.Sup: nil, atom.Span: nil,
+	atom.Blockquote: nil, atom.Code: nil, atom.Pre: nil,
+	atom.Ul: nil, atom.Ol: nil, atom.Li: nil,
+	atom.A:          {"href", "title", "target"},
+	atom.Img:        {"src", "alt", "title", "width", "height"},
+	atom.Figure:     nil,
+	atom.Figcaption: nil,
+	atom.Table:      nil, atom.Thead: nil, atom.Tbody: nil, atom.Tr: nil,
+	atom.Th: {"colspan", "rowspan"},
+	atom.Td: {"colspan", "rowspan"},
+}
+
+// droppedTags are removed along with everything inside them.
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Noscript: true, atom.Template: true, atom.Textarea: true,
+	atom.Select: true, atom.Svg: true, atom.Math: true, atom.Head: true, atom.Title: true,
+}
+
+// blockTags separate words when a body is reduced to text.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Li: true, atom.Blockquote: true, atom.Pre: true,
+	atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Td: true, atom.Th: true, atom.Figcaption: true, atom.Div: true,
+}
+
+// sanitizeHTML keeps only allowedTags and their attributes, and only links
+// that are relative or use http(s), mailto: or tel:. Links opening a new
+// tab get rel="noopener noreferrer". It also returns the text content.
+func sanitizeHTML(s string) (clean, text string, err error) {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	var out, txt strings.Builder
+	for _, n := range nodes {
+		writeNode(&out, &txt, n)
+	}
+	return strings.TrimSpace(out.String()), strings.Join(strings.Fields(txt.String()), " "), nil
+}
+
+func writeNode(out, txt *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		out.WriteString(html.EscapeString(n.Data))
+		txt.WriteString(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		// Comments and doctypes.
+		return
+	}
+	if droppedTags[n.DataAtom] {
+		return
+	}
+	attrs, allowed := allowedTags[n.DataAtom]
+	if blockTags[n.DataAtom] {
+		txt.WriteByte(' ')
+	}
+	if allowed {
+		out.WriteByte('<')
+		out.WriteString(n.Data)
+		newTab := false
+		for _, a := range n.Attr {
+			if a.Namespace != "" || !contains(attrs, a.Key) {
+				continue
+			}
+			if (a.Key == "href" || a.Key == "src") && !safeURL(a.Val) {
+				continue
+			}
+			if a.Key == "target" {
+				if a.Val != "_blank" {
+					continue
+				}
+				newTab = true
+			}
+			out.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+		}
+		if newTab {
+			out.WriteString(` rel="noopener noreferrer"`)
+		}
+		out.WriteByte('>')
+	}
+	if n.DataAtom == atom.Br || n.DataAtom == atom.Hr || n.DataAtom == atom.Img {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeNode(out, txt, c)
+	}
+	if allowed {
+		out.WriteString("</" + n.Data + ">")
+	}
+	if blockTags[n.DataAtom] {
+		txt.WriteByte(' ')
+	}
+}
+
+func safeURL(u string) bool {
+	u = strings.ToLower(strings.TrimSpace(u))
+	scheme, _, found := strings.Cut(u, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		// Relative, or the colon is past the scheme part.
+		return true
+	}
+	switch scheme {
+	case "http", "https", "mailto", "tel":
+		return true
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package productview
+
+import (
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+)
+
+type PaginatedResponse[T any] struct {
+	Page       int   `json:"page" example:"1"`
+	PageSize   int   `json:"page_size" example:"10"`
+	TotalItems int64 `json:"total_items" example:"125"`
+	TotalPages int   `json:"total_pages" example:"13"`
+	Data       []T   `json:"data"`
+}
+
+type RecentlyViewedProduct struct {
+	product_db.GetRecentlyViewedByCustomerRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type TrackViewRequest struct {
+	SessionID string `json:"session_id" validate:"max=64" example:"5f0c2a7e-3b9d-4c1e-9a55-1d2f3e4a5b6c"`
+}
@@ -0,0 +1,236 @@
+package productview
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+	"app/internal/token"
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	dateLayout         = "2006-01-02"
+	defaultReportDays  = 30
+	defaultRecentLimit = 20
+	maxRecentLimit     = 50
+)
+
+// trackViewMax is how many views one address may send per minute.
+const trackViewMax = 60
+
+// TrackViewLimiter rate limits TrackViewHandler per client address, so a
+// single client cannot flood the view queue.
+func TrackViewLimiter() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        trackViewMax,
+		Expiration: time.Minute,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "too many requests",
+			})
+		},
+	})
+}
+
+// TrackViewHandler godoc
+// @Summary      Track a product view
+// @Description  Queues a product view for the logged in customer (optional bearer token) or an anonymous session
+// @Tags         product-views
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int               true  "Product ID"
+// @Param        payload  body      TrackViewRequest  true  "View event"
+// @Success      202  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      429  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /products/{id}/views [post]
+func TrackViewHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	productID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req TrackViewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	customerID := token.OptionalCustomer(c)
+	if customerID == 0 && req.SessionID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "session_id is required for anonymous views",
+		})
+	}
+
+	queued := enqueue(view{
+		productID:  productID,
+		customerID: customerID,
+		sessionID:  req.SessionID,
+		viewedAt:   time.Now(),
+	})
+	if !queued {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "view queue is full",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+// GetRecentlyViewedHandler godoc
+// @Summary      Get recently viewed products
+// @Description  Returns the products the logged in customer viewed most recently
+// @Tags         product-views
+// @Security BearerAuth
+// @Produce      json
+// @Param        limit  query     int  false  "Number of products"  default(20)
+// @Success      200  {array}   RecentlyViewedProduct
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/recently-viewed [get]
+func GetRecentlyViewedHandler(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	id, ok := claims["id"].(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "customer token required",
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultRecentLimit)))
+	if limit <= 0 || limit > maxRecentLimit {
+		limit = defaultRecentLimit
+	}
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetRecentlyViewedByCustomer(ctx, product_db.GetRecentlyViewedByCustomerParams{
+		CustomerID: pgtype.Int8{Int64: int64(id), Valid: true},
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var media storage.ImageSet
+	for _, r := range result {
+		media.Add(r.File)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]RecentlyViewedProduct, len(result))
+	for i, r := range result {
+		data[i] = RecentlyViewedProduct{
+			GetRecentlyViewedByCustomerRow: r,
+			FileURL:                        config.FileURL(r.File),
+			FileRenditions:                 media.Renditions(r.File),
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(data)
+}
+
+// GetViewReportHandler godoc
+// @Summary      Product views vs orders
+// @Description  Returns views, unique visitors, orders and conversion rate per product for a date range (defaults to the last 30 days)
+// @Tags         product-views
+// @Security BearerAuth
+// @Produce      json
+// @Param        from       query     string  false  "From date (YYYY-MM-DD)"
+// @Param        to         query     string  false  "To date, inclusive (YYYY-MM-DD)"
+// @Param        page       query     int     false  "Page number"  default(1)
+// @Param        page_size  query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /product-views/report [get]
+func GetViewReportHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	to := time.Now().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid to date",
+			})
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+	from := to.AddDate(0, 0, -defaultReportDays)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid from date",
+			})
+		}
+		from = t
+	}
+
+	fromDate := pgtype.Timestamptz{Time: from, Valid: true}
+	toDate := pgtype.Timestamptz{Time: to, Valid: true}
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetProductViewReport(ctx, product_db.GetProductViewReportParams{
+		FromDate:    fromDate,
+		ToDate:      toDate,
+		LimitCount:  int32(pageSize),
+		OffsetCount: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountProductViewReport(ctx, product_db.CountProductViewReportParams{
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetProductViewReportRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
@@ -0,0 +1,101 @@
+package productview
+
+import (
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	queueSize     = 10000
+	batchSize     = 500
+	flushInterval = 5 * time.Second
+)
+
+type view struct {
+	productID  int64
+	customerID int64
+	sessionID  string
+	viewedAt   time.Time
+}
+
+var (
+	queue = make(chan view, queueSize)
+	done  = make(chan struct{})
+	wg    sync.WaitGroup
+)
+
+// Start runs the background writer that stores queued views in batches.
+func Start() {
+	wg.Add(1)
+	go run()
+}
+
+// Stop flushes the views still queued and waits for the writer to exit.
+func Stop() {
+	close(done)
+	wg.Wait()
+}
+
+// enqueue never blocks the request; views are dropped while the queue is
+// full.
+func enqueue(v view) bool {
+	select {
+	case queue <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+func run() {
+	defer wg.Done()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]view, 0, batchSize)
+	for {
+		select {
+		case v := <-queue:
+			batch = append(batch, v)
+			if len(batch) >= batchSize {
+				batch = flush(batch)
+			}
+		case <-ticker.C:
+			batch = flush(batch)
+		case <-done:
+			for {
+				select {
+				case v := <-queue:
+					batch = append(batch, v)
+				default:
+					flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+func flush(batch []view) []view {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	params := product_db.BulkInsertProductViewsParams{}
+	for _, v := range batch {
+		params.ProductIds = append(params.ProductIds, v.productID)
+		params.CustomerIds = append(params.CustomerIds, v.customerID)
+		params.SessionIds = append(params.SessionIds, v.sessionID)
+		params.ViewedAts = append(params.ViewedAts, pgtype.Timestamptz{Time: v.viewedAt, Valid: true})
+	}
+	if err := db.ProductQueries.BulkInsertProductViews(context.Background(), params); err != nil {
+		log.Printf("product views: dropped %d views: %v", len(batch), err)
+	}
+	return batch[:0]
+}
@@ -1,5 +1,12 @@
 package product
 
+import (
+	"app/internal/config"
+	product_db "app/internal/db/product"
+	"app/internal/modules/attribute"
+	"app/internal/storage"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -31,36 +38,86 @@ type VariantOption struct {
 }
 
 type OneVariant struct {
-	ID          int64           `json:"id"`
-	OriginPrice int32           `json:"origin_price"`
-	SalePrice   int32           `json:"sale_price"`
-	Stock       int32           `json:"stock"`
-	SKU         string          `json:"sku"`
-	File        string          `json:"file"`
-	Options     []VariantOption `json:"options"`
+	ID             int64               `json:"id"`
+	OriginPrice    int32               `json:"origin_price"`
+	SalePrice      int32               `json:"sale_price"`
+	Stock          int32               `json:"stock"`
+	SKU            string              `json:"sku"`
+	File           string              `json:"file"`
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+	Options        []VariantOption     `json:"options"`
 }
 
 type OneProductResponse struct {
-	ID              int64        `json:"id"`
-	Name            string       `json:"name"`
-	Slug            string       `json:"slug"`
-	OriginPrice     int32        `json:"origin_price"`
-	SalePrice       int32        `json:"sale_price"`
-	Stock           int32        `json:"stock"`
-	SKU             string       `json:"sku"`
-	Weight          int32        `json:"weight"`
-	Long            int32        `json:"long"`
-	Wide            int32        `json:"wide"`
-	High            int32        `json:"high"`
-	MetaTitle       string       `json:"meta_title"`
-	MetaDescription string       `json:"meta_description"`
-	IsActive        bool         `json:"is_active"`
-	CategoryID      *int64       `json:"category_id"`
-	Files           any          `json:"files"`
-	Tags            any          `json:"tags"`
-	Options         []Option     `json:"options"`
-	Variants        []OneVariant `json:"variants"`
-	Collections     any          `json:"collections"`
+	ID               int64                    `json:"id"`
+	Name             string                   `json:"name"`
+	Slug             string                   `json:"slug"`
+	OriginPrice      int32                    `json:"origin_price"`
+	SalePrice        int32                    `json:"sale_price"`
+	Stock            int32                    `json:"stock"`
+	SKU              string                   `json:"sku"`
+	Weight           int32                    `json:"weight"`
+	Long             int32                    `json:"long"`
+	Wide             int32                    `json:"wide"`
+	High             int32                    `json:"high"`
+	MetaTitle        string                   `json:"meta_title"`
+	MetaDescription  string                   `json:"meta_description"`
+	IsActive         bool                     `json:"is_active"`
+	Brand            string                   `json:"brand"`
+	Gtin             string                   `json:"gtin"`
+	ExcludeFromFeeds bool                     `json:"exclude_from_feeds"`
+	CategoryID       *int64                   `json:"category_id"`
+	Files            []string                 `json:"files"`
+	FileURLs         []string                 `json:"file_urls"`
+	FileRenditions   []*storage.Renditions    `json:"file_renditions"`
+	Tags             any                      `json:"tags"`
+	Options          []Option                 `json:"options"`
+	Variants         []OneVariant             `json:"variants"`
+	Collections      any                      `json:"collections"`
+	Attributes       []attribute.ProductValue `json:"attributes"`
+}
+
+type ProductItem struct {
+	product_db.GetProductsRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+// StoreVariant is a variant as the storefront queries aggregate it into a
+// json column.
+type StoreVariant struct {
+	ID             int64               `json:"id"`
+	Sku            string              `json:"sku"`
+	Stock          *int32              `json:"stock,omitempty"`
+	OriginPrice    int32               `json:"origin_price"`
+	SalePrice      int32               `json:"sale_price"`
+	File           string              `json:"file"`
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+	Options        map[string]string   `json:"options"`
+}
+
+// SetFile fills in the URL and renditions of the variant file once m has
+// loaded it.
+func (v *StoreVariant) SetFile(m *storage.ImageSet) {
+	v.FileURL = config.FileURL(v.File)
+	v.FileRenditions = m.Renditions(v.File)
+}
+
+type ProductDetailResponse struct {
+	product_db.GetProductBySlugRow
+	Files          []string              `json:"files"`
+	FileURLs       []string              `json:"file_urls"`
+	FileRenditions []*storage.Renditions `json:"file_renditions"`
+	Variants       []StoreVariant        `json:"variants"`
+}
+
+type CategoryProduct struct {
+	product_db.GetProductsByCategoryRow
+	Files          []string              `json:"files"`
+	FileURLs       []string              `json:"file_urls"`
+	FileRenditions []*storage.Renditions `json:"file_renditions"`
 }
 
 type CreateVariant struct {
@@ -89,24 +146,28 @@ type ProductFiles struct {
 }
 
 type CreateProductRequest struct {
-	Name            string          `json:"name" validate:"required"`
-	Slug            string          `json:"slug" validate:"required"`
-	OriginPrice     int32           `json:"origin_price" validate:"gte=0"`
-	SalePrice       int32           `json:"sale_price" validate:"gte=0"`
-	Stock           int32           `json:"stock"`
-	SKU             string          `json:"sku"`
-	Weight          int32           `json:"weight"`
-	Long            int32           `json:"long"`
-	Wide            int32           `json:"wide"`
-	High            int32           `json:"high"`
-	MetaTitle       string          `json:"meta_title"`
-	MetaDescription string          `json:"meta_description"`
-	CategoryID      int64           `json:"category_id"`
-	Tags            []string        `json:"tags"`
-	Files           []ProductFiles  `json:"files"`
-	CollectionIDs   []int64         `json:"collection_ids"`
-	Options         []CreateOptions `json:"options"`
-	Variants        []CreateVariant `json:"variants"`
+	Name             string            `json:"name" validate:"required"`
+	Slug             string            `json:"slug" validate:"required"`
+	OriginPrice      int32             `json:"origin_price" validate:"gte=0"`
+	SalePrice        int32             `json:"sale_price" validate:"gte=0"`
+	Stock            int32             `json:"stock"`
+	SKU              string            `json:"sku"`
+	Weight           int32             `json:"weight"`
+	Long             int32             `json:"long"`
+	Wide             int32             `json:"wide"`
+	High             int32             `json:"high"`
+	MetaTitle        string            `json:"meta_title"`
+	MetaDescription  string            `json:"meta_description"`
+	Brand            string            `json:"brand"`
+	Gtin             string            `json:"gtin"`
+	ExcludeFromFeeds bool              `json:"exclude_from_feeds"`
+	CategoryID       int64             `json:"category_id"`
+	Tags             []string          `json:"tags"`
+	Files            []ProductFiles    `json:"files"`
+	CollectionIDs    []int64           `json:"collection_ids"`
+	Options          []CreateOptions   `json:"options"`
+	Variants         []CreateVariant   `json:"variants"`
+	Attributes       []attribute.Value `json:"attributes" validate:"dive"`
 }
 
 type UpdateOptionValue struct {
@@ -131,24 +192,28 @@ type UpdateVariants struct {
 }
 
 type UpdateProductRequest struct {
-	Name            string           `json:"name" validate:"required"`
-	Slug            string           `json:"slug" validate:"required"`
-	OriginPrice     int32            `json:"origin_price" validate:"gte=0"`
-	SalePrice       int32            `json:"sale_price" validate:"gte=0"`
-	Stock           int32            `json:"stock"`
-	SKU             string           `json:"sku"`
-	Weight          int32            `json:"weight"`
-	Long            int32            `json:"long"`
-	Wide            int32            `json:"wide"`
-	High            int32            `json:"high"`
-	MetaTitle       string           `json:"meta_title"`
-	MetaDescription string           `json:"meta_description"`
-	CategoryID      int64            `json:"category_id"`
-	Tags            []string         `json:"tags"`
-	Files           []ProductFiles   `json:"files"`
-	CollectionIDs   []int64          `json:"collection_ids"`
-	Options         []UpdateOptions  `json:"options"`
-	Variants        []UpdateVariants `json:"variants"`
+	Name             string            `json:"name" validate:"required"`
+	Slug             string            `json:"slug" validate:"required"`
+	OriginPrice      int32             `json:"origin_price" validate:"gte=0"`
+	SalePrice        int32             `json:"sale_price" validate:"gte=0"`
+	Stock            int32             `json:"stock"`
+	SKU              string            `json:"sku"`
+	Weight           int32             `json:"weight"`
+	Long             int32             `json:"long"`
+	Wide             int32             `json:"wide"`
+	High             int32             `json:"high"`
+	MetaTitle        string            `json:"meta_title"`
+	MetaDescription  string            `json:"meta_description"`
+	Brand            string            `json:"brand"`
+	Gtin             string            `json:"gtin"`
+	ExcludeFromFeeds bool              `json:"exclude_from_feeds"`
+	CategoryID       int64             `json:"category_id"`
+	Tags             []string          `json:"tags"`
+	Files            []ProductFiles    `json:"files"`
+	CollectionIDs    []int64           `json:"collection_ids"`
+	Options          []UpdateOptions   `json:"options"`
+	Variants         []UpdateVariants  `json:"variants"`
+	Attributes       []attribute.Value `json:"attributes" validate:"dive"`
 }
 
 type DeleteProductsRequest struct {
@@ -1,14 +1,21 @@
 package product
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/modules/attribute"
+	"app/internal/modules/redirect"
+	"app/internal/modules/wishlist"
+	"app/internal/storage"
 	"context"
+	"errors"
 	"math"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -20,7 +27,9 @@ import (
 // @Produce      json
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  PaginatedResponse[any]
+// @Param        attr[code] query    string  false  "Attribute filter: comma separated values, or attr[code][min] / attr[code][max] for numbers"
+// @Success      200  {object}  PaginatedResponse[ProductItem]
+// @Failure      400  {object}  map[string]string
 // @Router       /products [get]
 func GetProductsHandler(c *fiber.Ctx) error {
 
@@ -28,30 +37,59 @@ func GetProductsHandler(c *fiber.Ctx) error {
 	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
 	offset := (page - 1) * pageSize
 
+	attributeFilters, err := attribute.ParseFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	ctx := context.Background()
 
-	results, err := db.ProductQueries.GetProducts(ctx, product_db.GetProductsParams{Limit: int32(pageSize), Offset: int32(offset)})
+	results, err := db.ProductQueries.GetProducts(ctx, product_db.GetProductsParams{
+		AttributeFilters: attributeFilters,
+		Limit:            int32(pageSize),
+		Offset:           int32(offset),
+	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	total, err := db.ProductQueries.CountProducts(c.Context())
+	total, err := db.ProductQueries.CountProducts(c.Context(), attributeFilters)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	var media storage.ImageSet
+	for _, r := range results {
+		media.Add(r.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]ProductItem, len(results))
+	for i, r := range results {
+		data[i] = ProductItem{
+			GetProductsRow: r,
+			FileURL:        config.FileURL(r.File.String),
+			FileRenditions: media.Renditions(r.File.String),
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.JSON(PaginatedResponse[product_db.GetProductsRow]{
+	return c.JSON(PaginatedResponse[ProductItem]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       results,
+		Data:       data,
 	})
 }
 
@@ -83,7 +121,11 @@ func GetProductHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	files, _ := db.ProductQueries.GetFilesByProductID(ctx, product.ID)
+	fileRows, _ := db.ProductQueries.GetFilesByProductID(ctx, product.ID)
+	files := make([]string, len(fileRows))
+	for i, f := range fileRows {
+		files[i] = f.String
+	}
 	tags, _ := db.ProductQueries.GetTagsByProductID(ctx, product.ID)
 	optionsDB, _ := db.ProductQueries.GetOptionsByProductID(ctx, product.ID)
 
@@ -117,6 +159,17 @@ func GetProductHandler(c *fiber.Ctx) error {
 
 	variantsDB, _ := db.ProductQueries.GetVariantsByProductID(ctx, product.ID)
 
+	var media storage.ImageSet
+	media.Add(files...)
+	for _, v := range variantsDB {
+		media.Add(v.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	variantIDs := make([]int64, len(variantsDB))
 	for i, v := range variantsDB {
 		variantIDs[i] = v.ID
@@ -137,39 +190,54 @@ func GetProductHandler(c *fiber.Ctx) error {
 	variants := make([]OneVariant, len(variantsDB))
 	for i, v := range variantsDB {
 		variants[i] = OneVariant{
-			ID:          v.ID,
-			SKU:         v.Sku,
-			OriginPrice: v.OriginPrice,
-			SalePrice:   v.SalePrice,
-			Stock:       v.Stock,
-			Options:     optionsMap[v.ID],
-			File:        v.File.String,
+			ID:             v.ID,
+			SKU:            v.Sku,
+			OriginPrice:    v.OriginPrice,
+			SalePrice:      v.SalePrice,
+			Stock:          v.Stock,
+			Options:        optionsMap[v.ID],
+			File:           v.File.String,
+			FileURL:        config.FileURL(v.File.String),
+			FileRenditions: media.Renditions(v.File.String),
 		}
 	}
 
 	collections, _ := db.ProductQueries.GetCollectionsByProductID(ctx, id)
 
+	attributes, err := attribute.GetProductValues(ctx, product.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(OneProductResponse{
-		ID:              product.ID,
-		Name:            product.Name,
-		Slug:            product.Slug,
-		OriginPrice:     product.OriginPrice,
-		SalePrice:       product.SalePrice,
-		Stock:           product.Stock.Int32,
-		SKU:             product.Sku.String,
-		Weight:          product.Weight.Int32,
-		Long:            product.Long.Int32,
-		Wide:            product.Wide.Int32,
-		High:            product.High.Int32,
-		MetaTitle:       product.MetaTitle,
-		MetaDescription: product.MetaDescription,
-		CategoryID:      &product.CategoryID.Int64,
-		Files:           files,
-		Tags:            tags,
-		Options:         optionsWithValues,
-		Variants:        variants,
-		Collections:     collections,
-		IsActive:        product.IsActive,
+		ID:               product.ID,
+		Name:             product.Name,
+		Slug:             product.Slug,
+		OriginPrice:      product.OriginPrice,
+		SalePrice:        product.SalePrice,
+		Stock:            product.Stock.Int32,
+		SKU:              product.Sku.String,
+		Weight:           product.Weight.Int32,
+		Long:             product.Long.Int32,
+		Wide:             product.Wide.Int32,
+		High:             product.High.Int32,
+		MetaTitle:        product.MetaTitle,
+		MetaDescription:  product.MetaDescription,
+		Brand:            product.Brand,
+		Gtin:             product.Gtin,
+		ExcludeFromFeeds: product.ExcludeFromFeeds,
+		CategoryID:       &product.CategoryID.Int64,
+		Files:            files,
+		FileURLs:         storage.URLs(files),
+		FileRenditions:   media.RenditionsOf(files),
+		Tags:             tags,
+		Options:          optionsWithValues,
+		Variants:         variants,
+		Collections:      collections,
+		Attributes:       attributes,
+		IsActive:         product.IsActive,
 	})
 }
 
@@ -179,7 +247,8 @@ func GetProductHandler(c *fiber.Ctx) error {
 // @Tags         products
 // @Produce      json
 // @Param        slug   path      string  true  "Product slug"
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  ProductDetailResponse
+// @Success      301  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -189,11 +258,41 @@ func GetProductBySlugHandler(c *fiber.Ctx) error {
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetProductBySlug(ctx, param)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityProduct, param)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-	return c.Status(fiber.StatusOK).JSON(result)
+
+	res := ProductDetailResponse{GetProductBySlugRow: result}
+	if err := storage.DecodeJSON(result.Files, &res.Files); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := storage.DecodeJSON(result.Variants, &res.Variants); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	var media storage.ImageSet
+	media.Add(res.Files...)
+	for _, v := range res.Variants {
+		media.Add(v.File)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	res.FileURLs = storage.URLs(res.Files)
+	res.FileRenditions = media.RenditionsOf(res.Files)
+	for i := range res.Variants {
+		res.Variants[i].SetFile(&media)
+	}
+	return c.Status(fiber.StatusOK).JSON(res)
 }
 
 // GetProductByCategoryHandler godoc
@@ -204,7 +303,8 @@ func GetProductBySlugHandler(c *fiber.Ctx) error {
 // @Param        id   path      string  true  "Category id"
 // @Param        page      query     int     false  "Page number"  default(1)
 // @Param        page_size query     int     false  "Page size"    default(10)
-// @Success      200  {object}  map[string]string
+// @Param        attr[code] query    string  false  "Attribute filter: comma separated values, or attr[code][min] / attr[code][max] for numbers"
+// @Success      200  {object}  PaginatedResponse[CategoryProduct]
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -216,32 +316,64 @@ func GetProductByCategoryHandler(c *fiber.Ctx) error {
 	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
 	offset := (page - 1) * pageSize
 
+	attributeFilters, err := attribute.ParseFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	ctx := context.Background()
 	result, err := db.ProductQueries.GetProductsByCategory(ctx, product_db.GetProductsByCategoryParams{
-		CategoryID: pgtype.Int8{Int64: id, Valid: true},
-		Limit:      int32(pageSize),
-		Offset:     int32(offset),
+		CategoryID:       pgtype.Int8{Int64: id, Valid: true},
+		AttributeFilters: attributeFilters,
+		Limit:            int32(pageSize),
+		Offset:           int32(offset),
 	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-	total, err := db.ProductQueries.CountProductsByCategory(ctx, pgtype.Int8{Int64: id, Valid: true})
+	total, err := db.ProductQueries.CountProductsByCategory(ctx, product_db.CountProductsByCategoryParams{
+		CategoryID:       pgtype.Int8{Int64: id, Valid: true},
+		AttributeFilters: attributeFilters,
+	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
+	var media storage.ImageSet
+	data := make([]CategoryProduct, len(result))
+	for i, r := range result {
+		data[i].GetProductsByCategoryRow = r
+		if err := storage.DecodeJSON(r.Files, &data[i].Files); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		media.Add(data[i].Files...)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for i := range data {
+		data[i].FileURLs = storage.URLs(data[i].Files)
+		data[i].FileRenditions = media.RenditionsOf(data[i].Files)
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.Status(fiber.StatusOK).JSON(PaginatedResponse[product_db.GetProductsByCategoryRow]{
+	return c.Status(fiber.StatusOK).JSON(PaginatedResponse[CategoryProduct]{
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
 		TotalPages: totalPages,
-		Data:       result,
+		Data:       data,
 	})
 }
 
@@ -275,6 +407,19 @@ func CreateProductHandler(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
+	attributes, err := attribute.Validate(ctx, req.CategoryID, req.Attributes)
+	if err != nil {
+		var validationErr *attribute.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	params := product_db.CreateProductParams{
 		Name:        req.Name,
 		Slug:        req.Slug,
@@ -293,8 +438,11 @@ func CreateProductHandler(c *fiber.Ctx) error {
 			Int64: req.CategoryID,
 			Valid: req.CategoryID > 0,
 		},
-		MetaTitle:       req.MetaTitle,
-		MetaDescription: req.MetaDescription,
+		MetaTitle:        req.MetaTitle,
+		MetaDescription:  req.MetaDescription,
+		Brand:            req.Brand,
+		Gtin:             req.Gtin,
+		ExcludeFromFeeds: req.ExcludeFromFeeds,
 	}
 
 	productID, err := db.ProductQueries.CreateProduct(ctx, params)
@@ -421,6 +569,12 @@ func CreateProductHandler(c *fiber.Ctx) error {
 		}
 	}
 
+	if err := attribute.SaveProductValues(ctx, productID, attributes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	return c.SendStatus(fiber.StatusCreated)
 }
 
@@ -459,6 +613,31 @@ func UpdateProductHandler(c *fiber.Ctx) error {
 		})
 	}
 	ctx := context.Background()
+	current, err := db.ProductQueries.GetProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	attributes, err := attribute.Validate(ctx, req.CategoryID, req.Attributes)
+	if err != nil {
+		var validationErr *attribute.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	params := product_db.UpdateProductParams{
 		ID:          productID,
 		Name:        req.Name,
@@ -471,14 +650,39 @@ func UpdateProductHandler(c *fiber.Ctx) error {
 			Int32: req.Weight,
 			Valid: true,
 		},
-		Long:            pgtype.Int4{Int32: req.Long, Valid: true},
-		Wide:            pgtype.Int4{Int32: req.Wide, Valid: true},
-		High:            pgtype.Int4{Int32: req.High, Valid: true},
-		CategoryID:      pgtype.Int8{Int64: req.CategoryID, Valid: req.CategoryID > 0},
-		MetaTitle:       req.MetaTitle,
-		MetaDescription: req.MetaDescription,
-	}
-	if err := db.ProductQueries.UpdateProduct(ctx, params); err != nil {
+		Long:             pgtype.Int4{Int32: req.Long, Valid: true},
+		Wide:             pgtype.Int4{Int32: req.Wide, Valid: true},
+		High:             pgtype.Int4{Int32: req.High, Valid: true},
+		CategoryID:       pgtype.Int8{Int64: req.CategoryID, Valid: req.CategoryID > 0},
+		MetaTitle:        req.MetaTitle,
+		MetaDescription:  req.MetaDescription,
+		Brand:            req.Brand,
+		Gtin:             req.Gtin,
+		ExcludeFromFeeds: req.ExcludeFromFeeds,
+	}
+
+	tx, err := db.ProductDBPool.Begin(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	defer tx.Rollback(ctx)
+	qtx := db.ProductQueries.WithTx(tx)
+
+	if err := qtx.UpdateProduct(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := redirect.RecordSlugChange(ctx, qtx, redirect.EntityProduct, current.Slug, req.Slug); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -718,6 +922,18 @@ func UpdateProductHandler(c *fiber.Ctx) error {
 
 		db.ProductQueries.BulkInsertVariantOption(ctx, createVariantOptionParams)
 	}
+
+	if err := attribute.SaveProductValues(ctx, productID, attributes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := wishlist.RecordPriceDrops(ctx, productID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 	return c.SendStatus(fiber.StatusOK)
 }
 
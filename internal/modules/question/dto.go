@@ -0,0 +1,41 @@
+package question
+
+import product_db "app/internal/db/product"
+
+type PaginatedResponse[T any] struct {
+	Page       int   `json:"page" example:"1"`
+	PageSize   int   `json:"page_size" example:"10"`
+	TotalItems int64 `json:"total_items" example:"125"`
+	TotalPages int   `json:"total_pages" example:"13"`
+	Data       []T   `json:"data"`
+}
+
+type CreateQuestionRequest struct {
+	Question string `json:"question" validate:"required,max=1000" example:"inox 304 hay 201?"`
+}
+
+type AnswerQuestionRequest struct {
+	Answer string `json:"answer" validate:"required"`
+	Status string `json:"status" validate:"omitempty,oneof=pending published rejected" example:"published"`
+}
+
+type UpdateQuestionStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending published rejected" example:"published"`
+}
+
+type DeleteQuestionsRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// Customer is the customer json column of GetPublishedQuestionsByProduct.
+type Customer struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Avatar    *string `json:"avatar"`
+	AvatarURL string  `json:"avatar_url"`
+}
+
+type PublishedQuestion struct {
+	product_db.GetPublishedQuestionsByProductRow
+	Customer *Customer `json:"customer"`
+}
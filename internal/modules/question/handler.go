@@ -0,0 +1,413 @@
+package question
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	StatusRejected  = "rejected"
+)
+
+// GetQuestionsByProductHandler godoc
+// @Summary      Get product questions
+// @Description  Returns published questions and answers for a product
+// @Tags         questions
+// @Produce      json
+// @Param        id          path      int   true   "Product ID"
+// @Param        page        query     int   false  "Page number"  default(1)
+// @Param        page_size   query     int   false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[PublishedQuestion]
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /products/{id}/questions [get]
+func GetQuestionsByProductHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetPublishedQuestionsByProduct(ctx, product_db.GetPublishedQuestionsByProductParams{
+		ProductID: id,
+		Limit:     int32(pageSize),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountPublishedQuestionsByProduct(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	data := make([]PublishedQuestion, len(result))
+	for i, r := range result {
+		data[i].GetPublishedQuestionsByProductRow = r
+		if err := storage.DecodeJSON(r.Customer, &data[i].Customer); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if data[i].Customer != nil && data[i].Customer.Avatar != nil {
+			data[i].Customer.AvatarURL = config.FileURL(*data[i].Customer.Avatar)
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[PublishedQuestion]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       data,
+	})
+}
+
+// CreateQuestionHandler godoc
+// @Summary      Ask a question
+// @Description  Posts a question about a product as the logged in customer. The question is held for moderation.
+// @Tags         questions
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "Product ID"
+// @Param        payload  body      CreateQuestionRequest  true  "Question"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /products/{id}/questions [post]
+func CreateQuestionHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	productID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	customerID, ok := claims["id"].(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "customer token required",
+		})
+	}
+
+	var req CreateQuestionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	req.Question = strings.TrimSpace(req.Question)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	questionID, err := db.ProductQueries.CreateQuestion(ctx, product_db.CreateQuestionParams{
+		ProductID:  productID,
+		CustomerID: int64(customerID),
+		Question:   req.Question,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      questionID,
+		"status":  StatusPending,
+		"message": "question submitted for moderation",
+	})
+}
+
+// GetQuestionsHandler godoc
+// @Summary      Get question list
+// @Description  Returns product questions for moderation, optionally filtered by status and product
+// @Tags         questions
+// @Security BearerAuth
+// @Produce      json
+// @Param        page        query     int     false  "Page number"  default(1)
+// @Param        page_size   query     int     false  "Page size"    default(10)
+// @Param        status      query     string  false  "pending, published or rejected"
+// @Param        product_id  query     int     false  "Product ID"
+// @Success      200  {object}  PaginatedResponse[any]
+// @Failure      500  {object}  map[string]string
+// @Router       /questions [get]
+func GetQuestionsHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	var status pgtype.Text
+	if s := c.Query("status"); s != "" {
+		status = pgtype.Text{String: s, Valid: true}
+	}
+
+	var productID pgtype.Int8
+	if p := c.Query("product_id"); p != "" {
+		if val, err := strconv.ParseInt(p, 10, 64); err == nil {
+			productID = pgtype.Int8{Int64: val, Valid: true}
+		}
+	}
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetQuestions(ctx, product_db.GetQuestionsParams{
+		Status:      status,
+		ProductID:   productID,
+		LimitCount:  int32(pageSize),
+		OffsetCount: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountQuestions(ctx, product_db.CountQuestionsParams{
+		Status:    status,
+		ProductID: productID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetQuestionsRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+// GetQuestionHandler godoc
+// @Summary      Get a question
+// @Description  Returns a product question by ID
+// @Tags         questions
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /questions/{id} [get]
+func GetQuestionHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetQuestion(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// AnswerQuestionHandler godoc
+// @Summary      Answer a question
+// @Description  Sets the answer for a question and publishes it unless another status is given
+// @Tags         questions
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "id"
+// @Param        payload  body      AnswerQuestionRequest  true  "Answer"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /questions/{id}/answer [put]
+func AnswerQuestionHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req AnswerQuestionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	req.Answer = strings.TrimSpace(req.Answer)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if req.Status == "" {
+		req.Status = StatusPublished
+	}
+
+	ctx := context.Background()
+	if _, err := db.ProductQueries.GetQuestion(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	err = db.ProductQueries.AnswerQuestion(ctx, product_db.AnswerQuestionParams{
+		ID:     id,
+		Answer: pgtype.Text{String: req.Answer, Valid: true},
+		Status: req.Status,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"id":     id,
+		"status": req.Status,
+	})
+}
+
+// UpdateQuestionStatusHandler godoc
+// @Summary      Moderate a question
+// @Description  Publishes, rejects or returns a question to pending
+// @Tags         questions
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                          true  "id"
+// @Param        payload  body      UpdateQuestionStatusRequest  true  "Status"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /questions/{id}/status [put]
+func UpdateQuestionStatusHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req UpdateQuestionStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	err = db.ProductQueries.UpdateQuestionStatus(ctx, product_db.UpdateQuestionStatusParams{
+		ID:     id,
+		Status: req.Status,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"id":     id,
+		"status": req.Status,
+	})
+}
+
+// BulkDeleteQuestionsHandler godoc
+// @Summary      Delete multiple questions
+// @Description  Deletes multiple product questions by their IDs
+// @Tags         questions
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeleteQuestionsRequest  true  "List of question IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /questions [delete]
+func BulkDeleteQuestionsHandler(c *fiber.Ctx) error {
+	var req DeleteQuestionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	ctx := context.Background()
+	if err := db.ProductQueries.BulkDeleteQuestions(ctx, req.IDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,39 @@
+package redirect
+
+type PaginatedResponse[T any] struct {
+	Page       int   `json:"page" example:"1"`
+	PageSize   int   `json:"page_size" example:"10"`
+	TotalItems int64 `json:"total_items" example:"125"`
+	TotalPages int   `json:"total_pages" example:"13"`
+	Data       []T   `json:"data"`
+}
+
+type CreateRedirectRequest struct {
+	EntityType string `json:"entity_type" validate:"required,oneof=product collection page" example:"product"`
+	FromSlug   string `json:"from_slug" validate:"required"`
+	ToSlug     string `json:"to_slug" validate:"required,nefield=FromSlug"`
+	StatusCode int32  `json:"status_code" validate:"omitempty,oneof=301 302" example:"301"`
+}
+
+type UpdateRedirectRequest struct {
+	EntityType string `json:"entity_type" validate:"required,oneof=product collection page" example:"product"`
+	FromSlug   string `json:"from_slug" validate:"required"`
+	ToSlug     string `json:"to_slug" validate:"required,nefield=FromSlug"`
+	StatusCode int32  `json:"status_code" validate:"omitempty,oneof=301 302" example:"301"`
+}
+
+type CreatePostRedirectRequest struct {
+	FromSlug   string `json:"from_slug" validate:"required"`
+	ToSlug     string `json:"to_slug" validate:"required,nefield=FromSlug"`
+	StatusCode int32  `json:"status_code" validate:"omitempty,oneof=301 302" example:"301"`
+}
+
+type UpdatePostRedirectRequest struct {
+	FromSlug   string `json:"from_slug" validate:"required"`
+	ToSlug     string `json:"to_slug" validate:"required,nefield=FromSlug"`
+	StatusCode int32  `json:"status_code" validate:"omitempty,oneof=301 302" example:"301"`
+}
+
+type DeleteRedirectsRequest struct {
+	IDs []int64 `json:"ids"`
+}
@@ -0,0 +1,540 @@
+package redirect
+
+import (
+	"app/internal/db"
+	blog_db "app/internal/db/blog"
+	product_db "app/internal/db/product"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	EntityProduct    = "product"
+	EntityCollection = "collection"
+	EntityPost       = "post"
+	EntityPage       = "page"
+)
+
+// GetRedirectsHandler godoc
+// @Summary      Get redirect list
+// @Description  Returns a list of product, collection and page slug redirects
+// @Tags         redirects
+// @Security BearerAuth
+// @Produce      json
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Router       /redirects [get]
+func GetRedirectsHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetRedirects(ctx, product_db.GetRedirectsParams{
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountRedirects(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetRedirectsRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+// GetRedirectHandler godoc
+// @Summary      Get a redirect
+// @Description  Returns a redirect by ID
+// @Tags         redirects
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects/{id} [get]
+func GetRedirectHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetRedirect(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// CreateRedirectHandler godoc
+// @Summary      Create a new redirect
+// @Description  Creates a manual product, collection or page slug redirect
+// @Tags         redirects
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body	CreateRedirectRequest  true  "Create data"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects [post]
+func CreateRedirectHandler(c *fiber.Ctx) error {
+	var req CreateRedirectRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if req.StatusCode == 0 {
+		req.StatusCode = fiber.StatusMovedPermanently
+	}
+
+	ctx := context.Background()
+	redirectID, err := db.ProductQueries.CreateRedirect(ctx, product_db.CreateRedirectParams{
+		EntityType: req.EntityType,
+		FromSlug:   req.FromSlug,
+		ToSlug:     req.ToSlug,
+		StatusCode: req.StatusCode,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id": redirectID,
+	})
+}
+
+// UpdateRedirectHandler godoc
+// @Summary      Update a redirect
+// @Description  Updates a redirect
+// @Tags         redirects
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Param        payload  body	UpdateRedirectRequest  true  "Update data"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects/{id} [put]
+func UpdateRedirectHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req UpdateRedirectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if req.StatusCode == 0 {
+		req.StatusCode = fiber.StatusMovedPermanently
+	}
+
+	ctx := context.Background()
+	params := product_db.UpdateRedirectParams{
+		ID:         id,
+		EntityType: req.EntityType,
+		FromSlug:   req.FromSlug,
+		ToSlug:     req.ToSlug,
+		StatusCode: req.StatusCode,
+	}
+	if err := db.ProductQueries.UpdateRedirect(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// BulkDeleteRedirectsHandler godoc
+// @Summary      Delete multiple redirects
+// @Description  Deletes multiple redirects by their IDs
+// @Tags         redirects
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeleteRedirectsRequest  true  "List of redirect IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects [delete]
+func BulkDeleteRedirectsHandler(c *fiber.Ctx) error {
+	var req DeleteRedirectsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	ctx := context.Background()
+	if err := db.ProductQueries.BulkDeleteRedirects(ctx, req.IDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// GetPostRedirectsHandler godoc
+// @Summary      Get post redirect list
+// @Description  Returns a list of post slug redirects
+// @Tags         redirects
+// @Security BearerAuth
+// @Produce      json
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Router       /redirects/posts [get]
+func GetPostRedirectsHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.BlogQueries.GetPostRedirects(ctx, blog_db.GetPostRedirectsParams{
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.BlogQueries.CountPostRedirects(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[blog_db.GetPostRedirectsRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+// GetPostRedirectHandler godoc
+// @Summary      Get a post redirect
+// @Description  Returns a post redirect by ID
+// @Tags         redirects
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects/posts/{id} [get]
+func GetPostRedirectHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	ctx := context.Background()
+	result, err := db.BlogQueries.GetPostRedirect(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// CreatePostRedirectHandler godoc
+// @Summary      Create a new post redirect
+// @Description  Creates a manual post slug redirect
+// @Tags         redirects
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body	CreatePostRedirectRequest  true  "Create data"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects/posts [post]
+func CreatePostRedirectHandler(c *fiber.Ctx) error {
+	var req CreatePostRedirectRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if req.StatusCode == 0 {
+		req.StatusCode = fiber.StatusMovedPermanently
+	}
+
+	ctx := context.Background()
+	redirectID, err := db.BlogQueries.CreatePostRedirect(ctx, blog_db.CreatePostRedirectParams{
+		FromSlug:   req.FromSlug,
+		ToSlug:     req.ToSlug,
+		StatusCode: req.StatusCode,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id": redirectID,
+	})
+}
+
+// UpdatePostRedirectHandler godoc
+// @Summary      Update a post redirect
+// @Description  Updates a post redirect
+// @Tags         redirects
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "id"
+// @Param        payload  body	UpdatePostRedirectRequest  true  "Update data"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects/posts/{id} [put]
+func UpdatePostRedirectHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+	var req UpdatePostRedirectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if req.StatusCode == 0 {
+		req.StatusCode = fiber.StatusMovedPermanently
+	}
+
+	ctx := context.Background()
+	params := blog_db.UpdatePostRedirectParams{
+		ID:         id,
+		FromSlug:   req.FromSlug,
+		ToSlug:     req.ToSlug,
+		StatusCode: req.StatusCode,
+	}
+	if err := db.BlogQueries.UpdatePostRedirect(ctx, params); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// BulkDeletePostRedirectsHandler godoc
+// @Summary      Delete multiple post redirects
+// @Description  Deletes multiple post redirects by their IDs
+// @Tags         redirects
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeleteRedirectsRequest  true  "List of redirect IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /redirects/posts [delete]
+func BulkDeletePostRedirectsHandler(c *fiber.Ctx) error {
+	var req DeleteRedirectsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	ctx := context.Background()
+	if err := db.BlogQueries.BulkDeletePostRedirects(ctx, req.IDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// RecordSlugChange stores a redirect from oldSlug to newSlug for a product,
+// collection or page. Existing redirects pointing at oldSlug are moved to
+// newSlug so every old link resolves in a single hop, and a redirect away
+// from newSlug is dropped so it can't shadow the live entity. q should be
+// the transaction that renames the entity, so a failure here undoes the
+// rename too.
+func RecordSlugChange(ctx context.Context, q *product_db.Queries, entityType, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	if err := q.DeleteRedirectBySlug(ctx, product_db.DeleteRedirectBySlugParams{
+		EntityType: entityType,
+		FromSlug:   newSlug,
+	}); err != nil {
+		return err
+	}
+
+	if err := q.RetargetRedirects(ctx, product_db.RetargetRedirectsParams{
+		NewSlug:    newSlug,
+		EntityType: entityType,
+		OldSlug:    oldSlug,
+	}); err != nil {
+		return err
+	}
+
+	return q.UpsertRedirect(ctx, product_db.UpsertRedirectParams{
+		EntityType: entityType,
+		FromSlug:   oldSlug,
+		ToSlug:     newSlug,
+	})
+}
+
+// RecordPostSlugChange is RecordSlugChange for posts, whose redirects live
+// in the blog database next to them.
+func RecordPostSlugChange(ctx context.Context, q *blog_db.Queries, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	if err := q.DeletePostRedirectBySlug(ctx, newSlug); err != nil {
+		return err
+	}
+
+	if err := q.RetargetPostRedirects(ctx, blog_db.RetargetPostRedirectsParams{
+		NewSlug: newSlug,
+		OldSlug: oldSlug,
+	}); err != nil {
+		return err
+	}
+
+	return q.UpsertPostRedirect(ctx, blog_db.UpsertPostRedirectParams{
+		FromSlug: oldSlug,
+		ToSlug:   newSlug,
+	})
+}
+
+// SendRedirect answers a slug lookup that found no entity. If the slug has
+// moved it responds with the redirect status, a Location header relative to
+// the current slug endpoint and the new slug in the body; otherwise 404.
+func SendRedirect(c *fiber.Ctx, entityType, slug string) error {
+	toSlug, statusCode, err := lookupRedirect(context.Background(), entityType, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Location(toSlug)
+	return c.Status(int(statusCode)).JSON(fiber.Map{
+		"redirect":    toSlug,
+		"status_code": statusCode,
+	})
+}
+
+// lookupRedirect finds where slug moved to, in the blog database for posts
+// and the product database otherwise.
+func lookupRedirect(ctx context.Context, entityType, slug string) (string, int32, error) {
+	if entityType == EntityPost {
+		result, err := db.BlogQueries.GetPostRedirectBySlug(ctx, slug)
+		return result.ToSlug, result.StatusCode, err
+	}
+	result, err := db.ProductQueries.GetRedirectBySlug(ctx, product_db.GetRedirectBySlugParams{
+		EntityType: entityType,
+		FromSlug:   slug,
+	})
+	return result.ToSlug, result.StatusCode, err
+}
@@ -1,5 +1,11 @@
 package review
 
+import (
+	"app/internal/config"
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -25,3 +31,39 @@ type AverageRatingResponse[T any] struct {
 type DeleteReviewsRequest struct {
 	IDs []int64 `json:"ids"`
 }
+
+// Customer is the customer json column of the review queries.
+type Customer struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Avatar    *string `json:"avatar"`
+	AvatarURL string  `json:"avatar_url"`
+}
+
+type ProductReview struct {
+	product_db.GetReviewsByProductIDRow
+	Files          []string              `json:"files"`
+	FileURLs       []string              `json:"file_urls"`
+	FileRenditions []*storage.Renditions `json:"file_renditions"`
+	Customer       *Customer             `json:"customer"`
+}
+
+type ReviewWithFiles struct {
+	product_db.GetReviewFilesByProductRow
+	Files          []string              `json:"files"`
+	FileURLs       []string              `json:"file_urls"`
+	FileRenditions []*storage.Renditions `json:"file_renditions"`
+	Customer       *Customer             `json:"customer"`
+}
+
+// decodeCustomer decodes the customer json column of a review row.
+func decodeCustomer(column interface{}) (*Customer, error) {
+	var customer *Customer
+	if err := storage.DecodeJSON(column, &customer); err != nil {
+		return nil, err
+	}
+	if customer != nil && customer.Avatar != nil {
+		customer.AvatarURL = config.FileURL(*customer.Avatar)
+	}
+	return customer, nil
+}
@@ -1,8 +1,10 @@
 package review
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/storage"
 	"context"
 	"fmt"
 	"math"
@@ -24,7 +26,7 @@ import (
 // @Param        rating      query     int   false  "Filter by rating"
 // @Param        has_image   query     bool  false  "Filter by whether review has image (true/false)"
 // @Param        sort_flag   query     int   false  "Sort by created_at (1 = newest, 0 = oldest)"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  PaginatedResponse[ProductReview]
 // @Router       /reviews/products/{id} [get]
 func GetReviewsByProductHandler(c *fiber.Ctx) error {
 	param := c.Params("id")
@@ -80,10 +82,36 @@ func GetReviewsByProductHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	var media storage.ImageSet
+	data := make([]ProductReview, len(reviews))
+	for i, r := range reviews {
+		data[i].GetReviewsByProductIDRow = r
+		if err := storage.DecodeJSON(r.Files, &data[i].Files); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if data[i].Customer, err = decodeCustomer(r.Customer); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		media.Add(data[i].Files...)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for i := range data {
+		data[i].FileURLs = storage.URLs(data[i].Files)
+		data[i].FileRenditions = media.RenditionsOf(data[i].Files)
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	return c.Status(fiber.StatusOK).JSON(PaginatedResponse[product_db.GetReviewsByProductIDRow]{
-		Data:       reviews,
+	return c.Status(fiber.StatusOK).JSON(PaginatedResponse[ProductReview]{
+		Data:       data,
 		Page:       page,
 		PageSize:   pageSize,
 		TotalItems: total,
@@ -105,6 +133,8 @@ func GetReviewsByProductHandler(c *fiber.Ctx) error {
 // @Param 			 files 			 formData  []file   false   "Array of files to upload" collectionFormat multi
 // @Success      201  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]string
+// @Failure      413  {object}  map[string]string
+// @Failure      415  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /reviews [post]
 func CreateReviewHandler(c *fiber.Ctx) error {
@@ -119,14 +149,29 @@ func CreateReviewHandler(c *fiber.Ctx) error {
 		})
 	}
 	files := form.File["files"]
+	if len(files) > config.UploadMaxFiles {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("at most %d files per review", config.UploadMaxFiles),
+		})
+	}
 	ctx := context.Background()
 
+	// Store the images first so a rejected file leaves no review behind.
+	var names []string
+	for _, fh := range files {
+		f, err := storage.Save(ctx, fh, storage.Images)
+		if err != nil {
+			return storage.Reject(c, err)
+		}
+		names = append(names, f.Name)
+	}
+
 	params := product_db.CreateReviewParams{
 		ProductID:  pgtype.Int8{Int64: req.ProductID, Valid: true},
 		Rating:     pgtype.Int4{Int32: int32(req.Rating), Valid: true},
 		Comment:    pgtype.Text{String: req.Comment, Valid: true},
 		CustomerID: req.CustomerID,
-		HasFile:    len(files) > 0,
+		HasFile:    len(names) > 0,
 	}
 
 	id, err := db.ProductQueries.CreateReview(ctx, params)
@@ -137,14 +182,16 @@ func CreateReviewHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	if len(files) > 0 {
-		createReviewFileParams := product_db.BulkInsertReviewFilesParams{}
-		for _, file := range files {
-			fileKey := fmt.Sprintf("%s%s", "review/", file.Filename)
-			createReviewFileParams.Names = append(createReviewFileParams.Names, fileKey)
+	if len(names) > 0 {
+		createReviewFileParams := product_db.BulkInsertReviewFilesParams{Names: names}
+		for range names {
 			createReviewFileParams.ReviewIds = append(createReviewFileParams.ReviewIds, id)
 		}
-		db.ProductQueries.BulkInsertReviewFiles(ctx, createReviewFileParams)
+		if err := db.ProductQueries.BulkInsertReviewFiles(ctx, createReviewFileParams); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -160,7 +207,7 @@ func CreateReviewHandler(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Product ID"
-// @Success      200  {object}  AverageRatingResponse[any]
+// @Success      200  {object}  AverageRatingResponse[ReviewWithFiles]
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
@@ -181,11 +228,31 @@ func GetOverviewByProductHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	response := AverageRatingResponse[product_db.GetReviewFilesByProductRow]{
+	var media storage.ImageSet
+	data := make([]ReviewWithFiles, len(result))
+	for i, r := range result {
+		data[i].GetReviewFilesByProductRow = r
+		if err := storage.DecodeJSON(r.Files, &data[i].Files); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if data[i].Customer, err = decodeCustomer(r.Customer); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		media.Add(data[i].Files...)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	for i := range data {
+		data[i].FileURLs = storage.URLs(data[i].Files)
+		data[i].FileRenditions = media.RenditionsOf(data[i].Files)
+	}
+
+	response := AverageRatingResponse[ReviewWithFiles]{
 		AverageRating: ratingInfo.AverageRating,
 		TotalReviews:  ratingInfo.TotalReviews,
 		TotalFiles:    countFiles,
-		Data:          result,
+		Data:          data,
 	}
 	return c.JSON(response)
 }
@@ -0,0 +1,17 @@
+package role
+
+type CreateRoleRequest struct {
+	Code        string   `json:"code" validate:"required" example:"support"`
+	Name        string   `json:"name" validate:"required" example:"Support"`
+	Permissions []string `json:"permissions" example:"orders.manage,customers.manage"`
+}
+
+type UpdateRoleRequest struct {
+	Code        string   `json:"code" validate:"required" example:"support"`
+	Name        string   `json:"name" validate:"required" example:"Support"`
+	Permissions []string `json:"permissions" example:"orders.manage,customers.manage"`
+}
+
+type DeleteRolesRequest struct {
+	IDs []int64 `json:"ids"`
+}
@@ -0,0 +1,234 @@
+package role
+
+import (
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetRolesHandler godoc
+// @Summary      Get role list
+// @Description  Returns staff roles with their permissions
+// @Tags         roles
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /roles [get]
+func GetRolesHandler(c *fiber.Ctx) error {
+	ctx := context.Background()
+	roles, err := db.AuthQueries.GetRoles(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": roles,
+	})
+}
+
+// GetPermissionsHandler godoc
+// @Summary      Get permission list
+// @Description  Returns every permission that can be granted to a role
+// @Tags         roles
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]string
+// @Router       /roles/permissions [get]
+func GetPermissionsHandler(c *fiber.Ctx) error {
+	ctx := context.Background()
+	permissions, err := db.AuthQueries.GetPermissions(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": permissions,
+	})
+}
+
+// CreateRoleHandler godoc
+// @Summary      Create a new role
+// @Description  Creates a staff role with a set of permissions
+// @Tags         roles
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateRoleRequest  true  "Role data"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /roles [post]
+func CreateRoleHandler(c *fiber.Ctx) error {
+	var req CreateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	if err := checkPermissions(ctx, req.Permissions); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	roleID, err := db.AuthQueries.CreateRole(ctx, auth_db.CreateRoleParams{
+		Code: req.Code,
+		Name: req.Name,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if len(req.Permissions) > 0 {
+		if err := db.AuthQueries.InsertRolePermissions(ctx, auth_db.InsertRolePermissionsParams{
+			RoleID:      roleID,
+			Permissions: req.Permissions,
+		}); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id": roleID,
+	})
+}
+
+// UpdateRoleHandler godoc
+// @Summary      Update a role
+// @Description  Updates a staff role and replaces its permissions. Users get the new permissions on their next token refresh.
+// @Tags         roles
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                true  "Role ID"
+// @Param        payload  body      UpdateRoleRequest  true  "Role data"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /roles/{id} [put]
+func UpdateRoleHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	roleID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req UpdateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	if err := checkPermissions(ctx, req.Permissions); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := db.AuthQueries.UpdateRole(ctx, auth_db.UpdateRoleParams{
+		ID:   roleID,
+		Code: req.Code,
+		Name: req.Name,
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := db.AuthQueries.DeleteRolePermissions(ctx, roleID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if len(req.Permissions) > 0 {
+		if err := db.AuthQueries.InsertRolePermissions(ctx, auth_db.InsertRolePermissionsParams{
+			RoleID:      roleID,
+			Permissions: req.Permissions,
+		}); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// BulkDeleteRolesHandler godoc
+// @Summary      Delete multiple roles
+// @Description  Deletes staff roles by their IDs. The owner role cannot be deleted.
+// @Tags         roles
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeleteRolesRequest  true  "List of role IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /roles [delete]
+func BulkDeleteRolesHandler(c *fiber.Ctx) error {
+	var req DeleteRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	ctx := context.Background()
+	if err := db.AuthQueries.BulkDeleteRoles(ctx, req.IDs); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func checkPermissions(ctx context.Context, codes []string) error {
+	permissions, err := db.AuthQueries.GetPermissions(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		known[p.Code] = true
+	}
+	for _, code := range codes {
+		if !known[code] {
+			return fmt.Errorf("unknown permission %q", code)
+		}
+	}
+	return nil
+}
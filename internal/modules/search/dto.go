@@ -1,5 +1,10 @@
 package search
 
+import (
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+)
+
 type PaginatedResponse[T any] struct {
 	Page       int   `json:"page" example:"1"`
 	PageSize   int   `json:"page_size" example:"10"`
@@ -7,3 +12,9 @@ type PaginatedResponse[T any] struct {
 	TotalPages int   `json:"total_pages" example:"13"`
 	Data       []T   `json:"data"`
 }
+
+type SearchProduct struct {
+	product_db.SearchProductsRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
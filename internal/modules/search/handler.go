@@ -1,8 +1,10 @@
 package search
 
 import (
+	"app/internal/config"
 	"app/internal/db"
 	product_db "app/internal/db/product"
+	"app/internal/storage"
 	"context"
 	"math"
 
@@ -18,7 +20,7 @@ import (
 // @Param        keyword  query     string  false  "Search keyword"
 // @Param        page     query     int     false  "Page number"      default(1)
 // @Param        limit    query     int     false  "Items per page"   default(20)
-// @Success      200      {object}  map[string]interface{}
+// @Success      200      {object}  PaginatedResponse[SearchProduct]
 // @Failure      500      {object}  map[string]interface{}
 // @Router       /search [get]
 func SearchProductsHandler(c *fiber.Ctx) error {
@@ -54,10 +56,28 @@ func SearchProductsHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	var media storage.ImageSet
+	for _, p := range products {
+		media.Add(p.File.String)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]SearchProduct, len(products))
+	for i, p := range products {
+		data[i] = SearchProduct{
+			SearchProductsRow: p,
+			FileURL:           config.FileURL(p.File.String),
+			FileRenditions:    media.Renditions(p.File.String),
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(limit)))
 
-	return c.JSON(PaginatedResponse[product_db.SearchProductsRow]{
-		Data:       products,
+	return c.JSON(PaginatedResponse[SearchProduct]{
+		Data:       data,
 		TotalItems: total,
 		Page:       page,
 		PageSize:   limit,
@@ -0,0 +1,71 @@
+package seo
+
+type Offer struct {
+	Type          string `json:"@type"`
+	URL           string `json:"url"`
+	SKU           string `json:"sku,omitempty"`
+	Image         string `json:"image,omitempty"`
+	Price         int32  `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+}
+
+type AggregateRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float64 `json:"ratingValue"`
+	ReviewCount int64   `json:"reviewCount"`
+}
+
+type ProductSchema struct {
+	Context         string           `json:"@context"`
+	Type            string           `json:"@type"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	URL             string           `json:"url"`
+	SKU             string           `json:"sku,omitempty"`
+	Image           []string         `json:"image,omitempty"`
+	Offers          []Offer          `json:"offers"`
+	AggregateRating *AggregateRating `json:"aggregateRating,omitempty"`
+}
+
+type ListItem struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name,omitempty"`
+	Item     string `json:"item,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type BreadcrumbListSchema struct {
+	Context         string     `json:"@context"`
+	Type            string     `json:"@type"`
+	ItemListElement []ListItem `json:"itemListElement"`
+}
+
+type ItemList struct {
+	Type            string     `json:"@type"`
+	NumberOfItems   int        `json:"numberOfItems"`
+	ItemListElement []ListItem `json:"itemListElement"`
+}
+
+type CollectionPageSchema struct {
+	Context     string   `json:"@context"`
+	Type        string   `json:"@type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url"`
+	Image       string   `json:"image,omitempty"`
+	MainEntity  ItemList `json:"mainEntity"`
+}
+
+type BlogPostingSchema struct {
+	Context          string   `json:"@context"`
+	Type             string   `json:"@type"`
+	Headline         string   `json:"headline"`
+	Description      string   `json:"description,omitempty"`
+	URL              string   `json:"url"`
+	MainEntityOfPage string   `json:"mainEntityOfPage"`
+	Image            []string `json:"image,omitempty"`
+	DatePublished    string   `json:"datePublished,omitempty"`
+	DateModified     string   `json:"dateModified,omitempty"`
+}
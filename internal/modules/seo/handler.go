@@ -0,0 +1,322 @@
+package seo
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	"app/internal/modules/redirect"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	schemaContext = "https://schema.org"
+	currency      = "VND"
+	inStock       = "https://schema.org/InStock"
+	outOfStock    = "https://schema.org/OutOfStock"
+)
+
+// GetProductStructuredDataHandler godoc
+// @Summary      Get product structured data
+// @Description  Returns schema.org Product and BreadcrumbList JSON-LD for a product
+// @Tags         seo
+// @Produce      json
+// @Param        slug   path      string  true  "Product slug"
+// @Success      200  {array}   map[string]interface{}
+// @Success      301  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /products/slug/{slug}/structured-data [get]
+func GetProductStructuredDataHandler(c *fiber.Ctx) error {
+	param := c.Params("slug")
+	ctx := context.Background()
+	product, err := db.ProductQueries.GetProductSeoBySlug(ctx, param)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityProduct, param)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	files, err := db.ProductQueries.GetFilesByProductID(ctx, product.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	variants, err := db.ProductQueries.GetVariantsByProductID(ctx, product.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	url := canonicalURL(product.CanonicalUrl, "products/"+product.Slug)
+
+	images := []string{}
+	if product.OgImage != "" {
+		images = append(images, config.FileURL(product.OgImage))
+	}
+	for _, f := range files {
+		if f.Valid && f.String != "" {
+			images = append(images, config.FileURL(f.String))
+		}
+	}
+
+	offers := make([]Offer, 0, len(variants))
+	for _, v := range variants {
+		offer := Offer{
+			Type:          "Offer",
+			URL:           url + "?variant=" + strconv.FormatInt(v.ID, 10),
+			SKU:           v.Sku,
+			Price:         currentPrice(v.OriginPrice, v.SalePrice),
+			PriceCurrency: currency,
+			Availability:  availability(v.Stock),
+		}
+		if v.File.Valid && v.File.String != "" {
+			offer.Image = config.FileURL(v.File.String)
+		}
+		offers = append(offers, offer)
+	}
+	if len(offers) == 0 {
+		offers = append(offers, Offer{
+			Type:          "Offer",
+			URL:           url,
+			SKU:           product.Sku.String,
+			Price:         currentPrice(product.OriginPrice, product.SalePrice),
+			PriceCurrency: currency,
+			Availability:  availability(product.Stock.Int32),
+		})
+	}
+
+	result := ProductSchema{
+		Context:     schemaContext,
+		Type:        "Product",
+		Name:        product.Name,
+		Description: firstNonEmpty(product.MetaDescription, product.OgDescription),
+		URL:         url,
+		SKU:         product.Sku.String,
+		Image:       images,
+		Offers:      offers,
+	}
+
+	productID := pgtype.Int8{Int64: product.ID, Valid: true}
+	reviewCount, err := db.ProductQueries.CountReviewsByProduct(ctx, productID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if reviewCount > 0 {
+		rating, err := db.ProductQueries.GetAverageRatingByProduct(ctx, productID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		result.AggregateRating = &AggregateRating{
+			Type:        "AggregateRating",
+			RatingValue: math.Round(rating.AverageRating*10) / 10,
+			ReviewCount: rating.TotalReviews,
+		}
+	}
+
+	crumbs := []ListItem{{Name: "Home", Item: config.AbsoluteURL("")}}
+	if product.CategoryID.Valid {
+		categories, err := db.ProductQueries.GetCategoryPath(ctx, product.CategoryID.Int64)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		for _, category := range categories {
+			crumbs = append(crumbs, ListItem{
+				Name: category.Name,
+				Item: config.AbsoluteURL("categories/" + category.Slug),
+			})
+		}
+	}
+	crumbs = append(crumbs, ListItem{Name: product.Name, Item: url})
+
+	return c.Status(fiber.StatusOK).JSON([]any{result, breadcrumbList(crumbs)})
+}
+
+// GetCollectionStructuredDataHandler godoc
+// @Summary      Get collection structured data
+// @Description  Returns schema.org CollectionPage and BreadcrumbList JSON-LD for a collection
+// @Tags         seo
+// @Produce      json
+// @Param        slug   path      string  true  "Collection slug"
+// @Success      200  {array}   map[string]interface{}
+// @Success      301  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /collections/slug/{slug}/structured-data [get]
+func GetCollectionStructuredDataHandler(c *fiber.Ctx) error {
+	param := c.Params("slug")
+	ctx := context.Background()
+	collection, err := db.ProductQueries.GetCollectionSeoBySlug(ctx, param)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityCollection, param)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	products, err := db.ProductQueries.GetProductsByCollection(ctx, collection.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	items := make([]ListItem, 0, len(products))
+	for _, p := range products {
+		if !p.Slug.Valid {
+			continue
+		}
+		items = append(items, ListItem{
+			Type:     "ListItem",
+			Position: len(items) + 1,
+			URL:      config.AbsoluteURL("products/" + p.Slug.String),
+		})
+	}
+
+	url := canonicalURL(collection.CanonicalUrl.String, "collections/"+collection.Slug)
+	image := firstNonEmpty(collection.OgImage.String, collection.File.String)
+	if image != "" {
+		image = config.FileURL(image)
+	}
+
+	result := CollectionPageSchema{
+		Context:     schemaContext,
+		Type:        "CollectionPage",
+		Name:        firstNonEmpty(collection.MetaTitle.String, collection.Name),
+		Description: firstNonEmpty(collection.MetaDescription.String, collection.OgDescription.String),
+		URL:         url,
+		Image:       image,
+		MainEntity: ItemList{
+			Type:            "ItemList",
+			NumberOfItems:   len(items),
+			ItemListElement: items,
+		},
+	}
+
+	crumbs := []ListItem{
+		{Name: "Home", Item: config.AbsoluteURL("")},
+		{Name: collection.Name, Item: url},
+	}
+
+	return c.Status(fiber.StatusOK).JSON([]any{result, breadcrumbList(crumbs)})
+}
+
+// GetPostStructuredDataHandler godoc
+// @Summary      Get post structured data
+// @Description  Returns schema.org BlogPosting and BreadcrumbList JSON-LD for a post
+// @Tags         seo
+// @Produce      json
+// @Param        id   path      string  true  "Post slug"
+// @Success      200  {array}   map[string]interface{}
+// @Success      301  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/slug/{id}/structured-data [get]
+func GetPostStructuredDataHandler(c *fiber.Ctx) error {
+	param := c.Params("id")
+	ctx := context.Background()
+	post, err := db.BlogQueries.GetPostSeoBySlug(ctx, param)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return redirect.SendRedirect(c, redirect.EntityPost, param)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	url := canonicalURL(post.CanonicalUrl, "posts/"+post.Slug)
+
+	images := []string{}
+	if image := firstNonEmpty(post.OgImage, post.File.String); image != "" {
+		images = append(images, config.FileURL(image))
+	}
+
+	result := BlogPostingSchema{
+		Context:          schemaContext,
+		Type:             "BlogPosting",
+		Headline:         firstNonEmpty(post.MetaTitle, post.OgTitle, post.Title),
+		Description:      firstNonEmpty(post.MetaDescription, post.OgDescription),
+		URL:              url,
+		MainEntityOfPage: url,
+		Image:            images,
+		DatePublished:    formatTimestamp(post.PublishedAt),
+		DateModified:     formatTimestamp(post.UpdatedAt),
+	}
+
+	crumbs := []ListItem{
+		{Name: "Home", Item: config.AbsoluteURL("")},
+		{Name: "Blog", Item: config.AbsoluteURL("posts")},
+		{Name: post.Title, Item: url},
+	}
+
+	return c.Status(fiber.StatusOK).JSON([]any{result, breadcrumbList(crumbs)})
+}
+
+func breadcrumbList(items []ListItem) BreadcrumbListSchema {
+	for i := range items {
+		items[i].Type = "ListItem"
+		items[i].Position = i + 1
+	}
+	return BreadcrumbListSchema{
+		Context:         schemaContext,
+		Type:            "BreadcrumbList",
+		ItemListElement: items,
+	}
+}
+
+func canonicalURL(canonical, path string) string {
+	if canonical != "" {
+		return canonical
+	}
+	return config.AbsoluteURL(path)
+}
+
+func currentPrice(originPrice, salePrice int32) int32 {
+	if salePrice > 0 {
+		return salePrice
+	}
+	return originPrice
+}
+
+func availability(stock int32) string {
+	if stock > 0 {
+		return inStock
+	}
+	return outOfStock
+}
+
+func formatTimestamp(t pgtype.Timestamp) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -11,3 +11,7 @@ type CreateUserRequest struct {
 type DeleteUsersRequest struct {
 	IDs []int64 `json:"ids"`
 }
+
+type SetUserRolesRequest struct {
+	RoleIDs []int64 `json:"role_ids"`
+}
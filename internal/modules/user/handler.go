@@ -160,3 +160,119 @@ func DeleteUsersHandler(c *fiber.Ctx) error {
 		"count":   len(req.IDs),
 	})
 }
+
+// GetUserRolesHandler godoc
+// @Summary      Get user roles
+// @Description  Returns the roles assigned to a user
+// @Tags         users
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/{id}/roles [get]
+func GetUserRolesHandler(c *fiber.Ctx) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(c.Params("id")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	ctx := context.Background()
+	roles, err := db.AuthQueries.GetRolesByUser(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": roles,
+	})
+}
+
+// SetUserRolesHandler godoc
+// @Summary      Set user roles
+// @Description  Replaces the roles assigned to a user. The user gets the new permissions on their next token refresh.
+// @Tags         users
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "User ID"
+// @Param        payload  body      SetUserRolesRequest  true  "Role IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/{id}/roles [put]
+func SetUserRolesHandler(c *fiber.Ctx) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(c.Params("id")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	var req SetUserRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	ctx := context.Background()
+	if err := db.AuthQueries.DeleteUserRoles(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if len(req.RoleIDs) > 0 {
+		if err := db.AuthQueries.InsertUserRoles(ctx, auth_db.InsertUserRolesParams{
+			UserID:  userID,
+			RoleIds: req.RoleIDs,
+		}); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// ResetUserTwoFactorHandler godoc
+// @Summary      Reset user two-factor authentication
+// @Description  Turns two-factor authentication off for a user who lost their device and recovery codes, and signs them out everywhere. They enroll again at their next login when it is required.
+// @Tags         users
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/{id}/2fa [delete]
+func ResetUserTwoFactorHandler(c *fiber.Ctx) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(c.Params("id")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid param",
+		})
+	}
+
+	ctx := context.Background()
+	if err := db.AuthQueries.DisableUserTotp(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := db.AuthQueries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := db.AuthQueries.RevokeSessionsByUser(ctx, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,35 @@
+package wishlist
+
+import (
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+)
+
+type PaginatedResponse[T any] struct {
+	Page       int   `json:"page" example:"1"`
+	PageSize   int   `json:"page_size" example:"10"`
+	TotalItems int64 `json:"total_items" example:"125"`
+	TotalPages int   `json:"total_pages" example:"13"`
+	Data       []T   `json:"data"`
+}
+
+type WishlistResponse[T any] struct {
+	TotalItems int `json:"total_items" example:"3"`
+	Data       []T `json:"data"`
+}
+
+type WishlistItem struct {
+	product_db.GetWishlistByCustomerRow
+	FileURL        string              `json:"file_url"`
+	FileRenditions *storage.Renditions `json:"file_renditions"`
+}
+
+type AddWishlistItemRequest struct {
+	ProductID       int64 `json:"product_id" validate:"required" example:"1"`
+	VariantID       int64 `json:"variant_id" example:"0"`
+	NotifyPriceDrop bool  `json:"notify_price_drop" example:"true"`
+}
+
+type DeleteWishlistItemsRequest struct {
+	IDs []int64 `json:"ids" validate:"required,min=1"`
+}
@@ -0,0 +1,295 @@
+package wishlist
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"app/internal/storage"
+	"context"
+	"errors"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetMyWishlistHandler godoc
+// @Summary      Get my wishlist
+// @Description  Returns the logged in customer's wishlist with current price and stock
+// @Tags         wishlists
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  WishlistResponse[WishlistItem]
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/wishlist [get]
+func GetMyWishlistHandler(c *fiber.Ctx) error {
+	customerID, ok := currentCustomerID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "customer token required",
+		})
+	}
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetWishlistByCustomer(ctx, customerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var media storage.ImageSet
+	for _, r := range result {
+		media.Add(r.File)
+	}
+	if err := media.Load(ctx); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	data := make([]WishlistItem, len(result))
+	for i, r := range result {
+		data[i] = WishlistItem{
+			GetWishlistByCustomerRow: r,
+			FileURL:                  config.FileURL(r.File),
+			FileRenditions:           media.Renditions(r.File),
+		}
+	}
+
+	return c.JSON(WishlistResponse[WishlistItem]{
+		TotalItems: len(data),
+		Data:       data,
+	})
+}
+
+// AddWishlistItemHandler godoc
+// @Summary      Add to wishlist
+// @Description  Saves a product or one of its variants to the logged in customer's wishlist. Adding an existing item only updates its price-drop setting.
+// @Tags         wishlists
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      AddWishlistItemRequest  true  "Wishlist item"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/wishlist [post]
+func AddWishlistItemHandler(c *fiber.Ctx) error {
+	customerID, ok := currentCustomerID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "customer token required",
+		})
+	}
+
+	var req AddWishlistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	itemID, err := db.ProductQueries.AddWishlistItem(ctx, product_db.AddWishlistItemParams{
+		CustomerID:      customerID,
+		ProductID:       req.ProductID,
+		VariantID:       pgtype.Int8{Int64: req.VariantID, Valid: req.VariantID != 0},
+		NotifyPriceDrop: req.NotifyPriceDrop,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "product or variant not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      itemID,
+		"message": "wishlist item saved",
+	})
+}
+
+// DeleteWishlistItemsHandler godoc
+// @Summary      Remove from wishlist
+// @Description  Removes items from the logged in customer's wishlist
+// @Tags         wishlists
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  body      DeleteWishlistItemsRequest  true  "List of wishlist item IDs"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/wishlist [delete]
+func DeleteWishlistItemsHandler(c *fiber.Ctx) error {
+	customerID, ok := currentCustomerID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "customer token required",
+		})
+	}
+
+	var req DeleteWishlistItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	err := db.ProductQueries.BulkDeleteWishlistItems(ctx, product_db.BulkDeleteWishlistItemsParams{
+		CustomerID: customerID,
+		Ids:        req.IDs,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// GetMyPriceDropsHandler godoc
+// @Summary      Get my price-drop notifications
+// @Description  Returns price drops recorded for wishlist items the logged in customer asked to be notified about
+// @Tags         wishlists
+// @Security BearerAuth
+// @Produce      json
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /customers/me/wishlist/price-drops [get]
+func GetMyPriceDropsHandler(c *fiber.Ctx) error {
+	customerID, ok := currentCustomerID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "customer token required",
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetWishlistPriceDropsByCustomer(ctx, product_db.GetWishlistPriceDropsByCustomerParams{
+		CustomerID: customerID,
+		Limit:      int32(pageSize),
+		Offset:     int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountWishlistPriceDropsByCustomer(ctx, customerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetWishlistPriceDropsByCustomerRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+// GetMostWishlistedProductsHandler godoc
+// @Summary      Most wishlisted products
+// @Description  Returns products ordered by how many wishlists they appear in
+// @Tags         wishlists
+// @Security BearerAuth
+// @Produce      json
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        page_size query     int     false  "Page size"    default(10)
+// @Success      200  {object}  PaginatedResponse[any]
+// @Failure      500  {object}  map[string]string
+// @Router       /wishlists/report [get]
+func GetMostWishlistedProductsHandler(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	ctx := context.Background()
+	result, err := db.ProductQueries.GetMostWishlistedProducts(ctx, product_db.GetMostWishlistedProductsParams{
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	total, err := db.ProductQueries.CountWishlistedProducts(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return c.JSON(PaginatedResponse[product_db.GetMostWishlistedProductsRow]{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		Data:       result,
+	})
+}
+
+// RecordPriceDrops compares the current price of every wishlist item for a
+// product with the last price seen and stores a price-drop notification for
+// customers who opted in. Call it after a product or its variants change.
+func RecordPriceDrops(ctx context.Context, productID int64) error {
+	drops, err := db.ProductQueries.RecordWishlistPriceDrops(ctx, productID)
+	if err != nil {
+		return err
+	}
+	for _, d := range drops {
+		log.Printf("wishlist price drop: customer %d product %d variant %d %d -> %d",
+			d.CustomerID, d.ProductID, d.VariantID.Int64, d.OldPrice, d.NewPrice)
+	}
+	return nil
+}
+
+func currentCustomerID(c *fiber.Ctx) (int64, bool) {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	id, ok := claims["id"].(float64)
+	return int64(id), ok
+}
@@ -0,0 +1,40 @@
+package otp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Email sends codes through an SMTP server.
+type Email struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewEmail(host, port, username, password, from string) *Email {
+	e := &Email{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+	if username != "" {
+		e.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return e
+}
+
+func (e *Email) Name() string {
+	return "email"
+}
+
+func (e *Email) Send(ctx context.Context, m Message) error {
+	if strings.ContainsAny(m.To, "\r\n") {
+		return fmt.Errorf("email: invalid recipient %q", m.To)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: Your verification code\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		e.from, m.To, m.Text())
+	return smtp.SendMail(e.addr, e.auth, e.from, []string{m.To}, []byte(msg))
+}
@@ -0,0 +1,42 @@
+package otp
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Fake logs codes instead of delivering them and keeps every message so
+// tests can read the code back.
+type Fake struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Send(ctx context.Context, m Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, m)
+	log.Printf("otp: %s code for %s: %s", m.Purpose, m.To, m.Code)
+	return nil
+}
+
+// Last returns the latest message sent to a recipient.
+func (f *Fake) Last(to string) (Message, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.sent) - 1; i >= 0; i-- {
+		if f.sent[i].To == to {
+			return f.sent[i], true
+		}
+	}
+	return Message{}, false
+}
@@ -0,0 +1,169 @@
+package otp
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PurposeVerifyPhone   = "verify_phone"
+	PurposeResetPassword = "reset_password"
+)
+
+const codeLength = 6
+
+var ErrInvalidCode = errors.New("invalid or expired code")
+
+// decoyHash stands in for a code hash when there is none to compare, so a
+// rejection takes as long whether or not a live code exists.
+var decoyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("000000"), bcrypt.DefaultCost)
+	return hash
+})
+
+// LimitError is returned by Send when the customer asks for codes too often.
+// Handlers answer it with 429 and RetryAfter.
+type LimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("too many codes requested, try again in %d seconds", int(e.RetryAfter.Seconds()))
+}
+
+// Send issues a new code for purpose and delivers it to recipient over
+// channel. A new code supersedes earlier ones since Verify only checks the
+// latest. Every delivery attempt is recorded in otp_deliveries.
+func Send(ctx context.Context, customerID int64, purpose, channel, recipient string) error {
+	provider, ok := providers[channel]
+	if !ok {
+		return fmt.Errorf("otp: no provider for channel %s", channel)
+	}
+
+	now := time.Now()
+	stats, err := db.ProductQueries.GetOtpDeliveryStats(ctx, product_db.GetOtpDeliveryStatsParams{
+		CustomerID: customerID,
+		Purpose:    purpose,
+		Since:      pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if stats.LastSentAt.Valid {
+		if wait := stats.LastSentAt.Time.Add(config.OTPResendCooldown).Sub(now); wait > 0 {
+			return &LimitError{RetryAfter: wait}
+		}
+	}
+	if stats.Total >= int64(config.OTPMaxSendsPerHour) {
+		return &LimitError{RetryAfter: time.Hour}
+	}
+
+	code, err := generateCode(codeLength)
+	if err != nil {
+		return err
+	}
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	otpID, err := db.ProductQueries.CreateCustomerOtp(ctx, product_db.CreateCustomerOtpParams{
+		CustomerID: customerID,
+		Purpose:    purpose,
+		CodeHash:   string(codeHash),
+		ExpiresAt:  pgtype.Timestamptz{Time: now.Add(config.OTPTTL), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	sendErr := provider.Send(ctx, Message{
+		Channel: channel,
+		To:      recipient,
+		Code:    code,
+		Purpose: purpose,
+		TTL:     config.OTPTTL,
+	})
+	delivery := product_db.CreateOtpDeliveryParams{
+		OtpID:     otpID,
+		Channel:   channel,
+		Provider:  provider.Name(),
+		Recipient: recipient,
+		Status:    "sent",
+	}
+	if sendErr != nil {
+		delivery.Status = "failed"
+		delivery.Error = pgtype.Text{String: sendErr.Error(), Valid: true}
+	}
+	if err := db.ProductQueries.CreateOtpDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	if sendErr != nil {
+		return fmt.Errorf("otp: %s delivery failed: %w", provider.Name(), sendErr)
+	}
+	return nil
+}
+
+// Verify checks code against the latest code sent for purpose. Every try
+// counts as an attempt, claimed before the comparison so parallel tries
+// can't share one; after config.OTPMaxAttempts the code is dead and a new
+// one must be sent. A correct code is redeemed through q, so a caller that
+// passes a transaction redeems it together with what the code authorizes,
+// and a code redeemed concurrently is rejected. Attempts are counted outside
+// q and stay counted when it rolls back.
+func Verify(ctx context.Context, q *product_db.Queries, customerID int64, purpose, code string) error {
+	claimed, err := db.ProductQueries.ClaimCustomerOtpAttempt(ctx, product_db.ClaimCustomerOtpAttemptParams{
+		CustomerID:  customerID,
+		Purpose:     purpose,
+		MaxAttempts: int32(config.OTPMaxAttempts),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			Discard(code)
+			return ErrInvalidCode
+		}
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(claimed.CodeHash), []byte(code)); err != nil {
+		return ErrInvalidCode
+	}
+
+	used, err := q.UseCustomerOtp(ctx, claimed.ID)
+	if err != nil {
+		return err
+	}
+	if used != 1 {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+// Discard rejects code as Verify would, taking as long. Callers use it for
+// unknown customers so the answer doesn't tell whether an account exists.
+func Discard(code string) {
+	bcrypt.CompareHashAndPassword(decoyHash(), []byte(code))
+}
+
+func generateCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
@@ -0,0 +1,79 @@
+package otp
+
+import (
+	"app/internal/config"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	ChannelPhone = "phone"
+	ChannelEmail = "email"
+)
+
+// Message is one code to deliver. Providers never persist Code.
+type Message struct {
+	Channel string
+	To      string
+	Code    string
+	Purpose string
+	TTL     time.Duration
+}
+
+// Text is the plain text body used by providers without their own template.
+func (m Message) Text() string {
+	return fmt.Sprintf("%s is your verification code. It expires in %d minutes.", m.Code, int(m.TTL.Minutes()))
+}
+
+// Provider delivers codes over one channel.
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, m Message) error
+}
+
+var providers = map[string]Provider{}
+
+// Init selects the phone and email providers from config.
+func Init() {
+	switch config.OTPPhoneProvider {
+	case "zns":
+		if config.ZNSAccessToken == "" || config.ZNSTemplateID == "" {
+			log.Fatal("ZNS_ACCESS_TOKEN and ZNS_TEMPLATE_ID are required for the zns OTP provider")
+		}
+		providers[ChannelPhone] = NewZNS(config.ZNSURL, config.ZNSAccessToken, config.ZNSTemplateID)
+	case "sms":
+		if config.SMSURL == "" {
+			log.Fatal("SMS_URL is required for the sms OTP provider")
+		}
+		providers[ChannelPhone] = NewSMS(config.SMSURL, config.SMSAPIKey, config.SMSSender)
+	case "fake":
+		providers[ChannelPhone] = NewFake()
+	default:
+		log.Fatalf("Unknown OTP_PHONE_PROVIDER %q", config.OTPPhoneProvider)
+	}
+
+	switch config.OTPEmailProvider {
+	case "email":
+		if config.SMTPHost == "" || config.SMTPFrom == "" {
+			log.Fatal("SMTP_HOST and SMTP_FROM are required for the email OTP provider")
+		}
+		providers[ChannelEmail] = NewEmail(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword, config.SMTPFrom)
+	case "fake":
+		providers[ChannelEmail] = NewFake()
+	default:
+		log.Fatalf("Unknown OTP_EMAIL_PROVIDER %q", config.OTPEmailProvider)
+	}
+
+	for channel, p := range providers {
+		if p.Name() == "fake" {
+			log.Printf("OTP codes for %s are logged, not delivered", channel)
+		}
+	}
+}
+
+// SetProvider replaces the provider of a channel, e.g. with a *Fake in tests.
+func SetProvider(channel string, p Provider) {
+	providers[channel] = p
+}
@@ -0,0 +1,62 @@
+package otp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SMS posts codes to an SMS gateway as {"to", "from", "message"} with the API
+// key as a bearer token.
+type SMS struct {
+	url    string
+	apiKey string
+	sender string
+	client *http.Client
+}
+
+func NewSMS(url, apiKey, sender string) *SMS {
+	return &SMS{
+		url:    url,
+		apiKey: apiKey,
+		sender: sender,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *SMS) Name() string {
+	return "sms"
+}
+
+func (s *SMS) Send(ctx context.Context, m Message) error {
+	body, err := json.Marshal(map[string]string{
+		"to":      m.To,
+		"from":    s.sender,
+		"message": m.Text(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("sms: status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package otp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ZNS sends codes as Zalo notification service template messages. The
+// template must have an "otp" parameter.
+type ZNS struct {
+	url         string
+	accessToken string
+	templateID  string
+	client      *http.Client
+}
+
+func NewZNS(url, accessToken, templateID string) *ZNS {
+	return &ZNS{
+		url:         url,
+		accessToken: accessToken,
+		templateID:  templateID,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (z *ZNS) Name() string {
+	return "zns"
+}
+
+func (z *ZNS) Send(ctx context.Context, m Message) error {
+	body, err := json.Marshal(map[string]any{
+		"phone":       internationalPhone(m.To),
+		"template_id": z.templateID,
+		"template_data": map[string]string{
+			"otp": m.Code,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, z.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("access_token", z.accessToken)
+
+	res, err := z.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// ZNS answers 200 with a non-zero error code on failure.
+	var result struct {
+		Error   int    `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("zns: status %d: %w", res.StatusCode, err)
+	}
+	if res.StatusCode != http.StatusOK || result.Error != 0 {
+		return fmt.Errorf("zns: status %d, error %d: %s", res.StatusCode, result.Error, result.Message)
+	}
+	return nil
+}
+
+// internationalPhone turns a local Vietnamese number into the 84... form
+// ZNS expects.
+func internationalPhone(phone string) string {
+	phone = strings.TrimPrefix(strings.TrimSpace(phone), "+")
+	if strings.HasPrefix(phone, "0") {
+		return "84" + phone[1:]
+	}
+	return phone
+}
@@ -0,0 +1,88 @@
+// Package postpublish marks scheduled blog posts as published once their
+// published_at has passed. Public queries show a scheduled post from its
+// published_at on whether or not this has run; it turns the post into an
+// ordinary active one, so the admin lists it as published and it leaves the
+// editorial calendar.
+package postpublish
+
+import (
+	"app/internal/audit"
+	"app/internal/config"
+	"app/internal/db"
+	blog_db "app/internal/db/blog"
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	done = make(chan struct{})
+	wg   sync.WaitGroup
+)
+
+// Run publishes every scheduled post that is due and records each in the
+// audit log.
+func Run(ctx context.Context) ([]blog_db.PublishDuePostsRow, error) {
+	posts, err := db.BlogQueries.PublishDuePosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	before, _ := json.Marshal(map[string]bool{"is_active": false, "is_scheduled": true})
+	after, _ := json.Marshal(map[string]bool{"is_active": true, "is_scheduled": false})
+	for _, p := range posts {
+		err := audit.Record(ctx, audit.Entry{
+			Action:     audit.ActionUpdate,
+			EntityType: "posts",
+			EntityID:   strconv.FormatInt(p.ID, 10),
+			Before:     before,
+			After:      after,
+		})
+		if err != nil {
+			log.Printf("postpublish: audit post %d: %v", p.ID, err)
+		}
+	}
+	return posts, nil
+}
+
+// Start publishes due posts now and then every config.PostPublishInterval,
+// not at all when it is 0.
+func Start() {
+	if config.PostPublishInterval == 0 {
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		publish()
+		ticker := time.NewTicker(config.PostPublishInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				publish()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func Stop() {
+	close(done)
+	wg.Wait()
+}
+
+func publish() {
+	posts, err := Run(context.Background())
+	if err != nil {
+		log.Printf("postpublish: %v", err)
+		return
+	}
+	for _, p := range posts {
+		log.Printf("postpublish: published post %d (%s)", p.ID, p.Slug)
+	}
+}
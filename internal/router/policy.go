@@ -0,0 +1,86 @@
+package router
+
+import (
+	"app/internal/audit"
+	"app/internal/token"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// access registers routes under an explicit authorization policy. Every API
+// route goes through one: public routes carry no guard, protected routes get
+// the guard prepended to their own handler chain so registration order never
+// decides who can call them. Staff writes are also recorded in the audit log.
+type access struct {
+	router fiber.Router
+	policy string
+	guard  fiber.Handler
+	audit  bool
+}
+
+const (
+	policyPublic   = "public"
+	policyStaff    = "staff"
+	policyCustomer = "customer"
+)
+
+// registering holds the policy of the route an access value is adding. The
+// OnRoute hook installed by enforcePolicies rejects any route added without
+// one and records the policy of the others in policies.
+var registering string
+
+// policies maps "METHOD /path" to the policy the route was registered with.
+var policies = map[string]string{}
+
+func publicAccess(r fiber.Router) access {
+	return access{router: r, policy: policyPublic}
+}
+
+func staffAccess(r fiber.Router, permission string) access {
+	return access{router: r, policy: policyStaff, guard: token.Staff(permission), audit: true}
+}
+
+func customerAccess(r fiber.Router) access {
+	return access{router: r, policy: policyCustomer, guard: token.Customer()}
+}
+
+func (a access) Get(path string, handlers ...fiber.Handler) {
+	a.add(fiber.MethodGet, path, handlers)
+}
+
+func (a access) Post(path string, handlers ...fiber.Handler) {
+	a.add(fiber.MethodPost, path, handlers)
+}
+
+func (a access) Put(path string, handlers ...fiber.Handler) {
+	a.add(fiber.MethodPut, path, handlers)
+}
+
+func (a access) Delete(path string, handlers ...fiber.Handler) {
+	a.add(fiber.MethodDelete, path, handlers)
+}
+
+func (a access) add(method, path string, handlers []fiber.Handler) {
+	if a.audit && method != fiber.MethodGet {
+		handlers = append([]fiber.Handler{audit.Middleware()}, handlers...)
+	}
+	if a.guard != nil {
+		handlers = append([]fiber.Handler{a.guard}, handlers...)
+	}
+	registering = a.policy
+	defer func() { registering = "" }()
+	a.router.Add(method, path, handlers...)
+}
+
+// enforcePolicies makes registering a route without an access policy panic,
+// so a route mounted straight on a fiber.Router stops the server at startup.
+func enforcePolicies(app *fiber.App) {
+	app.Hooks().OnRoute(func(r fiber.Route) error {
+		if registering == "" {
+			return fmt.Errorf("router: %s %s has no access policy", r.Method, r.Path)
+		}
+		policies[r.Method+" "+r.Path] = registering
+		return nil
+	})
+}
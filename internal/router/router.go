@@ -1,11 +1,14 @@
 package router
 
 import (
+	"app/internal/modules/attribute"
+	auditlog "app/internal/modules/audit-log"
 	"app/internal/modules/auth"
 	"app/internal/modules/category"
 	"app/internal/modules/collection"
 	"app/internal/modules/customer"
 	"app/internal/modules/discount"
+	"app/internal/modules/feed"
 	"app/internal/modules/file"
 	"app/internal/modules/hotspot"
 	"app/internal/modules/menu"
@@ -13,179 +16,290 @@ import (
 	"app/internal/modules/page"
 	"app/internal/modules/post"
 	"app/internal/modules/product"
+	productview "app/internal/modules/product-view"
+	"app/internal/modules/question"
+	"app/internal/modules/redirect"
 	"app/internal/modules/review"
+	"app/internal/modules/role"
 	"app/internal/modules/search"
+	"app/internal/modules/seo"
 	shippingfee "app/internal/modules/shipping-fee"
 	"app/internal/modules/user"
+	"app/internal/modules/wishlist"
 
-	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 )
 
 func Init(app *fiber.App) {
+	enforcePolicies(app)
+
 	v1 := app.Group("/api/v1")
 
 	authGroup := v1.Group("/auth")
-	authGroup.Post("/login", auth.LoginHandler)
-	authGroup.Post("/register", auth.RegisterHandler)
-
-	userGroup := v1.Group("/users")
-	userGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
+	publicAccess(authGroup).Post("/login", auth.LoginHandler)
+	publicAccess(authGroup).Post("/refresh", auth.RefreshHandler)
+	staffAccess(authGroup, "").Post("/logout", auth.LogoutHandler)
+	staffAccess(authGroup, "").Get("/sessions", auth.GetSessionsHandler)
+	staffAccess(authGroup, "").Delete("/sessions", auth.RevokeOtherSessionsHandler)
+	staffAccess(authGroup, "").Delete("/sessions/:id", auth.RevokeSessionHandler)
+	publicAccess(authGroup).Post("/login/2fa", auth.LoginTwoFactorHandler)
+	staffAccess(authGroup, "").Get("/2fa", auth.GetTwoFactorHandler)
+	staffAccess(authGroup, "").Post("/2fa/setup", auth.SetupTwoFactorHandler)
+	staffAccess(authGroup, "").Post("/2fa/enable", auth.EnableTwoFactorHandler)
+	staffAccess(authGroup, "").Post("/2fa/disable", auth.DisableTwoFactorHandler)
+	staffAccess(authGroup, "").Post("/2fa/recovery-codes", auth.RegenerateRecoveryCodesHandler)
+	staffAccess(authGroup, auth.PermUsers).Get("/settings", auth.GetSecuritySettingsHandler)
+	staffAccess(authGroup, auth.PermUsers).Put("/settings", auth.UpdateSecuritySettingsHandler)
+	staffAccess(authGroup, auth.PermUsers).Get("/api-keys", auth.GetAPIKeysHandler)
+	staffAccess(authGroup, auth.PermUsers).Post("/api-keys", auth.CreateAPIKeyHandler)
+	staffAccess(authGroup, auth.PermUsers).Delete("/api-keys/:id", auth.RevokeAPIKeyHandler)
+	staffAccess(authGroup, auth.PermUsers).Post("/register", auth.RegisterHandler)
+	staffAccess(authGroup, auth.PermUsers).Get("/lockouts", auth.GetLockoutsHandler)
+	staffAccess(authGroup, auth.PermUsers).Delete("/lockouts", auth.UnlockHandler)
+
+	userGroup := staffAccess(v1.Group("/users"), auth.PermUsers)
 	userGroup.Get("/", user.GetUsersHandler)
 	userGroup.Get("/:id", user.GetUserHandler)
 	userGroup.Post("/", user.CreateUserHandler)
 	userGroup.Delete("/", user.DeleteUsersHandler)
+	userGroup.Get("/:id/roles", user.GetUserRolesHandler)
+	userGroup.Put("/:id/roles", user.SetUserRolesHandler)
+	userGroup.Delete("/:id/2fa", user.ResetUserTwoFactorHandler)
+
+	roleGroup := staffAccess(v1.Group("/roles"), auth.PermUsers)
+	roleGroup.Get("/", role.GetRolesHandler)
+	roleGroup.Get("/permissions", role.GetPermissionsHandler)
+	roleGroup.Post("/", role.CreateRoleHandler)
+	roleGroup.Put("/:id", role.UpdateRoleHandler)
+	roleGroup.Delete("/", role.BulkDeleteRolesHandler)
 
 	productGroup := v1.Group("/products")
-	productGroup.Get("/slug/:slug", product.GetProductBySlugHandler)
-	productGroup.Get("/categories/:id", product.GetProductByCategoryHandler)
-
-	productGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
-	productGroup.Get("/", product.GetProductsHandler)
-	productGroup.Get("/:id", product.GetProductHandler)
-	productGroup.Post("/", product.CreateProductHandler)
-	productGroup.Put("/:id", product.UpdateProductHandler)
-	productGroup.Delete("/", product.DeleteProductsHandler)
-
-	categoryGroup := v1.Group("/categories")
-	categoryGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
+	productPublic := publicAccess(productGroup)
+	productPublic.Get("/slug/:slug", product.GetProductBySlugHandler)
+	productPublic.Get("/slug/:slug/structured-data", seo.GetProductStructuredDataHandler)
+	productPublic.Get("/categories/:id", product.GetProductByCategoryHandler)
+	productPublic.Get("/:id/questions", question.GetQuestionsByProductHandler)
+	productPublic.Post("/:id/views", productview.TrackViewLimiter(), productview.TrackViewHandler)
+	customerAccess(productGroup).Post("/:id/questions", question.CreateQuestionHandler)
+
+	productAdmin := staffAccess(productGroup, auth.PermCatalog)
+	productAdmin.Get("/", product.GetProductsHandler)
+	productAdmin.Get("/:id", product.GetProductHandler)
+	productAdmin.Post("/", product.CreateProductHandler)
+	productAdmin.Put("/:id", product.UpdateProductHandler)
+	productAdmin.Delete("/", product.DeleteProductsHandler)
+
+	categoryGroup := staffAccess(v1.Group("/categories"), auth.PermCatalog)
 	categoryGroup.Get("/", category.GetCategoriesHandler)
 	categoryGroup.Get("/:id", category.GetCategoryHandler)
 	categoryGroup.Post("/", category.CreateCategoryHandler)
 	categoryGroup.Put("/:id", category.UpdateCategoryHandler)
 	categoryGroup.Delete("/", category.DeleteCategoriesHandler)
 
-	collectionGroup := v1.Group("/collections")
-	collectionGroup.Get("/hero", collection.GetHeroCollectionsHandler)
-	collectionGroup.Get("/home", collection.GetHomeCollectionsHandler)
-	collectionGroup.Get("/slug/:slug", collection.GetCollectionBySlugHandler)
-	collectionGroup.Get("/:id/products", collection.GetProductsHandler)
-
-	collectionGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
-	collectionGroup.Get("/", collection.GetCollectionsHandler)
-	collectionGroup.Get("/:id", collection.GetCollectionHandler)
-	collectionGroup.Post("/", collection.CreateCollectionHandler)
-	collectionGroup.Put("/:id", collection.UpdateCollectionHandler)
-	collectionGroup.Delete("/", collection.DeleteCollectionsHandler)
-
-	v1.Get("/count-orders", order.CountOrderHandler)
+	attributeGroup := v1.Group("/attributes")
+	publicAccess(attributeGroup).Get("/categories/:id", attribute.GetAttributesByCategoryHandler)
 
-	orderGroup := v1.Group("/orders")
-	orderGroup.Get("/", order.GetOrdersHandler)
+	attributeAdmin := staffAccess(attributeGroup, auth.PermCatalog)
+	attributeAdmin.Get("/", attribute.GetAttributesHandler)
+	attributeAdmin.Get("/:id", attribute.GetAttributeHandler)
+	attributeAdmin.Post("/", attribute.CreateAttributeHandler)
+	attributeAdmin.Put("/:id", attribute.UpdateAttributeHandler)
+	attributeAdmin.Delete("/", attribute.BulkDeleteAttributesHandler)
+	attributeAdmin.Get("/categories/:id/assigned", attribute.GetCategoryAttributesHandler)
+	attributeAdmin.Put("/categories/:id", attribute.SetCategoryAttributesHandler)
 
-	orderGroup.Get("/:id", order.GetOrderHandler)
-	orderGroup.Get("/:id/success", order.CheckOrderCreatedHandler)
-	orderGroup.Post("/", order.CreateOrderHandler)
+	collectionGroup := v1.Group("/collections")
+	collectionPublic := publicAccess(collectionGroup)
+	collectionPublic.Get("/hero", collection.GetHeroCollectionsHandler)
+	collectionPublic.Get("/home", collection.GetHomeCollectionsHandler)
+	collectionPublic.Get("/slug/:slug", collection.GetCollectionBySlugHandler)
+	collectionPublic.Get("/slug/:slug/structured-data", seo.GetCollectionStructuredDataHandler)
+	collectionPublic.Get("/:id/products", collection.GetProductsHandler)
+
+	collectionAdmin := staffAccess(collectionGroup, auth.PermCatalog)
+	collectionAdmin.Get("/", collection.GetCollectionsHandler)
+	collectionAdmin.Get("/:id", collection.GetCollectionHandler)
+	collectionAdmin.Post("/", collection.CreateCollectionHandler)
+	collectionAdmin.Put("/:id", collection.UpdateCollectionHandler)
+	collectionAdmin.Delete("/", collection.DeleteCollectionsHandler)
+
+	staffAccess(v1, auth.PermOrders).Get("/count-orders", order.CountOrderHandler)
 
-	orderGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
+	orderGroup := v1.Group("/orders")
+	orderPublic := publicAccess(orderGroup)
+	orderPublic.Get("/:id/success", order.CheckOrderCreatedHandler)
+	orderPublic.Post("/", order.CreateOrderHandler)
 
-	orderGroup.Put("/:id/status", order.UpdateOrderStatusHandler)
-	orderGroup.Delete("/", order.DeleteOrdersHandler)
+	orderAdmin := staffAccess(orderGroup, auth.PermOrders)
+	orderAdmin.Get("/", order.GetOrdersHandler)
+	orderAdmin.Get("/:id", order.GetOrderHandler)
+	orderAdmin.Put("/:id/status", order.UpdateOrderStatusHandler)
+	orderAdmin.Delete("/", order.DeleteOrdersHandler)
 
 	reviewGroup := v1.Group("/reviews")
-	reviewGroup.Post("/", review.CreateReviewHandler)
-	reviewGroup.Delete("/", review.BulkDeleteReviewsHandler)
-
-	reviewGroup.Get("/products/:id", review.GetReviewsByProductHandler)
-	reviewGroup.Get("/products/:id/overview", review.GetOverviewByProductHandler)
+	reviewPublic := publicAccess(reviewGroup)
+	reviewPublic.Post("/", review.CreateReviewHandler)
+	reviewPublic.Get("/products/:id", review.GetReviewsByProductHandler)
+	reviewPublic.Get("/products/:id/overview", review.GetOverviewByProductHandler)
+	staffAccess(reviewGroup, auth.PermCatalog).Delete("/", review.BulkDeleteReviewsHandler)
 
 	customerGroup := v1.Group("/customers")
-
-	customerGroup.Post("/register", customer.RegisterCustomerHandler)
-	customerGroup.Post("/login", customer.CustomerLoginHandler)
-	customerGroup.Post("/verify-phone", customer.VerifyPhoneHandler)
-
-	customerGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
-	customerGroup.Get("/", customer.GetCustomersHandler)
-	customerGroup.Get("/me", customer.GetMeHandler)
-	customerGroup.Post("/me", customer.UpdateMeHandler)
-	customerGroup.Post("/", customer.CreateCustomerHandler)
-	customerGroup.Delete("/", customer.BulkDeleteCustomersHandler)
+	customerPublic := publicAccess(customerGroup)
+	customerPublic.Post("/register", customer.RegisterCustomerHandler)
+	customerPublic.Post("/login", customer.CustomerLoginHandler)
+	customerPublic.Post("/refresh", customer.CustomerRefreshHandler)
+	customerPublic.Post("/verify-phone", customer.VerifyPhoneHandler)
+	customerPublic.Post("/verify-phone/send", customer.SendPhoneOtpHandler)
+	customerPublic.Post("/forgot-password", customer.ForgotPasswordHandler)
+	customerPublic.Post("/reset-password", customer.ResetPasswordHandler)
+
+	customerAccess(customerGroup).Post("/logout", customer.CustomerLogoutHandler)
+
+	meGroup := customerAccess(customerGroup.Group("/me"))
+	meGroup.Get("/", customer.GetMeHandler)
+	meGroup.Post("/", customer.UpdateMeHandler)
+	meGroup.Get("/sessions", customer.GetSessionsHandler)
+	meGroup.Delete("/sessions", customer.RevokeOtherSessionsHandler)
+	meGroup.Delete("/sessions/:id", customer.RevokeSessionHandler)
+	meGroup.Get("/wishlist", wishlist.GetMyWishlistHandler)
+	meGroup.Post("/wishlist", wishlist.AddWishlistItemHandler)
+	meGroup.Delete("/wishlist", wishlist.DeleteWishlistItemsHandler)
+	meGroup.Get("/wishlist/price-drops", wishlist.GetMyPriceDropsHandler)
+	meGroup.Get("/recently-viewed", productview.GetRecentlyViewedHandler)
+
+	customerAdmin := staffAccess(customerGroup, auth.PermCustomers)
+	customerAdmin.Get("/", customer.GetCustomersHandler)
+	customerAdmin.Post("/", customer.CreateCustomerHandler)
+	customerAdmin.Delete("/", customer.BulkDeleteCustomersHandler)
+	customerAdmin.Get("/otp-deliveries", customer.GetOtpDeliveriesHandler)
 
 	pageGroup := v1.Group("/pages")
-	pageGroup.Get("/", page.GetPagesHandler)
-	pageGroup.Get("/:id", page.GetPageHandler)
-	pageGroup.Get("/slug/:id", page.GetPageBySlugHandler)
-	pageGroup.Post("/", page.CreatePageHandler)
-	pageGroup.Put("/:id", page.UpdatePageHandler)
-	pageGroup.Delete("/", page.BulkDeletePagesHandler)
+	publicAccess(pageGroup).Get("/slug/:id", page.GetPageBySlugHandler)
+
+	pageAdmin := staffAccess(pageGroup, auth.PermContent)
+	pageAdmin.Get("/", page.GetPagesHandler)
+	pageAdmin.Get("/blocks", page.GetBlockSchemasHandler)
+	pageAdmin.Get("/:id", page.GetPageHandler)
+	pageAdmin.Get("/:id/preview", page.PreviewPageHandler)
+	pageAdmin.Post("/", page.CreatePageHandler)
+	pageAdmin.Put("/:id", page.UpdatePageHandler)
+	pageAdmin.Put("/:id/blocks", page.UpdatePageBlocksHandler)
+	pageAdmin.Post("/:id/publish", page.PublishPageHandler)
+	pageAdmin.Post("/:id/unpublish", page.UnpublishPageHandler)
+	pageAdmin.Delete("/", page.BulkDeletePagesHandler)
 
 	postGroup := v1.Group("/posts")
-	postGroup.Get("/slug/:id", post.GetPostBySlugHandler)
-	postGroup.Get("/public", post.GetPublicPostsHandler)
-
-	postGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
-	postGroup.Get("/", post.GetPostsHandler)
-	postGroup.Get("/:id", post.GetPostHandler)
-	postGroup.Post("/", post.CreatePostHandler)
-	postGroup.Put("/:id", post.UpdatePostHandler)
-	postGroup.Delete("/", post.BulkDeletePostsHandler)
+	postPublic := publicAccess(postGroup)
+	postPublic.Get("/slug/:id", post.GetPostBySlugHandler)
+	postPublic.Get("/slug/:id/structured-data", seo.GetPostStructuredDataHandler)
+	postPublic.Get("/public", post.GetPublicPostsHandler)
+	postPublic.Get("/categories", post.GetPostCategoriesHandler)
+	postPublic.Get("/tags", post.GetPostTagsHandler)
+
+	postAdmin := staffAccess(postGroup, auth.PermContent)
+	postAdmin.Post("/categories", post.CreatePostCategoryHandler)
+	postAdmin.Put("/categories/:id", post.UpdatePostCategoryHandler)
+	postAdmin.Delete("/categories", post.BulkDeletePostCategoriesHandler)
+	postAdmin.Get("/calendar", post.GetPostCalendarHandler)
+	postAdmin.Get("/", post.GetPostsHandler)
+	postAdmin.Get("/:id", post.GetPostHandler)
+	postAdmin.Post("/", post.CreatePostHandler)
+	postAdmin.Put("/:id", post.UpdatePostHandler)
+	postAdmin.Delete("/", post.BulkDeletePostsHandler)
 
 	menuGroup := v1.Group("/menus")
-	menuGroup.Get("/", menu.GetMenusHandler)
-	menuGroup.Get("/:id", menu.GetMenuHandler)
-	menuGroup.Get("/position/:id", menu.GetMenuByPositionHandler)
-	menuGroup.Post("/", menu.CreateMenuHandler)
-	menuGroup.Put("/:id", menu.UpdateMenuHandler)
-	menuGroup.Delete("/", menu.BulkDeleteMenusHandler)
-
-	fileGroup := v1.Group("/files")
+	publicAccess(menuGroup).Get("/position/:id", menu.GetMenuByPositionHandler)
+
+	menuAdmin := staffAccess(menuGroup, auth.PermContent)
+	menuAdmin.Get("/", menu.GetMenusHandler)
+	menuAdmin.Get("/:id", menu.GetMenuHandler)
+	menuAdmin.Post("/", menu.CreateMenuHandler)
+	menuAdmin.Put("/:id", menu.UpdateMenuHandler)
+	menuAdmin.Post("/:id/items", menu.CreateMenuItemHandler)
+	menuAdmin.Put("/:id/items", menu.MoveMenuItemsHandler)
+	menuAdmin.Put("/:id/items/:item_id", menu.UpdateMenuItemHandler)
+	menuAdmin.Delete("/:id/items/:item_id", menu.DeleteMenuItemHandler)
+	menuAdmin.Delete("/", menu.BulkDeleteMenusHandler)
+
+	fileGroup := staffAccess(v1.Group("/files"), auth.PermContent)
 	fileGroup.Get("/", file.GetFilesHandler)
+	fileGroup.Get("/folders", file.GetFoldersHandler)
+	fileGroup.Get("/:id", file.GetFileHandler)
 	fileGroup.Post("/", file.CreateFileHandler)
+	fileGroup.Post("/upload", file.UploadFilesHandler)
+	fileGroup.Put("/move", file.MoveFilesHandler)
+	fileGroup.Put("/:id", file.UpdateFileHandler)
 	fileGroup.Delete("/", file.DeleteFilesHandler)
 
 	discountGroup := v1.Group("/discounts")
-	discountGroup.Get("/public", discount.GetValidDiscountsHandler)
-
-	discountGroup.Get("/", discount.GetDiscountsHandler)
-	discountGroup.Get("/:id", discount.GetDiscountHandler)
-	discountGroup.Put("/:id", discount.UpdateDiscountHandler)
-	discountGroup.Post("/", discount.CreateDiscountHandler)
-	discountGroup.Delete("/", discount.BulkDeleteDiscountsHandler)
-
-	discountGroup.Get("/:discount_id/customers/:customer_id/usage", discount.GetCustomerUsageHandler)
-	discountGroup.Post("/usage", discount.UpsertCustomerUsageHandler)
-
-	discountGroup.Post("/:id/targets", discount.CreateDiscountTargetHandler)
-	discountGroup.Post("/:id/effects", discount.CreateDiscountEffectHandler)
-	discountGroup.Post("/:id/conditions", discount.CreateDiscountConditionHandler)
-	discountGroup.Put("/:id/effects/:effectID", discount.UpdateDiscountEffectHandler)
-	discountGroup.Put("/:id/conditions/:conditionID", discount.UpdateDiscountConditionHandler)
+	discountPublic := publicAccess(discountGroup)
+	discountPublic.Get("/public", discount.GetValidDiscountsHandler)
+	customerAccess(discountGroup).Get("/:discount_id/customers/:customer_id/usage", discount.GetCustomerUsageHandler)
+	staffAccess(discountGroup, auth.PermOrders).Post("/usage", discount.UpsertCustomerUsageHandler)
+
+	discountAdmin := staffAccess(discountGroup, auth.PermMarketing)
+	discountAdmin.Get("/", discount.GetDiscountsHandler)
+	discountAdmin.Get("/:id", discount.GetDiscountHandler)
+	discountAdmin.Put("/:id", discount.UpdateDiscountHandler)
+	discountAdmin.Post("/", discount.CreateDiscountHandler)
+	discountAdmin.Delete("/", discount.BulkDeleteDiscountsHandler)
+	discountAdmin.Post("/:id/targets", discount.CreateDiscountTargetHandler)
+	discountAdmin.Post("/:id/effects", discount.CreateDiscountEffectHandler)
+	discountAdmin.Post("/:id/conditions", discount.CreateDiscountConditionHandler)
+	discountAdmin.Put("/:id/effects/:effectID", discount.UpdateDiscountEffectHandler)
+	discountAdmin.Put("/:id/conditions/:conditionID", discount.UpdateDiscountConditionHandler)
 
 	hotspotGroup := v1.Group("/hotspots")
-	hotspotGroup.Get("/", hotspot.GetHotspotsHandler)
-	hotspotGroup.Get("/:id", hotspot.GetHotspotHandler)
-	hotspotGroup.Get("/products/:id", hotspot.GetHotspotByProductHandler)
-	hotspotGroup.Post("/", hotspot.CreateHotspotHandler)
-	hotspotGroup.Put("/:id", hotspot.UpdateHotspotHandler)
-	hotspotGroup.Delete("/", hotspot.DeleteHotspotsHandler)
+	publicAccess(hotspotGroup).Get("/products/:id", hotspot.GetHotspotByProductHandler)
+
+	hotspotAdmin := staffAccess(hotspotGroup, auth.PermCatalog)
+	hotspotAdmin.Get("/", hotspot.GetHotspotsHandler)
+	hotspotAdmin.Get("/:id", hotspot.GetHotspotHandler)
+	hotspotAdmin.Post("/", hotspot.CreateHotspotHandler)
+	hotspotAdmin.Put("/:id", hotspot.UpdateHotspotHandler)
+	hotspotAdmin.Delete("/", hotspot.DeleteHotspotsHandler)
 
 	shippingFeeGroup := v1.Group("/shipping-fees")
-	shippingFeeGroup.Get("/weight/:value", shippingfee.GetShippingFeeByWeightHandler)
-
-	shippingFeeGroup.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte("jwt")},
-	}))
-	shippingFeeGroup.Get("/", shippingfee.GetShippingFeesHandler)
-	shippingFeeGroup.Get("/:id", shippingfee.GetShippingFeeHandler)
-	shippingFeeGroup.Post("/", shippingfee.CreateShippingFeeHandler)
-	shippingFeeGroup.Put("/:id", shippingfee.UpdateShippingFeeHandler)
-	shippingFeeGroup.Delete("/", shippingfee.DeleteShippingFeesHandler)
-
-	v1.Get("/search", search.SearchProductsHandler)
+	publicAccess(shippingFeeGroup).Get("/weight/:value", shippingfee.GetShippingFeeByWeightHandler)
+
+	shippingFeeAdmin := staffAccess(shippingFeeGroup, auth.PermOrders)
+	shippingFeeAdmin.Get("/", shippingfee.GetShippingFeesHandler)
+	shippingFeeAdmin.Get("/:id", shippingfee.GetShippingFeeHandler)
+	shippingFeeAdmin.Post("/", shippingfee.CreateShippingFeeHandler)
+	shippingFeeAdmin.Put("/:id", shippingfee.UpdateShippingFeeHandler)
+	shippingFeeAdmin.Delete("/", shippingfee.DeleteShippingFeesHandler)
+
+	redirectGroup := staffAccess(v1.Group("/redirects"), auth.PermContent)
+	redirectGroup.Get("/", redirect.GetRedirectsHandler)
+	redirectGroup.Get("/posts", redirect.GetPostRedirectsHandler)
+	redirectGroup.Get("/posts/:id", redirect.GetPostRedirectHandler)
+	redirectGroup.Post("/posts", redirect.CreatePostRedirectHandler)
+	redirectGroup.Put("/posts/:id", redirect.UpdatePostRedirectHandler)
+	redirectGroup.Delete("/posts", redirect.BulkDeletePostRedirectsHandler)
+	redirectGroup.Get("/:id", redirect.GetRedirectHandler)
+	redirectGroup.Post("/", redirect.CreateRedirectHandler)
+	redirectGroup.Put("/:id", redirect.UpdateRedirectHandler)
+	redirectGroup.Delete("/", redirect.BulkDeleteRedirectsHandler)
+
+	questionGroup := staffAccess(v1.Group("/questions"), auth.PermCatalog)
+	questionGroup.Get("/", question.GetQuestionsHandler)
+	questionGroup.Get("/:id", question.GetQuestionHandler)
+	questionGroup.Put("/:id/answer", question.AnswerQuestionHandler)
+	questionGroup.Put("/:id/status", question.UpdateQuestionStatusHandler)
+	questionGroup.Delete("/", question.BulkDeleteQuestionsHandler)
+
+	staffAccess(v1.Group("/wishlists"), auth.PermReports).Get("/report", wishlist.GetMostWishlistedProductsHandler)
+
+	staffAccess(v1.Group("/product-views"), auth.PermReports).Get("/report", productview.GetViewReportHandler)
+
+	feedGroup := v1.Group("/feeds")
+	feedPublic := publicAccess(feedGroup)
+	feedPublic.Get("/google.xml", feed.GetGoogleFeedHandler)
+	feedPublic.Get("/facebook.csv", feed.GetFacebookFeedHandler)
+	staffAccess(feedGroup, auth.PermMarketing).Post("/regenerate", feed.RegenerateFeedsHandler)
+
+	publicAccess(v1).Get("/search", search.SearchProductsHandler)
+
+	staffAccess(v1.Group("/audit-logs"), auth.PermAudit).Get("/", auditlog.GetAuditLogsHandler)
 
 }
@@ -0,0 +1,54 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// publicWrites are the writes anyone may call: signing in, signing up and
+// recovering an account, and what a storefront visitor does without an
+// account. Adding to it should be a deliberate, reviewed change.
+var publicWrites = map[string]bool{
+	"POST /api/v1/auth/login":                  true,
+	"POST /api/v1/auth/refresh":                true,
+	"POST /api/v1/auth/login/2fa":              true,
+	"POST /api/v1/customers/register":          true,
+	"POST /api/v1/customers/login":             true,
+	"POST /api/v1/customers/refresh":           true,
+	"POST /api/v1/customers/verify-phone":      true,
+	"POST /api/v1/customers/verify-phone/send": true,
+	"POST /api/v1/customers/forgot-password":   true,
+	"POST /api/v1/customers/reset-password":    true,
+	"POST /api/v1/products/:id/views":          true,
+	"POST /api/v1/orders/":                     true,
+	"POST /api/v1/reviews/":                    true,
+}
+
+func TestRoutesHaveAccessPolicy(t *testing.T) {
+	app := fiber.New()
+	Init(app)
+
+	routes := app.GetRoutes(true)
+	if len(routes) == 0 {
+		t.Fatal("no routes registered")
+	}
+	seen := map[string]bool{}
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		seen[key] = true
+		policy := policies[key]
+		switch {
+		case policy == "":
+			t.Errorf("%s has no access policy", key)
+		case r.Method == fiber.MethodGet || r.Method == fiber.MethodHead:
+		case policy == policyPublic && !publicWrites[key]:
+			t.Errorf("%s is a public write; guard it with staffAccess or customerAccess", key)
+		}
+	}
+	for key := range publicWrites {
+		if !seen[key] {
+			t.Errorf("publicWrites lists %s, which is not registered", key)
+		}
+	}
+}
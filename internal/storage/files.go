@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"context"
+	"log"
+)
+
+// Usage is a place a file is referenced from, e.g. the og_image of a
+// collection or one of the files of a product.
+type Usage struct {
+	EntityType string `json:"entity_type"`
+	EntityID   int64  `json:"entity_id"`
+	EntityName string `json:"entity_name"`
+	Field      string `json:"field"`
+}
+
+// Usages looks up where each of the named files is referenced, across the
+// product and blog databases. Files nothing references are left out.
+func Usages(ctx context.Context, names []string) (map[string][]Usage, error) {
+	usages := map[string][]Usage{}
+	if len(names) == 0 {
+		return usages, nil
+	}
+	rows, err := db.ProductQueries.GetFileUsages(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		usages[r.FileName] = append(usages[r.FileName], Usage{
+			EntityType: r.EntityType,
+			EntityID:   r.EntityID,
+			EntityName: r.EntityName,
+			Field:      r.Field,
+		})
+	}
+	posts, err := db.BlogQueries.GetPostFileUsages(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range posts {
+		usages[r.FileName] = append(usages[r.FileName], Usage{
+			EntityType: "post",
+			EntityID:   r.ID,
+			EntityName: r.Title,
+			Field:      r.Field,
+		})
+	}
+	return usages, nil
+}
+
+// Remove deletes files from the files table and then, for the ones stored
+// through Save, their bytes and renditions from the backend. Files only
+// registered by name live elsewhere and are left alone. Storage errors are
+// logged rather than returned: the rows are gone by then and a leftover
+// object costs nothing but space.
+func Remove(ctx context.Context, files []product_db.GetFilesByIDsRow) error {
+	if len(files) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(files))
+	for i, f := range files {
+		ids[i] = f.ID
+	}
+	renditions, err := db.ProductQueries.GetFileRenditionNames(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if err := db.ProductQueries.BulkDeleteFiles(ctx, ids); err != nil {
+		return err
+	}
+
+	names := renditions
+	for _, f := range files {
+		if f.Hash.Valid {
+			names = append(names, f.Name)
+		}
+	}
+	for _, name := range names {
+		if err := Delete(ctx, name); err != nil {
+			log.Printf("storage: delete %s: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"app/internal/config"
+	"app/internal/imaging"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidImage  = errors.New("file is not a readable image")
+	ErrTooManyPixels = fmt.Errorf("image has more than %d pixels", imaging.MaxPixels)
+)
+
+// blurhashWidth is the width images are shrunk to before hashing; the hash
+// only keeps a few low frequencies, so more pixels add nothing.
+const blurhashWidth = 32
+
+type rendition struct {
+	Name     string
+	SizeName string
+	Format   string
+	Width    int
+	Height   int
+	Data     []byte
+}
+
+type processedImage struct {
+	Data       []byte
+	Width      int
+	Height     int
+	Blurhash   string
+	Renditions []rendition
+}
+
+// processImage strips the metadata from an uploaded image and renders it at
+// every configured size narrower than the image, in every configured
+// format. Renditions are named after the original: media/ab/<hash>.jpg gets
+// media/ab/<hash>-thumbnail.webp. GIFs keep all their frames and get no
+// renditions.
+func processImage(data []byte, contentType, name string) (processedImage, error) {
+	img, err := imaging.Decode(data)
+	if err != nil {
+		if errors.Is(err, imaging.ErrTooManyPixels) {
+			return processedImage{}, ErrTooManyPixels
+		}
+		return processedImage{}, ErrInvalidImage
+	}
+	stripped, err := imaging.Strip(data, contentType, config.ImageQuality)
+	if err != nil {
+		return processedImage{}, ErrInvalidImage
+	}
+
+	b := img.Bounds()
+	p := processedImage{
+		Data:     stripped,
+		Width:    b.Dx(),
+		Height:   b.Dy(),
+		Blurhash: imaging.Blurhash(imaging.Resize(img, min(blurhashWidth, b.Dx())), 4, 3),
+	}
+	if contentType == "image/gif" {
+		return p, nil
+	}
+
+	base := strings.TrimSuffix(name, extensions[contentType])
+	for _, size := range config.ImageSizes {
+		if size.Width >= p.Width {
+			continue
+		}
+		resized := imaging.Resize(img, size.Width)
+		for _, format := range config.ImageFormats {
+			out, err := imaging.Encode(resized, format, config.ImageQuality)
+			if err != nil {
+				return processedImage{}, fmt.Errorf("render %s %s: %w", size.Name, format, err)
+			}
+			p.Renditions = append(p.Renditions, rendition{
+				Name:     base + "-" + size.Name + imaging.Extensions[format],
+				SizeName: size.Name,
+				Format:   format,
+				Width:    resized.Bounds().Dx(),
+				Height:   resized.Bounds().Dy(),
+				Data:     out,
+			})
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	"context"
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+// Renditions describes a processed image: its dimensions, blurhash and the
+// resized copies made on upload, both as a srcset per format and as URLs
+// per size name, e.g.
+//
+//	{"width": 2400, "height": 1600, "blurhash": "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+//	 "srcset": {"webp": "https://…-thumbnail.webp 320w, https://…-medium.webp 768w"},
+//	 "sizes": {"thumbnail": {"webp": "https://…-thumbnail.webp"}}}
+type Renditions struct {
+	Width    int32                        `json:"width"`
+	Height   int32                        `json:"height"`
+	Blurhash string                       `json:"blurhash"`
+	Srcset   map[string]string            `json:"srcset"`
+	Sizes    map[string]map[string]string `json:"sizes"`
+}
+
+// ImageSet looks up the renditions of the files one response returns in a
+// single query: Add every name first, Load once, then read them back with
+// Renditions. The zero value is ready to use.
+type ImageSet struct {
+	names  map[string]bool
+	images map[string]*Renditions
+}
+
+// Add queues names for Load. Empty names are ignored.
+func (s *ImageSet) Add(names ...string) {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if s.names == nil {
+			s.names = map[string]bool{}
+		}
+		s.names[name] = true
+	}
+}
+
+// Load looks up the renditions of every name added so far.
+func (s *ImageSet) Load(ctx context.Context) error {
+	if len(s.names) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(s.names))
+	for name := range s.names {
+		list = append(list, name)
+	}
+	rows, err := db.ProductQueries.GetFileRenditionsByNames(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	s.images = map[string]*Renditions{}
+	for _, r := range rows {
+		img := s.images[r.Name]
+		if img == nil {
+			img = &Renditions{
+				Width:    r.Width.Int32,
+				Height:   r.Height.Int32,
+				Blurhash: r.Blurhash.String,
+				Srcset:   map[string]string{},
+				Sizes:    map[string]map[string]string{},
+			}
+			s.images[r.Name] = img
+		}
+		if !r.RenditionName.Valid {
+			continue
+		}
+		url := config.FileURL(r.RenditionName.String)
+		if img.Srcset[r.Format.String] != "" {
+			img.Srcset[r.Format.String] += ", "
+		}
+		img.Srcset[r.Format.String] += fmt.Sprintf("%s %dw", url, r.RenditionWidth.Int32)
+		if img.Sizes[r.SizeName.String] == nil {
+			img.Sizes[r.SizeName.String] = map[string]string{}
+		}
+		img.Sizes[r.SizeName.String][r.Format.String] = url
+	}
+	return nil
+}
+
+// Renditions returns the renditions of a loaded name, or nil when the file
+// is not a processed image.
+func (s *ImageSet) Renditions(name string) *Renditions {
+	return s.images[name]
+}
+
+// RenditionsOf returns the loaded renditions of each name, nil for files
+// that are not processed images.
+func (s *ImageSet) RenditionsOf(names []string) []*Renditions {
+	images := make([]*Renditions, len(names))
+	for i, name := range names {
+		images[i] = s.Renditions(name)
+	}
+	return images
+}
+
+// URLs returns the public URL of each name.
+func URLs(names []string) []string {
+	urls := make([]string, len(names))
+	for i, name := range names {
+		urls[i] = config.FileURL(name)
+	}
+	return urls
+}
+
+// DecodeJSON converts a json column, which pgx scans into maps and slices,
+// into dst so its file fields can be filled in.
+func DecodeJSON(src interface{}, dst any) error {
+	if src == nil {
+		return nil
+	}
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
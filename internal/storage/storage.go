@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"app/internal/config"
+	"context"
+	"errors"
+	"log"
+	"path"
+	"strings"
+	"time"
+)
+
+// Storage keeps the bytes of uploaded files under their file name. Names
+// use forward slashes whatever the backend.
+type Storage interface {
+	Put(ctx context.Context, name string, data []byte, contentType string) error
+	Delete(ctx context.Context, name string) error
+	// List returns every object whose name starts with prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object is a stored file as the backend sees it.
+type Object struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+var ErrInvalidName = errors.New("storage: invalid file name")
+
+var backend Storage
+
+// Init selects the backend from config.
+func Init() {
+	switch config.StorageDriver {
+	case "local":
+		backend = NewLocal(config.StorageLocalDir)
+	case "s3":
+		if config.S3Endpoint == "" || config.S3Bucket == "" {
+			log.Fatal("S3_ENDPOINT and S3_BUCKET are required for the s3 storage driver")
+		}
+		backend = NewS3(config.S3Endpoint, config.S3Region, config.S3Bucket, config.S3AccessKey, config.S3SecretKey, config.S3PathStyle)
+	default:
+		log.Fatalf("Unknown STORAGE_DRIVER %q", config.StorageDriver)
+	}
+}
+
+// SetStorage replaces the backend, e.g. with a local one in a temporary
+// directory in tests.
+func SetStorage(s Storage) {
+	backend = s
+}
+
+func Put(ctx context.Context, name string, data []byte, contentType string) error {
+	if !validName(name) {
+		return ErrInvalidName
+	}
+	return backend.Put(ctx, name, data, contentType)
+}
+
+func Delete(ctx context.Context, name string) error {
+	if !validName(name) {
+		return ErrInvalidName
+	}
+	return backend.Delete(ctx, name)
+}
+
+func List(ctx context.Context, prefix string) ([]Object, error) {
+	return backend.List(ctx, prefix)
+}
+
+// validName rejects names that could leave the storage root.
+func validName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, "/") && path.Clean(name) == name && !strings.HasPrefix(name, "../") && name != ".."
+}
@@ -0,0 +1,263 @@
+package storage
+
+import (
+	"app/internal/config"
+	"app/internal/db"
+	product_db "app/internal/db/product"
+	"app/internal/imaging"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Content types accepted for upload, with the extension stored files get.
+// The type is sniffed from the content; what the client claims is ignored.
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/avif":      ".avif",
+	"application/pdf": ".pdf",
+	"video/mp4":       ".mp4",
+}
+
+// Sets of content types an upload may be restricted to.
+var (
+	Images = []string{"image/jpeg", "image/png", "image/gif", "image/webp", "image/avif"}
+	Media  = append(slices.Clone(Images), "application/pdf", "video/mp4")
+)
+
+var ErrTooLarge = errors.New("file is too large")
+
+// UnsupportedTypeError is returned for content outside the allowed types.
+type UnsupportedTypeError struct {
+	ContentType string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported file type %s", e.ContentType)
+}
+
+// File is a stored upload.
+type File struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Width, Height and Blurhash are set for images.
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Blurhash string `json:"blurhash,omitempty"`
+	// Duplicate is set when the same content was already stored and the
+	// existing file is returned instead.
+	Duplicate bool `json:"duplicate"`
+	// Unprocessed says why an image was stored as uploaded, without
+	// renditions or metadata removal: imaging cannot decode AVIF.
+	Unprocessed string `json:"unprocessed,omitempty"`
+}
+
+// Save stores an uploaded file and records it in the files table. Files are
+// named after the SHA-256 of their content, so uploading the same bytes
+// twice returns the first file. Images are stored without their metadata,
+// along with their renditions, dimensions and blurhash.
+func Save(ctx context.Context, fh *multipart.FileHeader, allowed []string) (File, error) {
+	if fh.Size > int64(config.UploadMaxSize) {
+		return File{}, ErrTooLarge
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return File{}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, int64(config.UploadMaxSize)+1))
+	if err != nil {
+		return File{}, err
+	}
+	if len(data) > config.UploadMaxSize {
+		return File{}, ErrTooLarge
+	}
+
+	contentType := detectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok || !slices.Contains(allowed, contentType) {
+		return File{}, &UnsupportedTypeError{ContentType: contentType}
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if f, err := existingFile(ctx, hash); !errors.Is(err, pgx.ErrNoRows) {
+		return f, err
+	}
+
+	name := "media/" + hash[:2] + "/" + hash + ext
+	var img processedImage
+	if slices.Contains(imaging.Decodable, contentType) {
+		if img, err = processImage(data, contentType, name); err != nil {
+			return File{}, err
+		}
+		data = img.Data
+	}
+	if err := Put(ctx, name, data, contentType); err != nil {
+		return File{}, err
+	}
+	for _, r := range img.Renditions {
+		if err := Put(ctx, r.Name, r.Data, imaging.ContentTypes[r.Format]); err != nil {
+			return File{}, err
+		}
+	}
+
+	// The file and its renditions are recorded together. An upload of the
+	// same bytes that got in first wins, and its file is returned instead.
+	tx, err := db.ProductDBPool.Begin(ctx)
+	if err != nil {
+		return File{}, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := db.ProductQueries.WithTx(tx)
+
+	row, err := qtx.CreateUploadedFile(ctx, product_db.CreateUploadedFileParams{
+		Name:         name,
+		Hash:         pgtype.Text{String: hash, Valid: true},
+		Size:         pgtype.Int8{Int64: int64(len(data)), Valid: true},
+		ContentType:  pgtype.Text{String: contentType, Valid: true},
+		OriginalName: pgtype.Text{String: fh.Filename, Valid: fh.Filename != ""},
+		Width:        pgtype.Int4{Int32: int32(img.Width), Valid: img.Width > 0},
+		Height:       pgtype.Int4{Int32: int32(img.Height), Valid: img.Height > 0},
+		Blurhash:     pgtype.Text{String: img.Blurhash, Valid: img.Blurhash != ""},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		tx.Rollback(ctx)
+		return existingFile(ctx, hash)
+	}
+	if err != nil {
+		return File{}, err
+	}
+	for _, r := range img.Renditions {
+		if err := qtx.CreateFileRendition(ctx, product_db.CreateFileRenditionParams{
+			FileID:   row.ID,
+			Name:     r.Name,
+			SizeName: r.SizeName,
+			Format:   r.Format,
+			Width:    int32(r.Width),
+			Height:   int32(r.Height),
+			Size:     int64(len(r.Data)),
+		}); err != nil {
+			return File{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return File{}, err
+	}
+	return File{
+		ID:          row.ID,
+		Name:        row.Name,
+		URL:         config.FileURL(row.Name),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       img.Width,
+		Height:      img.Height,
+		Blurhash:    img.Blurhash,
+		Unprocessed: unprocessed(contentType),
+	}, nil
+}
+
+// existingFile returns the stored file with content hash as a duplicate,
+// or pgx.ErrNoRows when there is none.
+func existingFile(ctx context.Context, hash string) (File, error) {
+	existing, err := db.ProductQueries.GetFileByHash(ctx, pgtype.Text{String: hash, Valid: true})
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		ID:          existing.ID,
+		Name:        existing.Name,
+		URL:         config.FileURL(existing.Name),
+		ContentType: existing.ContentType.String,
+		Size:        existing.Size.Int64,
+		Width:       int(existing.Width.Int32),
+		Height:      int(existing.Height.Int32),
+		Blurhash:    existing.Blurhash.String,
+		Duplicate:   true,
+		Unprocessed: unprocessed(existing.ContentType.String),
+	}, nil
+}
+
+// unprocessed is File.Unprocessed for an upload of contentType.
+func unprocessed(contentType string) string {
+	if !slices.Contains(Images, contentType) || slices.Contains(imaging.Decodable, contentType) {
+		return ""
+	}
+	return fmt.Sprintf("%s cannot be decoded, so it was stored as uploaded without renditions or metadata removal", contentType)
+}
+
+// detectContentType sniffs data like http.DetectContentType, which does not
+// recognize AVIF.
+func detectContentType(data []byte) string {
+	if isAVIF(data) {
+		return "image/avif"
+	}
+	return http.DetectContentType(data)
+}
+
+// isAVIF reports whether data starts with an ISO BMFF ftyp box whose major
+// or a compatible brand is AVIF.
+func isAVIF(data []byte) bool {
+	if len(data) < 16 || string(data[4:8]) != "ftyp" {
+		return false
+	}
+	size := int(binary.BigEndian.Uint32(data))
+	if size < 16 || size > len(data) {
+		return false
+	}
+	// Brands are 4 bytes each from offset 8, except the minor version at 12.
+	for i := 8; i+4 <= size; i += 4 {
+		if i == 12 {
+			continue
+		}
+		if b := string(data[i : i+4]); b == "avif" || b == "avis" {
+			return true
+		}
+	}
+	return false
+}
+
+// Reject answers a failed Save: 413 for a file over the size or pixel
+// limit, 415 for a type that is not allowed or an image that cannot be read
+// and 500 otherwise.
+func Reject(c *fiber.Ctx, err error) error {
+	var typeErr *UnsupportedTypeError
+	switch {
+	case errors.Is(err, ErrTooLarge):
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": fmt.Sprintf("%s, the limit is %d bytes", err.Error(), config.UploadMaxSize),
+		})
+	case errors.Is(err, ErrTooManyPixels):
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, ErrInvalidImage):
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.As(err, &typeErr):
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"error": typeErr.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
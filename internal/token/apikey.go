@@ -0,0 +1,111 @@
+package token
+
+import (
+	"app/internal/db"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// API keys look like ak_<prefix>_<secret>. The prefix is stored in clear to
+// find the key and to tell keys apart in lists; only a hash of the whole key
+// is stored.
+const apiKeyScheme = "ak"
+
+var prefixEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewAPIKey returns a new API key for the client, its prefix and the hash to
+// store server side.
+func NewAPIKey() (string, string, string, error) {
+	b := make([]byte, 37)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	prefix := strings.ToLower(prefixEncoding.EncodeToString(b[:5]))
+	key := apiKeyScheme + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(b[5:])
+	return key, prefix, HashRefreshToken(key), nil
+}
+
+// apiKeyFromRequest returns the API key sent in the X-API-Key header or as a
+// bearer token.
+func apiKeyFromRequest(c *fiber.Ctx) (string, bool) {
+	key := c.Get("X-API-Key")
+	if key == "" {
+		key, _ = strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	}
+	if !strings.HasPrefix(key, apiKeyScheme+"_") {
+		return "", false
+	}
+	return key, true
+}
+
+// apiKeyClaims resolves an API key to the claims of a staff token acting as
+// the user who created it. The key only carries the permissions it was
+// issued with that its creator still has.
+func apiKeyClaims(ctx context.Context, key string) (jwt.MapClaims, bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 {
+		return nil, false
+	}
+	row, err := db.AuthQueries.GetActiveApiKeyByPrefix(ctx, parts[1])
+	if err != nil {
+		return nil, false
+	}
+	if subtle.ConstantTimeCompare([]byte(row.KeyHash), []byte(HashRefreshToken(key))) != 1 {
+		return nil, false
+	}
+
+	granted, err := db.AuthQueries.GetPermissionsByUser(ctx, row.CreatedBy)
+	if err != nil {
+		return nil, false
+	}
+	permissions := []interface{}{}
+	for _, p := range row.Permissions {
+		if slices.Contains(granted, p) {
+			permissions = append(permissions, p)
+		}
+	}
+
+	if err := db.AuthQueries.TouchApiKey(ctx, row.ID); err != nil {
+		log.Printf("api key %d: %v", row.ID, err)
+	}
+	return jwt.MapClaims{
+		"sub":         row.CreatedBy.String(),
+		"aud":         AudienceStaff,
+		"permissions": permissions,
+		"api_key_id":  row.ID,
+	}, true
+}
+
+// apiKeyGuard is the API key half of Staff. Keys are for integrations, so
+// they only reach routes that name a permission; account routes such as
+// two-factor enrollment need a signed-in user.
+func apiKeyGuard(c *fiber.Ctx, key, permission string) error {
+	claims, ok := apiKeyClaims(context.Background(), key)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid API key",
+		})
+	}
+	if permission == "" || !HasPermission(claims, permission) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "permission denied",
+		})
+	}
+	c.Locals("user", &jwt.Token{Claims: claims, Valid: true})
+	return c.Next()
+}
+
+// IsAPIKey reports whether claims belong to a request made with an API key.
+func IsAPIKey(claims jwt.MapClaims) bool {
+	_, ok := claims["api_key_id"]
+	return ok
+}
@@ -0,0 +1,88 @@
+package token
+
+import (
+	"app/internal/db"
+	auth_db "app/internal/db/auth"
+	product_db "app/internal/db/product"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Every access token belongs to a server-side session, named by its sid
+// claim. Revoking the session stops its access tokens at the next request
+// and its refresh tokens at the next refresh.
+const sessionClaim = "sid"
+
+// Last seen times are written at most this often per session.
+const touchInterval = time.Minute
+
+// SessionID returns the session of an access token.
+func SessionID(claims jwt.MapClaims) (int64, bool) {
+	sid, ok := claims[sessionClaim].(float64)
+	return int64(sid), ok
+}
+
+// WithSession adds the session ID to the claims of a new access token.
+func WithSession(claims jwt.MapClaims, sessionID int64) jwt.MapClaims {
+	claims[sessionClaim] = sessionID
+	return claims
+}
+
+// checkSession reports whether the session of an access token is still
+// active and records the request as its last activity.
+func checkSession(c *fiber.Ctx, claims jwt.MapClaims, audience string) (bool, error) {
+	sessionID, ok := SessionID(claims)
+	if !ok {
+		return false, nil
+	}
+
+	ctx := context.Background()
+	var lastSeen pgtype.Timestamptz
+	var err error
+	switch audience {
+	case AudienceStaff:
+		lastSeen, err = db.AuthQueries.GetActiveSession(ctx, sessionID)
+	case AudienceCustomer:
+		lastSeen, err = db.ProductQueries.GetActiveCustomerSession(ctx, sessionID)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if time.Since(lastSeen.Time) >= touchInterval {
+		if audience == AudienceStaff {
+			err = db.AuthQueries.TouchSession(ctx, auth_db.TouchSessionParams{ID: sessionID, Ip: c.IP()})
+		} else {
+			err = db.ProductQueries.TouchCustomerSession(ctx, product_db.TouchCustomerSessionParams{ID: sessionID, Ip: c.IP()})
+		}
+		if err != nil {
+			log.Printf("session %s/%d: %v", audience, sessionID, err)
+		}
+	}
+	return true, nil
+}
+
+// rejectSession answers a request whose session is gone or could not be
+// checked.
+func rejectSession(c *fiber.Ctx, err error) error {
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"error": "session expired or revoked",
+	})
+}
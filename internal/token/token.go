@@ -0,0 +1,86 @@
+package token
+
+import (
+	"app/internal/config"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Access tokens are issued for one of two audiences so a customer token is
+// never accepted on staff routes and the other way round. AudienceMFA marks
+// the short-lived challenge between the password and the second factor; it
+// is not accepted by any guard.
+const (
+	AudienceStaff    = "staff"
+	AudienceCustomer = "customer"
+	AudienceMFA      = "mfa"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Sign returns an access token for claims and audience, signed with the
+// current key and expiring after config.AccessTokenTTL.
+func Sign(audience string, claims jwt.MapClaims) (string, error) {
+	return SignWithTTL(audience, claims, config.AccessTokenTTL)
+}
+
+// SignWithTTL is Sign with its own lifetime.
+func SignWithTTL(audience string, claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims["aud"] = audience
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	t.Header["kid"] = config.JWTKeyID
+	return t.SignedString(config.JWTKeys[config.JWTKeyID])
+}
+
+// Keyfunc resolves the verification key from the token's kid header. Use it
+// as the KeyFunc of jwtware.Config.
+func Keyfunc(t *jwt.Token) (interface{}, error) {
+	if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	kid, _ := t.Header["kid"].(string)
+	key, ok := config.JWTKeys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// Parse verifies an access token outside of the jwtware middleware.
+func Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, Keyfunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+}
+
+// NewRefreshToken returns an opaque refresh token for the client and the hash
+// to store server side.
+func NewRefreshToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(b)
+	return refreshToken, HashRefreshToken(refreshToken), nil
+}
+
+func HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshExpiry returns the expiry time of a refresh token issued now.
+func RefreshExpiry() time.Time {
+	return time.Now().Add(config.RefreshTokenTTL)
+}
@@ -2,7 +2,17 @@ package main
 
 import (
 	"app/cmd/server"
+	"app/internal/audit"
+	"app/internal/config"
 	"app/internal/db"
+	"app/internal/loginlimit"
+	"app/internal/mediagc"
+	productview "app/internal/modules/product-view"
+	"app/internal/otp"
+	"app/internal/postpublish"
+	"app/internal/storage"
+	"log"
+	"os"
 )
 
 // @title           Swagger Example API
@@ -14,7 +24,34 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	os.Exit(run())
+}
+
+// run starts the app and returns its exit code. Deferred shutdowns run
+// before main exits.
+func run() int {
+	config.Init()
+	otp.Init()
+	storage.Init()
 	db.Init()
 	defer db.Close()
-	server.Serve()
+	if len(os.Args) > 1 && os.Args[1] == "media-gc" {
+		mediagc.Command(os.Args[2:])
+		return 0
+	}
+	productview.Start()
+	defer productview.Stop()
+	loginlimit.Start()
+	defer loginlimit.Stop()
+	audit.Start()
+	defer audit.Stop()
+	mediagc.Start()
+	defer mediagc.Stop()
+	postpublish.Start()
+	defer postpublish.Stop()
+	if err := server.Serve(); err != nil {
+		log.Printf("server: %v", err)
+		return 1
+	}
+	return 0
 }